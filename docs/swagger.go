@@ -15,16 +15,215 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the API keys of the caller's tenant, including revoked ones. The keys themselves are never returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Get all API keys",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIKeyListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIKeyListResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate an API key with the given scopes: read grants the viewer role, write the scanner and reviewer\nroles and admin every role.\nThe key has the form bcr_\u003cid\u003e_\u003csecret\u003e and is only returned here; only its SHA-256 hash is stored.\nKeys belong to the caller's tenant; only the bootstrap key can create keys for another tenant_id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Create an API key",
+                "parameters": [
+                    {
+                        "description": "API key",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.APIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIKeyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIKeyResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIKeyResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIKeyResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api-keys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Disable an API key immediately. The key stays listed with its revocation time.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Revoke an API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIKeyResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIKeyResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIKeyResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/business-cards": {
             "get": {
-                "description": "Retrieve all processed business cards",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve business cards one page at a time, newest first by default. Image bytes are not included;\nuse GET /business-cards/{id} for them. Pass next_cursor back as cursor to get the following page.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "business-cards"
                 ],
-                "summary": "Get all business cards",
+                "summary": "List business cards",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 50, max 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned as next_cursor by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only cards with this status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only cards of this company (case-insensitive exact match)",
+                        "name": "company_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only cards created at or after this RFC 3339 time",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only cards created at or before this RFC 3339 time",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at (default) or processed_at",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "desc (default) or asc",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -32,6 +231,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BusinessCardListResponse"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardListResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -41,9 +246,17 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Upload and process business card images using Gemini AI",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload and process business card images using Gemini AI. In async processing mode the card is\nstored as PENDING and 202 is returned immediately; poll GET /business-cards/{id} for the result.\nUploads are rate limited per API key and per client IP; 429 responses carry a Retry-After header.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
@@ -54,11 +267,13 @@ const docTemplate = `{
                 "summary": "Process business card images",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Business card images (max 2)",
-                        "name": "images",
-                        "in": "formData",
-                        "required": true
+                        "description": "Business card images in base64 format",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardRequestBase64"
+                        }
                     }
                 ],
                 "responses": {
@@ -68,31 +283,57 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BusinessCardResponse"
                         }
                     },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.BusinessCardResponse"
                         }
                     },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.BusinessCardResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
                     }
                 }
             }
         },
-        "/business-cards/failed": {
+        "/business-cards/dead-letter": {
             "get": {
-                "description": "Retrieve all failed business cards",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve business cards that exhausted their automatic retries. They keep their last error and can\nstill be retried manually.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "business-cards"
                 ],
-                "summary": "Get failed business cards",
+                "summary": "Get dead-lettered business cards",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -109,61 +350,120 @@ const docTemplate = `{
                 }
             }
         },
-        "/business-cards/{id}": {
+        "/business-cards/erasure": {
             "get": {
-                "description": "Retrieve a specific business card by its ID",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the stored receipts of every erasure request, oldest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "business-cards"
                 ],
-                "summary": "Get business card by ID",
+                "summary": "List erasure receipts",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErasureReceiptListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErasureReceiptListResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete every business card whose personal or company email matches, including stored images, redact\nthe webhook deliveries that carried them and return an erasure receipt. The receipt is stored for\naudits with a SHA-256 of the email instead of the email. Cards that are being processed are listed\nas skipped and the receipt is marked incomplete; repeat the request once they are done.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "business-cards"
+                ],
+                "summary": "Erase a contact's data",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Business Card ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Name of the person handling the request, used when authentication is off",
+                        "name": "X-User",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Contact to erase",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ErasureRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.BusinessCardResponse"
+                            "$ref": "#/definitions/models.ErasureReceiptResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.BusinessCardResponse"
+                            "$ref": "#/definitions/models.ErasureReceiptResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/models.BusinessCardResponse"
+                            "$ref": "#/definitions/models.ErasureReceiptResponse"
                         }
                     }
                 }
             }
         },
-        "/business-cards/{id}/retry": {
-            "post": {
-                "description": "Retry processing a failed business card",
+        "/business-cards/erasure/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the stored receipt of an erasure request",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "business-cards"
                 ],
-                "summary": "Retry failed business card processing",
+                "summary": "Get an erasure receipt",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Business Card ID",
+                        "description": "Erasure Receipt ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -173,91 +473,2011 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.BusinessCardResponse"
+                            "$ref": "#/definitions/models.ErasureReceiptResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/models.BusinessCardResponse"
+                            "$ref": "#/definitions/models.ErasureReceiptResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/models.BusinessCardResponse"
+                            "$ref": "#/definitions/models.ErasureReceiptResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.Address": {
-            "type": "object",
-            "properties": {
-                "city": {
-                    "type": "string"
-                },
-                "country": {
-                    "type": "string"
-                },
-                "full": {
-                    "type": "string"
-                },
-                "postal_code": {
-                    "type": "string"
-                },
-                "state": {
-                    "type": "string"
-                },
-                "street": {
+        },
+        "/business-cards/events": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Server-Sent Events stream with one \"status\" event per business card transition, for dashboards",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "business-cards"
+                ],
+                "summary": "Stream status changes of all business cards",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardEvent"
+                        }
+                    }
+                }
+            }
+        },
+        "/business-cards/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stream every business card matching the filters as CSV or XLSX. Columns follow a preset (default: every\nfield, salesforce, hubspot or google for their contact import formats) or a custom mapping given as\ncolumns=field:Header, repeated or comma-separated, e.g. columns=personal_data.email:Email,company_data.name:Company.\nOnly COMPLETED cards are exported unless another status is requested.",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "business-cards"
+                ],
+                "summary": "Export business cards as a spreadsheet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv (default) or xlsx",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "default, salesforce, hubspot or google",
+                        "name": "preset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Custom column mapping (field:Header), overrides the preset",
+                        "name": "columns",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only cards with this status (default COMPLETED)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only cards of this company (case-insensitive exact match)",
+                        "name": "company_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only cards created at or after this RFC 3339 time",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only cards created at or before this RFC 3339 time",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at (default) or processed_at",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "desc (default) or asc",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Spreadsheet",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/business-cards/failed": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve all failed business cards",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "business-cards"
+                ],
+                "summary": "Get failed business cards",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/business-cards/import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create business cards without images from contacts. The file is sent as multipart form field \"file\"\nor as the raw request body. CSV files need a header row; field paths such as personal_data.email and\nthe headers of the export presets are recognized. Contacts are normalized like extraction results,\nand duplicates of stored cards or of earlier rows are reported instead of imported.",
+                "consumes": [
+                    "multipart/form-data",
+                    "text/vcard",
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "business-cards"
+                ],
+                "summary": "Import business cards from a vCard or CSV file",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "vCard (.vcf) or CSV file",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "vcard or csv, detected from the file name or content type when omitted",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ImportReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ImportReportResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ImportReportResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/business-cards/vcard": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download every business card matching the filters as one vCard file. Only COMPLETED cards are\nexported unless another status is requested.",
+                "produces": [
+                    "text/vcard"
+                ],
+                "tags": [
+                    "business-cards"
+                ],
+                "summary": "Export business cards as vCards",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "3.0 or 4.0 (default)",
+                        "name": "version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Embed the first card image as PHOTO",
+                        "name": "photo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only cards with this status (default COMPLETED)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only cards of this company (case-insensitive exact match)",
+                        "name": "company_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only cards created at or after this RFC 3339 time",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only cards created at or before this RFC 3339 time",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at (default) or processed_at",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "desc (default) or asc",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "vCards",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/business-cards/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific business card by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "business-cards"
+                ],
+                "summary": "Get business card by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business Card ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Permanently remove a business card and all of its stored image bytes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "business-cards"
+                ],
+                "summary": "Delete a business card",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business Card ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Partially update the personal and company data of a business card. Fields present in the request are\nvalidated, marked as verified so reprocessing keeps them, and every change is recorded in the change log\ntogether with the X-User header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "business-cards"
+                ],
+                "summary": "Correct extracted fields",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business Card ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of the person making the correction, used when authentication is off",
+                        "name": "X-User",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Fields to correct",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/business-cards/{id}/duplicates": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List other business cards that probably describe the same contact, best matches first. Emails, phone\nnumbers and name with company are compared after normalization; names and companies tolerate typos.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "business-cards"
+                ],
+                "summary": "Find duplicates of a business card",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business Card ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.DuplicateListResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.DuplicateListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.DuplicateListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/business-cards/{id}/events": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Server-Sent Events stream. The current status is sent first as a \"status\" event, followed by one\n\"status\" event per transition (PENDING, PROCESSING, RETRYING, COMPLETED, NEEDS_REVIEW, FAILED, DEAD_LETTER).\nOnce the card is COMPLETED, NEEDS_REVIEW, FAILED or DEAD_LETTER an \"end\" event is sent and the stream is closed.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "business-cards"
+                ],
+                "summary": "Stream status changes of a business card",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business Card ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardEvent"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/business-cards/{id}/merge": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Combine duplicate business cards into this one and delete them. For each field listed in \"fields\" the value\nof the chosen card is kept and marked as verified; other fields keep this card's value, or the first\nnon-empty value of the duplicates. The images of all cards are kept and changes are recorded in the\nchange log together with the X-User header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "business-cards"
+                ],
+                "summary": "Merge duplicates into a business card",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business Card ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of the person merging, used when authentication is off",
+                        "name": "X-User",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Duplicates and per-field source choice",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardMergeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/business-cards/{id}/retry": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retry processing a FAILED or DEAD_LETTER business card",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "business-cards"
+                ],
+                "summary": "Retry failed business card processing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business Card ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/business-cards/{id}/vcard": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download a business card as an RFC 6350 vCard (4.0) or a vCard 3.0 for older address books",
+                "produces": [
+                    "text/vcard"
+                ],
+                "tags": [
+                    "business-cards"
+                ],
+                "summary": "Export a business card as vCard",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business Card ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "3.0 or 4.0 (default)",
+                        "name": "version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Embed the first card image as PHOTO",
+                        "name": "photo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "vCard",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/review-queue": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the business cards that need a person to confirm them before they go to the CRM, oldest first:\nNEEDS_REVIEW cards waiting for a reviewer and IN_REVIEW cards claimed by one.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "Get the review queue",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewQueueResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewQueueResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/review-queue/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approve a NEEDS_REVIEW business card, or an IN_REVIEW one claimed by the reviewer named in the X-User\nheader, and move it to COMPLETED. Corrections in the body are applied first, like a PATCH of the card.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "Approve a business card",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business Card ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of the reviewer, used when authentication is off",
+                        "name": "X-User",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Optional corrections",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewApprovalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/review-queue/{id}/claim": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Assign a NEEDS_REVIEW business card to the reviewer named in the X-User header and move it to IN_REVIEW.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "Claim a business card for review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business Card ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of the reviewer, used when authentication is off",
+                        "name": "X-User",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.BusinessCardResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve all webhook subscriptions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get all webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookSubscriptionListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookSubscriptionListResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Subscribe an endpoint to business card events (business_card.completed, business_card.failed,\nbusiness_card.retried, business_card.dead_lettered, business_card.needs_review). Deliveries are signed with X-Webhook-Signature: sha256=HMAC-SHA256(secret,\n\"\u003cX-Webhook-Timestamp\u003e.\u003cbody\u003e\"). The secret is generated when omitted and only returned here.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Register a webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook subscription",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookSubscriptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookSubscriptionResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookSubscriptionResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific webhook subscription by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhook by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookSubscriptionResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookSubscriptionResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookSubscriptionResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a webhook subscription and its delivery log",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookSubscriptionResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookSubscriptionResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookSubscriptionResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the deliveries of a webhook subscription, including attempts and last errors",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhook delivery log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookDeliveryListResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookDeliveryListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookDeliveryListResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.APIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "key_hash": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tenant_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.APIKeyListResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.APIKey"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIKeyRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tenant_id": {
+                    "description": "TenantID defaults to the tenant of the caller. Only the bootstrap key can create keys\nfor other tenants.",
+                    "type": "string"
+                }
+            }
+        },
+        "models.APIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.APIKey"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.Address": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "full": {
+                    "type": "string"
+                },
+                "postal_code": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "street": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AddressUpdate": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "full": {
+                    "type": "string"
+                },
+                "postal_code": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "street": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BusinessCard": {
+            "type": "object",
+            "properties": {
+                "change_log": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FieldChange"
+                    }
+                },
+                "company_data": {
+                    "$ref": "#/definitions/models.CompanyData"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "extracted_text": {
+                    "type": "string"
+                },
+                "extraction_provider": {
+                    "type": "string"
+                },
+                "field_confidence": {
+                    "description": "FieldConfidence holds the extractor's 0-1 confidence per field path, such as\npersonal_data.email. Fields without a score weren't rated or were set by a person.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ImageData"
+                    }
+                },
+                "last_retry_at": {
+                    "type": "string"
+                },
+                "merged_from": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "personal_data": {
+                    "$ref": "#/definitions/models.PersonalData"
+                },
+                "phone_numbers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PhoneNumber"
+                    }
+                },
+                "processed_at": {
+                    "type": "string"
+                },
+                "retry_count": {
+                    "type": "integer"
+                },
+                "review": {
+                    "$ref": "#/definitions/models.Review"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "validation_warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ValidationWarning"
+                    }
+                },
+                "verified_fields": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.BusinessCardEvent": {
+            "type": "object",
+            "properties": {
+                "business_card": {
+                    "$ref": "#/definitions/models.BusinessCard"
+                },
+                "business_card_id": {
+                    "type": "string"
+                },
+                "previous_status": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BusinessCardListResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BusinessCard"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.BusinessCardMergeRequest": {
+            "type": "object",
+            "required": [
+                "duplicate_ids"
+            ],
+            "properties": {
+                "duplicate_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "fields": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.BusinessCardRequestBase64": {
+            "type": "object",
+            "properties": {
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ImageUploadBase64"
+                    }
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "total_images": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BusinessCardResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.BusinessCard"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.BusinessCardUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "company_data": {
+                    "$ref": "#/definitions/models.CompanyDataUpdate"
+                },
+                "personal_data": {
+                    "$ref": "#/definitions/models.PersonalDataUpdate"
+                }
+            }
+        },
+        "models.CompanyData": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "$ref": "#/definitions/models.Address"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "industry": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "social_media": {
+                    "type": "object",
+                    "properties": {
+                        "facebook": {
+                            "type": "string"
+                        },
+                        "instagram": {
+                            "type": "string"
+                        },
+                        "linkedin": {
+                            "type": "string"
+                        },
+                        "twitter": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CompanyDataUpdate": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "$ref": "#/definitions/models.AddressUpdate"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "industry": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "social_media": {
+                    "$ref": "#/definitions/models.SocialMediaUpdate"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DuplicateCandidate": {
+            "type": "object",
+            "properties": {
+                "business_card": {
+                    "$ref": "#/definitions/models.BusinessCard"
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "score": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.DuplicateListResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DuplicateCandidate"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.ErasureReceipt": {
+            "type": "object",
+            "properties": {
+                "complete": {
+                    "type": "boolean"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "description": "Email is only returned to the requester and never stored",
+                    "type": "string"
+                },
+                "email_sha256": {
+                    "description": "EmailSHA256 is the hex SHA-256 of the trimmed, lowercased email address",
+                    "type": "string"
+                },
+                "erased_business_card_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "images_deleted": {
+                    "type": "integer"
+                },
+                "requested_at": {
+                    "type": "string"
+                },
+                "requested_by": {
+                    "type": "string"
+                },
+                "skipped_business_card_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "webhook_deliveries_redacted": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ErasureReceiptListResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ErasureReceipt"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.ErasureReceiptResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.ErasureReceipt"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.ErasureRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.FieldChange": {
+            "type": "object",
+            "properties": {
+                "changed_at": {
+                    "type": "string"
+                },
+                "changed_by": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "new_value": {
+                    "type": "string"
+                },
+                "old_value": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ImageData": {
+            "type": "object",
+            "properties": {
+                "base64_data": {
+                    "type": "string"
+                },
+                "checksum": {
+                    "type": "string"
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "object_key": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "uploaded_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ImageUploadBase64": {
+            "type": "object",
+            "properties": {
+                "base64_data": {
+                    "type": "string"
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "last_modified": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ImportReport": {
+            "type": "object",
+            "properties": {
+                "duplicates": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "ignored_columns": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "imported": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ImportRowResult"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ImportReportResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.ImportReport"
+                },
+                "error": {
                     "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "models.BusinessCard": {
+        "models.ImportRowResult": {
             "type": "object",
             "properties": {
-                "company_data": {
-                    "$ref": "#/definitions/models.CompanyData"
+                "business_card_id": {
+                    "type": "string"
                 },
-                "created_at": {
+                "duplicate_of": {
                     "type": "string"
                 },
                 "error": {
                     "type": "string"
                 },
-                "extracted_text": {
+                "row": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PersonalData": {
+            "type": "object",
+            "properties": {
+                "department": {
                     "type": "string"
                 },
-                "id": {
+                "email": {
                     "type": "string"
                 },
-                "images": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.ImageData"
-                    }
+                "first_name": {
+                    "type": "string"
                 },
-                "last_retry_at": {
+                "full_name": {
                     "type": "string"
                 },
-                "personal_data": {
-                    "$ref": "#/definitions/models.PersonalData"
+                "job_title": {
+                    "type": "string"
                 },
-                "processed_at": {
+                "last_name": {
                     "type": "string"
                 },
-                "retry_count": {
-                    "type": "integer"
+                "linkedin": {
+                    "type": "string"
                 },
-                "status": {
+                "mobile": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "website": {
                     "type": "string"
                 }
             }
         },
-        "models.BusinessCardListResponse": {
+        "models.PersonalDataUpdate": {
+            "type": "object",
+            "properties": {
+                "department": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "job_title": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "linkedin": {
+                    "type": "string"
+                },
+                "mobile": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PhoneNumber": {
+            "type": "object",
+            "properties": {
+                "e164": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "original": {
+                    "type": "string"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "valid": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.Review": {
+            "type": "object",
+            "properties": {
+                "approved_at": {
+                    "type": "string"
+                },
+                "claimed_at": {
+                    "type": "string"
+                },
+                "corrected": {
+                    "description": "Corrected tells whether the reviewer changed fields when approving",
+                    "type": "boolean"
+                },
+                "reviewer": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ReviewApprovalRequest": {
+            "type": "object",
+            "properties": {
+                "corrections": {
+                    "$ref": "#/definitions/models.BusinessCardUpdateRequest"
+                }
+            }
+        },
+        "models.ReviewQueueResponse": {
             "type": "object",
             "properties": {
                 "count": {
@@ -277,118 +2497,200 @@ const docTemplate = `{
                 }
             }
         },
-        "models.BusinessCardResponse": {
+        "models.SocialMediaUpdate": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/models.BusinessCard"
+                "facebook": {
+                    "type": "string"
                 },
-                "error": {
+                "instagram": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "linkedin": {
+                    "type": "string"
+                },
+                "twitter": {
+                    "type": "string"
                 }
             }
         },
-        "models.CompanyData": {
+        "models.ValidationWarning": {
             "type": "object",
             "properties": {
-                "address": {
-                    "$ref": "#/definitions/models.Address"
+                "field": {
+                    "type": "string"
                 },
-                "email": {
+                "message": {
                     "type": "string"
                 },
-                "industry": {
+                "processor": {
                     "type": "string"
+                }
+            }
+        },
+        "models.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
                 },
-                "name": {
+                "business_card_id": {
                     "type": "string"
                 },
-                "phone": {
+                "created_at": {
                     "type": "string"
                 },
-                "social_media": {
-                    "type": "object",
-                    "properties": {
-                        "facebook": {
-                            "type": "string"
-                        },
-                        "instagram": {
-                            "type": "string"
-                        },
-                        "linkedin": {
-                            "type": "string"
-                        },
-                        "twitter": {
-                            "type": "string"
-                        }
-                    }
+                "delivered_at": {
+                    "type": "string"
                 },
-                "website": {
+                "event": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_attempt_at": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_status_code": {
+                    "type": "integer"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subscription_id": {
                     "type": "string"
                 }
             }
         },
-        "models.ImageData": {
+        "models.WebhookDeliveryListResponse": {
             "type": "object",
             "properties": {
-                "content_type": {
-                    "type": "string"
+                "count": {
+                    "type": "integer"
                 },
                 "data": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "$ref": "#/definitions/models.WebhookDelivery"
                     }
                 },
-                "file_name": {
+                "error": {
                     "type": "string"
                 },
-                "size": {
-                    "type": "integer"
-                },
-                "uploaded_at": {
-                    "type": "string"
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "models.PersonalData": {
+        "models.WebhookSubscription": {
             "type": "object",
             "properties": {
-                "department": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "email": {
+                "description": {
                     "type": "string"
                 },
-                "first_name": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
                     "type": "string"
                 },
-                "full_name": {
+                "secret": {
                     "type": "string"
                 },
-                "job_title": {
+                "tenant_id": {
                     "type": "string"
                 },
-                "last_name": {
+                "url": {
                     "type": "string"
+                }
+            }
+        },
+        "models.WebhookSubscriptionListResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
                 },
-                "linkedin": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WebhookSubscription"
+                    }
+                },
+                "error": {
                     "type": "string"
                 },
-                "mobile": {
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.WebhookSubscriptionRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
                     "type": "string"
                 },
-                "phone": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
                     "type": "string"
                 },
-                "website": {
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WebhookSubscriptionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.WebhookSubscription"
+                },
+                "error": {
                     "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
+        "BearerAuth": {
+            "description": "JWT from the identity provider as \"Bearer \u003ctoken\u003e\" when AUTH_MODE is jwt",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
@@ -2,7 +2,11 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
@@ -14,6 +18,94 @@ type Config struct {
 		APIKey    string
 		ModelName string
 	}
+	OpenAI struct {
+		BaseURL   string
+		APIKey    string
+		ModelName string
+	}
+	Ollama struct {
+		BaseURL   string
+		ModelName string
+	}
+	Extraction struct {
+		Provider            string
+		ConfidenceThreshold float64
+	}
+	OCR struct {
+		FallbackEnabled bool
+		TesseractPath   string
+		Language        string
+	}
+	Storage struct {
+		Backend    string
+		SQLitePath string
+	}
+	ImageStore struct {
+		Backend        string
+		Path           string
+		S3Bucket       string
+		S3Region       string
+		S3Endpoint     string
+		S3UsePathStyle bool
+	}
+	Phone struct {
+		DefaultRegion string
+	}
+	PostProcessing struct {
+		Processors []string
+	}
+	Processing struct {
+		Mode      string
+		Workers   int
+		QueueSize int
+	}
+	Retry struct {
+		SchedulerEnabled bool
+		MaxAttempts      int
+		InitialBackoff   time.Duration
+		MaxBackoff       time.Duration
+		Interval         time.Duration
+	}
+	Auth struct {
+		Mode     string
+		AdminKey string
+		JWT      struct {
+			JWKSURL     string
+			KeyFile     string
+			Issuer      string
+			Audience    string
+			RolesClaim  string
+			TenantClaim string
+			// RoleMapping maps claim values, such as OIDC group names, to roles
+			RoleMapping map[string]string
+		}
+	}
+	RateLimit struct {
+		// Token buckets for the routes that run an extraction, per API key or JWT subject and per
+		// client IP. A rate of 0 turns the limit off.
+		KeyRequestsPerMinute float64
+		KeyBurst             int
+		IPRequestsPerMinute  float64
+		IPBurst              int
+		// MaxConcurrentExtractions caps the extraction provider calls running at once, 0 for no cap
+		MaxConcurrentExtractions int
+		// ExtractionWait is how long an upload or retry waits for an extraction slot before 429
+		ExtractionWait time.Duration
+		// TrustedProxies may set X-Forwarded-For; the client IP of other requests is their address
+		TrustedProxies []string
+	}
+	Webhooks struct {
+		MaxAttempts    int
+		InitialBackoff time.Duration
+		MaxBackoff     time.Duration
+		PollInterval   time.Duration
+		Timeout        time.Duration
+		// DeliveryRetention is how long DynamoDB keeps a delivery after its last scheduled attempt
+		DeliveryRetention time.Duration
+		// AllowedHosts lists host names, IP addresses and CIDR ranges webhooks may be sent to even
+		// though they are internal, such as a CRM in the same network
+		AllowedHosts []string
+	}
 }
 
 func Load() (*Config, error) {
@@ -23,13 +115,122 @@ func Load() (*Config, error) {
 	cfg.AWS.Region = getEnvOrDefault("AWS_REGION", "us-east-1")
 	cfg.AWS.TableName = getEnvOrDefault("DYNAMODB_TABLE_NAME", "business-cards")
 
+	// Extraction Configuration
+	cfg.Extraction.Provider = strings.ToLower(getEnvOrDefault("EXTRACTION_PROVIDER", "gemini"))
+	cfg.Extraction.ConfidenceThreshold = getEnvFloat("CONFIDENCE_THRESHOLD", 0.6)
+	if cfg.Extraction.ConfidenceThreshold < 0 || cfg.Extraction.ConfidenceThreshold > 1 {
+		return nil, fmt.Errorf("CONFIDENCE_THRESHOLD must be between 0 and 1")
+	}
+
 	// Gemini Configuration
 	cfg.Gemini.APIKey = os.Getenv("GEMINI_API_KEY")
-	if cfg.Gemini.APIKey == "" {
+	if cfg.Gemini.APIKey == "" && cfg.Extraction.Provider == "gemini" {
 		return nil, fmt.Errorf("GEMINI_API_KEY environment variable is required")
 	}
 	cfg.Gemini.ModelName = getEnvOrDefault("GEMINI_MODEL_NAME", "gemini-1.5-flash")
 
+	// OpenAI-compatible Configuration
+	cfg.OpenAI.BaseURL = getEnvOrDefault("OPENAI_BASE_URL", "https://api.openai.com/v1")
+	cfg.OpenAI.APIKey = os.Getenv("OPENAI_API_KEY")
+	cfg.OpenAI.ModelName = getEnvOrDefault("OPENAI_MODEL_NAME", "gpt-4o-mini")
+
+	// Ollama Configuration
+	cfg.Ollama.BaseURL = getEnvOrDefault("OLLAMA_BASE_URL", "http://localhost:11434")
+	cfg.Ollama.ModelName = getEnvOrDefault("OLLAMA_MODEL_NAME", "llava")
+
+	// OCR Fallback Configuration
+	cfg.OCR.FallbackEnabled = getEnvBool("OCR_FALLBACK_ENABLED", false)
+	cfg.OCR.TesseractPath = getEnvOrDefault("TESSERACT_PATH", "tesseract")
+	cfg.OCR.Language = getEnvOrDefault("TESSERACT_LANGUAGE", "eng")
+
+	// Storage Configuration
+	cfg.Storage.Backend = getEnvOrDefault("STORAGE_BACKEND", "dynamodb")
+	cfg.Storage.SQLitePath = getEnvOrDefault("SQLITE_PATH", "business-cards.db")
+
+	// Image Store Configuration
+	cfg.ImageStore.Path = getEnvOrDefault("IMAGE_STORE_PATH", "images")
+	cfg.ImageStore.S3Bucket = os.Getenv("S3_BUCKET")
+	// DynamoDB items are limited to 400 KB, too small for embedded images. Local files would be lost
+	// with the instance, so filesystem has to be chosen explicitly.
+	dynamoDB := cfg.Storage.Backend == "" || strings.EqualFold(cfg.Storage.Backend, "dynamodb")
+	defaultImageStore := "inline"
+	if dynamoDB {
+		defaultImageStore = "s3"
+	}
+	cfg.ImageStore.Backend = strings.ToLower(getEnvOrDefault("IMAGE_STORE", defaultImageStore))
+	if dynamoDB && cfg.ImageStore.Backend == "inline" {
+		return nil, fmt.Errorf("IMAGE_STORE=inline can't be used with the dynamodb storage backend, whose items are limited to 400 KB; use s3 or filesystem")
+	}
+	if cfg.ImageStore.Backend == "s3" && cfg.ImageStore.S3Bucket == "" {
+		return nil, fmt.Errorf("IMAGE_STORE=s3 needs S3_BUCKET; set it, or set IMAGE_STORE=filesystem to keep images on local disk")
+	}
+	cfg.ImageStore.S3Region = getEnvOrDefault("S3_REGION", cfg.AWS.Region)
+	cfg.ImageStore.S3Endpoint = os.Getenv("S3_ENDPOINT")
+	cfg.ImageStore.S3UsePathStyle = getEnvBool("S3_USE_PATH_STYLE", false)
+
+	// Phone Normalization Configuration
+	cfg.Phone.DefaultRegion = os.Getenv("PHONE_DEFAULT_REGION")
+
+	// Post-Processing Configuration
+	cfg.PostProcessing.Processors = getEnvList("POST_PROCESSORS", []string{"names", "emails", "urls", "social", "postal_codes", "phones"})
+
+	// Processing Configuration
+	cfg.Processing.Mode = strings.ToLower(getEnvOrDefault("PROCESSING_MODE", "sync"))
+	if cfg.Processing.Mode != "sync" && cfg.Processing.Mode != "async" {
+		return nil, fmt.Errorf("PROCESSING_MODE must be either sync or async")
+	}
+	cfg.Processing.Workers = getEnvInt("PROCESSING_WORKERS", 4)
+	cfg.Processing.QueueSize = getEnvInt("PROCESSING_QUEUE_SIZE", 100)
+
+	// Retry Configuration
+	cfg.Retry.SchedulerEnabled = getEnvBool("RETRY_SCHEDULER_ENABLED", true)
+	cfg.Retry.MaxAttempts = getEnvInt("RETRY_MAX_ATTEMPTS", 5)
+	cfg.Retry.InitialBackoff = getEnvDuration("RETRY_INITIAL_BACKOFF", time.Minute)
+	cfg.Retry.MaxBackoff = getEnvDuration("RETRY_MAX_BACKOFF", time.Hour)
+	cfg.Retry.Interval = getEnvDuration("RETRY_SCHEDULER_INTERVAL", 30*time.Second)
+
+	// Authentication Configuration
+	// Authentication is on unless turned off explicitly
+	cfg.Auth.Mode = strings.ToLower(getEnvOrDefault("AUTH_MODE", "api_key"))
+	cfg.Auth.AdminKey = os.Getenv("API_ADMIN_KEY")
+	cfg.Auth.JWT.JWKSURL = os.Getenv("JWT_JWKS_URL")
+	cfg.Auth.JWT.KeyFile = os.Getenv("JWT_KEY_FILE")
+	cfg.Auth.JWT.Issuer = os.Getenv("JWT_ISSUER")
+	cfg.Auth.JWT.Audience = os.Getenv("JWT_AUDIENCE")
+	cfg.Auth.JWT.RolesClaim = getEnvOrDefault("JWT_ROLES_CLAIM", "roles")
+	cfg.Auth.JWT.TenantClaim = getEnvOrDefault("JWT_TENANT_CLAIM", "tenant_id")
+	cfg.Auth.JWT.RoleMapping = make(map[string]string)
+	for _, entry := range getEnvList("JWT_ROLE_MAPPING", nil) {
+		claimValue, role, found := strings.Cut(entry, "=")
+		if !found || strings.TrimSpace(claimValue) == "" || strings.TrimSpace(role) == "" {
+			return nil, fmt.Errorf("JWT_ROLE_MAPPING entries must have the form claim_value=role, got %q", entry)
+		}
+		cfg.Auth.JWT.RoleMapping[strings.TrimSpace(claimValue)] = strings.TrimSpace(role)
+	}
+
+	// Webhook Configuration
+	cfg.Webhooks.MaxAttempts = getEnvInt("WEBHOOK_MAX_ATTEMPTS", 8)
+	cfg.Webhooks.InitialBackoff = getEnvDuration("WEBHOOK_INITIAL_BACKOFF", 30*time.Second)
+	cfg.Webhooks.MaxBackoff = getEnvDuration("WEBHOOK_MAX_BACKOFF", time.Hour)
+	cfg.Webhooks.PollInterval = getEnvDuration("WEBHOOK_POLL_INTERVAL", 10*time.Second)
+	cfg.Webhooks.Timeout = getEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second)
+	cfg.Webhooks.DeliveryRetention = getEnvDuration("WEBHOOK_DELIVERY_RETENTION", 30*24*time.Hour)
+	cfg.Webhooks.AllowedHosts = getEnvList("WEBHOOK_ALLOWED_HOSTS", nil)
+	for _, entry := range cfg.Webhooks.AllowedHosts {
+		if _, _, err := net.ParseCIDR(entry); strings.Contains(entry, "/") && err != nil {
+			return nil, fmt.Errorf("WEBHOOK_ALLOWED_HOSTS entry %q is not a valid CIDR range", entry)
+		}
+	}
+
+	// Rate Limit Configuration
+	cfg.RateLimit.KeyRequestsPerMinute = getEnvFloat("RATE_LIMIT_KEY_PER_MINUTE", 30)
+	cfg.RateLimit.KeyBurst = getEnvInt("RATE_LIMIT_KEY_BURST", 10)
+	cfg.RateLimit.IPRequestsPerMinute = getEnvFloat("RATE_LIMIT_IP_PER_MINUTE", 60)
+	cfg.RateLimit.IPBurst = getEnvInt("RATE_LIMIT_IP_BURST", 20)
+	cfg.RateLimit.MaxConcurrentExtractions = getEnvInt("EXTRACTION_MAX_CONCURRENCY", 4)
+	cfg.RateLimit.ExtractionWait = getEnvDuration("EXTRACTION_SLOT_WAIT", 10*time.Second)
+	cfg.RateLimit.TrustedProxies = getEnvList("TRUSTED_PROXIES", nil)
+
 	return cfg, nil
 }
 
@@ -39,3 +240,50 @@ func getEnvOrDefault(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value, err := strconv.ParseBool(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// getEnvList reads a comma-separated list. "none" selects an empty list.
+func getEnvList(key string, defaultValue []string) []string {
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		return defaultValue
+	}
+	if strings.EqualFold(value, "none") {
+		return []string{}
+	}
+
+	var values []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	return values
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+// setEnv sets the environment for Load, with a Gemini key so the default provider is usable
+func setEnv(t *testing.T, env map[string]string) {
+	t.Helper()
+	t.Setenv("GEMINI_API_KEY", "test-key")
+	for _, key := range []string{"STORAGE_BACKEND", "IMAGE_STORE", "S3_BUCKET", "AUTH_MODE"} {
+		t.Setenv(key, "")
+	}
+	for key, value := range env {
+		t.Setenv(key, value)
+	}
+}
+
+func TestLoadImageStore(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    string
+		wantErr string
+	}{
+		{"dynamodb defaults to s3", map[string]string{"S3_BUCKET": "cards"}, "s3", ""},
+		{"dynamodb needs a bucket", map[string]string{}, "", "S3_BUCKET"},
+		{"dynamodb keeps an explicit filesystem store", map[string]string{"IMAGE_STORE": "filesystem"}, "filesystem", ""},
+		{"dynamodb refuses inline", map[string]string{"STORAGE_BACKEND": "DynamoDB", "IMAGE_STORE": "inline"}, "", "IMAGE_STORE=inline"},
+		{"sqlite defaults to inline", map[string]string{"STORAGE_BACKEND": "sqlite"}, "inline", ""},
+		{"memory keeps an explicit store", map[string]string{"STORAGE_BACKEND": "memory", "IMAGE_STORE": "S3", "S3_BUCKET": "cards"}, "s3", ""},
+		{"s3 needs a bucket", map[string]string{"STORAGE_BACKEND": "sqlite", "IMAGE_STORE": "s3"}, "", "S3_BUCKET"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEnv(t, tt.env)
+
+			cfg, err := Load()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.ImageStore.Backend != tt.want {
+				t.Errorf("ImageStore.Backend = %q, want %q", cfg.ImageStore.Backend, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadAuthMode(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{"defaults to api_key", map[string]string{}, "api_key"},
+		{"explicit none", map[string]string{"AUTH_MODE": "none"}, "none"},
+		{"case insensitive", map[string]string{"AUTH_MODE": "JWT"}, "jwt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEnv(t, tt.env)
+			t.Setenv("S3_BUCKET", "cards")
+
+			cfg, err := Load()
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.Auth.Mode != tt.want {
+				t.Errorf("Auth.Mode = %q, want %q", cfg.Auth.Mode, tt.want)
+			}
+		})
+	}
+}
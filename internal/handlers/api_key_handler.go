@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"business-card-reader/internal/logger"
+	"business-card-reader/internal/middleware"
+	"business-card-reader/internal/models"
+	"business-card-reader/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type APIKeyHandler struct {
+	service *services.APIKeyService
+}
+
+func NewAPIKeyHandler(service *services.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		service: service,
+	}
+}
+
+// @Summary Create an API key
+// @Description Generate an API key with the given scopes: read grants the viewer role, write the scanner and reviewer
+// @Description roles and admin every role.
+// @Description The key has the form bcr_<id>_<secret> and is only returned here; only its SHA-256 hash is stored.
+// @Description Keys belong to the caller's tenant; only the bootstrap key can create keys for another tenant_id.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param request body models.APIKeyRequest true "API key"
+// @Success 201 {object} models.APIKeyResponse
+// @Failure 400 {object} models.APIKeyResponse
+// @Failure 403 {object} models.APIKeyResponse
+// @Failure 500 {object} models.APIKeyResponse
+// @Router /api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	var request models.APIKeyRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		logger.LogError("CreateAPIKey", err, map[string]interface{}{
+			"step":        "parse_json_request",
+			"remote_addr": c.ClientIP(),
+		})
+		c.JSON(http.StatusBadRequest, models.APIKeyResponse{
+			Success: false,
+			Error:   "Invalid JSON request format",
+		})
+		return
+	}
+
+	// Without authentication there is no principal and every tenant is open
+	if request.TenantID != "" && request.TenantID != services.TenantFromContext(c.Request.Context()) {
+		if principal, ok := middleware.PrincipalFromContext(c); ok && principal.ID != middleware.BootstrapPrincipalID {
+			c.JSON(http.StatusForbidden, models.APIKeyResponse{
+				Success: false,
+				Error:   "Only the bootstrap key can create API keys for another tenant",
+			})
+			return
+		}
+	}
+
+	apiKey, err := h.service.CreateAPIKey(c.Request.Context(), request, requestActor(c))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, services.ErrInvalidAPIKeyRequest) {
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, models.APIKeyResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to create API key: %v", err),
+		})
+		return
+	}
+
+	apiKey.KeyHash = ""
+
+	c.JSON(http.StatusCreated, models.APIKeyResponse{
+		Success: true,
+		Data:    *apiKey,
+	})
+}
+
+// @Summary Get all API keys
+// @Description Retrieve the API keys of the caller's tenant, including revoked ones. The keys themselves are never returned.
+// @Tags api-keys
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Success 200 {object} models.APIKeyListResponse
+// @Failure 500 {object} models.APIKeyListResponse
+// @Router /api-keys [get]
+func (h *APIKeyHandler) GetAPIKeys(c *gin.Context) {
+	apiKeys, err := h.service.GetAPIKeys(c.Request.Context())
+	if err != nil {
+		logger.LogError("GetAPIKeys", err, map[string]interface{}{
+			"step": "get_api_keys",
+		})
+		c.JSON(http.StatusInternalServerError, models.APIKeyListResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to retrieve API keys: %v", err),
+		})
+		return
+	}
+
+	for i := range apiKeys {
+		apiKeys[i].KeyHash = ""
+	}
+
+	c.JSON(http.StatusOK, models.APIKeyListResponse{
+		Success: true,
+		Data:    apiKeys,
+		Count:   len(apiKeys),
+	})
+}
+
+// @Summary Revoke an API key
+// @Description Disable an API key immediately. The key stays listed with its revocation time.
+// @Tags api-keys
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "API key ID"
+// @Success 200 {object} models.APIKeyResponse
+// @Failure 404 {object} models.APIKeyResponse
+// @Failure 500 {object} models.APIKeyResponse
+// @Router /api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	id := c.Param("id")
+
+	apiKey, err := h.service.RevokeAPIKey(c.Request.Context(), id, requestActor(c))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, services.ErrAPIKeyNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, models.APIKeyResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to revoke API key: %v", err),
+		})
+		return
+	}
+
+	apiKey.KeyHash = ""
+
+	c.JSON(http.StatusOK, models.APIKeyResponse{
+		Success: true,
+		Data:    *apiKey,
+	})
+}
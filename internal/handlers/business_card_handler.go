@@ -2,12 +2,17 @@ package handlers
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"business-card-reader/internal/logger"
+	"business-card-reader/internal/middleware"
 	"business-card-reader/internal/models"
 	"business-card-reader/internal/services"
 
@@ -25,14 +30,21 @@ func NewBusinessCardHandler(service *services.BusinessCardService) *BusinessCard
 }
 
 // @Summary Process business card images
-// @Description Upload and process business card images using Gemini AI
+// @Description Upload and process business card images using Gemini AI. In async processing mode the card is
+// @Description stored as PENDING and 202 is returned immediately; poll GET /business-cards/{id} for the result.
+// @Description Uploads are rate limited per API key and per client IP; 429 responses carry a Retry-After header.
 // @Tags business-cards
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Param request body models.BusinessCardRequestBase64 true "Business card images in base64 format"
 // @Success 200 {object} models.BusinessCardResponse
+// @Success 202 {object} models.BusinessCardResponse
 // @Failure 400 {object} models.BusinessCardResponse
+// @Failure 429 {object} models.ErrorResponse
 // @Failure 500 {object} models.BusinessCardResponse
+// @Failure 503 {object} models.BusinessCardResponse
 // @Router /business-cards [post]
 func (h *BusinessCardHandler) ProcessBusinessCard(c *gin.Context) {
 	logger.LogInfo("ProcessBusinessCard", "Starting business card processing", map[string]interface{}{
@@ -141,8 +153,17 @@ func (h *BusinessCardHandler) ProcessBusinessCard(c *gin.Context) {
 		})
 	}
 
+	if h.service.IsAsync() {
+		h.submitBusinessCard(c, imageUploads)
+		return
+	}
+
 	// Process the business card
 	businessCard, err := h.service.ProcessBusinessCard(c.Request.Context(), imageUploads)
+	if errors.Is(err, services.ErrExtractionBusy) {
+		middleware.AbortTooManyRequests(c, h.service.ExtractionRetryAfter(), "Too many business cards are being processed")
+		return
+	}
 	if err != nil {
 		logger.LogError("ProcessBusinessCard", err, map[string]interface{}{
 			"step":       "process_business_card",
@@ -172,24 +193,89 @@ func (h *BusinessCardHandler) ProcessBusinessCard(c *gin.Context) {
 	})
 }
 
-// @Summary Get all business cards
-// @Description Retrieve all processed business cards
+// submitBusinessCard queues the upload for background processing and answers with 202
+func (h *BusinessCardHandler) submitBusinessCard(c *gin.Context, imageUploads []models.ImageUpload) {
+	businessCard, err := h.service.SubmitBusinessCard(c.Request.Context(), imageUploads)
+	if err != nil {
+		logger.LogError("ProcessBusinessCard", err, map[string]interface{}{
+			"step":       "submit_business_card",
+			"file_count": len(imageUploads),
+		})
+
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, services.ErrProcessingQueueFull) {
+			statusCode = http.StatusServiceUnavailable
+		}
+		c.JSON(statusCode, models.BusinessCardResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to submit business card: %v", err),
+		})
+		return
+	}
+
+	logger.LogInfo("ProcessBusinessCard", "Business card accepted for async processing", map[string]interface{}{
+		"business_card_id": businessCard.ID,
+		"status":           businessCard.Status,
+	})
+
+	// Remove image data from response to keep it lightweight
+	responseCard := *businessCard
+	for i := range responseCard.Images {
+		responseCard.Images[i].Data = nil
+	}
+
+	c.Header("Location", "/api/v1/business-cards/"+businessCard.ID)
+	c.JSON(http.StatusAccepted, models.BusinessCardResponse{
+		Success: true,
+		Data:    responseCard,
+	})
+}
+
+// @Summary List business cards
+// @Description Retrieve business cards one page at a time, newest first by default. Image bytes are not included;
+// @Description use GET /business-cards/{id} for them. Pass next_cursor back as cursor to get the following page.
 // @Tags business-cards
 // @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param limit query int false "Page size (default 50, max 200)"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param status query string false "Only cards with this status"
+// @Param company_name query string false "Only cards of this company (case-insensitive exact match)"
+// @Param created_after query string false "Only cards created at or after this RFC 3339 time"
+// @Param created_before query string false "Only cards created at or before this RFC 3339 time"
+// @Param sort_by query string false "created_at (default) or processed_at"
+// @Param order query string false "desc (default) or asc"
 // @Success 200 {object} models.BusinessCardListResponse
+// @Failure 400 {object} models.BusinessCardListResponse
 // @Failure 500 {object} models.BusinessCardListResponse
 // @Router /business-cards [get]
 func (h *BusinessCardHandler) GetBusinessCards(c *gin.Context) {
-	logger.LogInfo("GetBusinessCards", "Retrieving all business cards", map[string]interface{}{
+	logger.LogInfo("GetBusinessCards", "Retrieving business cards", map[string]interface{}{
 		"remote_addr": c.ClientIP(),
+		"query":       c.Request.URL.RawQuery,
 	})
 
-	businessCards, err := h.service.GetAllBusinessCards(c.Request.Context())
+	query, err := parseBusinessCardQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BusinessCardListResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	page, err := h.service.ListBusinessCards(c.Request.Context(), query)
 	if err != nil {
 		logger.LogError("GetBusinessCards", err, map[string]interface{}{
-			"step": "get_all_business_cards",
+			"step": "list_business_cards",
 		})
-		c.JSON(http.StatusInternalServerError, models.BusinessCardListResponse{
+
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, services.ErrInvalidQuery) {
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, models.BusinessCardListResponse{
 			Success: false,
 			Error:   fmt.Sprintf("Failed to retrieve business cards: %v", err),
 		})
@@ -197,29 +283,58 @@ func (h *BusinessCardHandler) GetBusinessCards(c *gin.Context) {
 	}
 
 	logger.LogInfo("GetBusinessCards", "Business cards retrieved successfully", map[string]interface{}{
-		"count": len(businessCards),
+		"count":    len(page.BusinessCards),
+		"has_more": page.NextCursor != "",
 	})
 
-	for i := range businessCards {
-		for j := range businessCards[i].Images {
-			if len(businessCards[i].Images[j].Data) > 0 {
-				businessCards[i].Images[j].Base64Data = base64.StdEncoding.EncodeToString(businessCards[i].Images[j].Data)
-				businessCards[i].Images[j].Data = nil
+	c.JSON(http.StatusOK, models.BusinessCardListResponse{
+		Success:    true,
+		Data:       page.BusinessCards,
+		Count:      len(page.BusinessCards),
+		NextCursor: page.NextCursor,
+	})
+}
+
+// parseBusinessCardQuery reads the listing parameters from the query string
+func parseBusinessCardQuery(c *gin.Context) (models.BusinessCardQuery, error) {
+	query := models.BusinessCardQuery{
+		Status:      c.Query("status"),
+		CompanyName: c.Query("company_name"),
+		SortBy:      c.Query("sort_by"),
+		SortOrder:   c.Query("order"),
+		Cursor:      c.Query("cursor"),
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value < 1 {
+			return query, fmt.Errorf("limit must be a positive integer")
+		}
+		query.Limit = value
+	}
+
+	for param, target := range map[string]**time.Time{
+		"created_after":  &query.CreatedAfter,
+		"created_before": &query.CreatedBefore,
+	} {
+		if value := c.Query(param); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return query, fmt.Errorf("%s must be an RFC 3339 time", param)
 			}
+			*target = &parsed
 		}
 	}
 
-	c.JSON(http.StatusOK, models.BusinessCardListResponse{
-		Success: true,
-		Data:    businessCards,
-		Count:   len(businessCards),
-	})
+	return query, nil
 }
 
 // @Summary Get business card by ID
 // @Description Retrieve a specific business card by its ID
 // @Tags business-cards
 // @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Param id path string true "Business Card ID"
 // @Success 200 {object} models.BusinessCardResponse
 // @Failure 400 {object} models.BusinessCardResponse
@@ -275,6 +390,638 @@ func (h *BusinessCardHandler) GetBusinessCardByID(c *gin.Context) {
 	})
 }
 
+// @Summary Correct extracted fields
+// @Description Partially update the personal and company data of a business card. Fields present in the request are
+// @Description validated, marked as verified so reprocessing keeps them, and every change is recorded in the change log
+// @Description together with the X-User header.
+// @Tags business-cards
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "Business Card ID"
+// @Param X-User header string false "Name of the person making the correction, used when authentication is off"
+// @Param request body models.BusinessCardUpdateRequest true "Fields to correct"
+// @Success 200 {object} models.BusinessCardResponse
+// @Failure 400 {object} models.BusinessCardResponse
+// @Failure 404 {object} models.BusinessCardResponse
+// @Failure 409 {object} models.BusinessCardResponse
+// @Failure 500 {object} models.BusinessCardResponse
+// @Router /business-cards/{id} [patch]
+func (h *BusinessCardHandler) UpdateBusinessCard(c *gin.Context) {
+	id := c.Param("id")
+
+	var request models.BusinessCardUpdateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		logger.LogError("UpdateBusinessCard", err, map[string]interface{}{
+			"step":             "parse_json_request",
+			"business_card_id": id,
+			"remote_addr":      c.ClientIP(),
+		})
+		c.JSON(http.StatusBadRequest, models.BusinessCardResponse{
+			Success: false,
+			Error:   "Invalid JSON request format",
+		})
+		return
+	}
+
+	businessCard, err := h.service.UpdateBusinessCard(c.Request.Context(), id, request, requestActor(c))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, services.ErrBusinessCardNotFound):
+			statusCode = http.StatusNotFound
+		case errors.Is(err, services.ErrInvalidBusinessCardUpdate):
+			statusCode = http.StatusBadRequest
+		case errors.Is(err, services.ErrBusinessCardBusy):
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, models.BusinessCardResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to update business card: %v", err),
+		})
+		return
+	}
+
+	// Remove image data from response to keep it lightweight
+	for i := range businessCard.Images {
+		businessCard.Images[i].Data = nil
+	}
+
+	c.JSON(http.StatusOK, models.BusinessCardResponse{
+		Success: true,
+		Data:    *businessCard,
+	})
+}
+
+// @Summary Find duplicates of a business card
+// @Description List other business cards that probably describe the same contact, best matches first. Emails, phone
+// @Description numbers and name with company are compared after normalization; names and companies tolerate typos.
+// @Tags business-cards
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "Business Card ID"
+// @Success 200 {object} models.DuplicateListResponse
+// @Failure 404 {object} models.DuplicateListResponse
+// @Failure 500 {object} models.DuplicateListResponse
+// @Router /business-cards/{id}/duplicates [get]
+func (h *BusinessCardHandler) GetBusinessCardDuplicates(c *gin.Context) {
+	id := c.Param("id")
+
+	candidates, err := h.service.FindDuplicates(c.Request.Context(), id)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, services.ErrBusinessCardNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, models.DuplicateListResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to find duplicates: %v", err),
+		})
+		return
+	}
+
+	// Remove image data from response to keep it lightweight
+	for i := range candidates {
+		for j := range candidates[i].BusinessCard.Images {
+			candidates[i].BusinessCard.Images[j].Data = nil
+		}
+	}
+
+	c.JSON(http.StatusOK, models.DuplicateListResponse{
+		Success: true,
+		Data:    candidates,
+		Count:   len(candidates),
+	})
+}
+
+// @Summary Merge duplicates into a business card
+// @Description Combine duplicate business cards into this one and delete them. For each field listed in "fields" the value
+// @Description of the chosen card is kept and marked as verified; other fields keep this card's value, or the first
+// @Description non-empty value of the duplicates. The images of all cards are kept and changes are recorded in the
+// @Description change log together with the X-User header.
+// @Tags business-cards
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "Business Card ID"
+// @Param X-User header string false "Name of the person merging, used when authentication is off"
+// @Param request body models.BusinessCardMergeRequest true "Duplicates and per-field source choice"
+// @Success 200 {object} models.BusinessCardResponse
+// @Failure 400 {object} models.BusinessCardResponse
+// @Failure 404 {object} models.BusinessCardResponse
+// @Failure 409 {object} models.BusinessCardResponse
+// @Failure 500 {object} models.BusinessCardResponse
+// @Router /business-cards/{id}/merge [post]
+func (h *BusinessCardHandler) MergeBusinessCards(c *gin.Context) {
+	id := c.Param("id")
+
+	var request models.BusinessCardMergeRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		logger.LogError("MergeBusinessCards", err, map[string]interface{}{
+			"step":             "parse_json_request",
+			"business_card_id": id,
+			"remote_addr":      c.ClientIP(),
+		})
+		c.JSON(http.StatusBadRequest, models.BusinessCardResponse{
+			Success: false,
+			Error:   "Invalid JSON request format",
+		})
+		return
+	}
+
+	businessCard, err := h.service.MergeBusinessCards(c.Request.Context(), id, request, requestActor(c))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, services.ErrBusinessCardNotFound):
+			statusCode = http.StatusNotFound
+		case errors.Is(err, services.ErrInvalidMerge):
+			statusCode = http.StatusBadRequest
+		case errors.Is(err, services.ErrBusinessCardBusy):
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, models.BusinessCardResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to merge business cards: %v", err),
+		})
+		return
+	}
+
+	// Remove image data from response to keep it lightweight
+	for i := range businessCard.Images {
+		businessCard.Images[i].Data = nil
+	}
+
+	c.JSON(http.StatusOK, models.BusinessCardResponse{
+		Success: true,
+		Data:    *businessCard,
+	})
+}
+
+// @Summary Delete a business card
+// @Description Permanently remove a business card and all of its stored image bytes
+// @Tags business-cards
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "Business Card ID"
+// @Success 200 {object} models.BusinessCardResponse
+// @Failure 404 {object} models.BusinessCardResponse
+// @Failure 409 {object} models.BusinessCardResponse
+// @Failure 500 {object} models.BusinessCardResponse
+// @Router /business-cards/{id} [delete]
+func (h *BusinessCardHandler) DeleteBusinessCard(c *gin.Context) {
+	id := c.Param("id")
+
+	logger.LogInfo("DeleteBusinessCard", "Deleting business card", map[string]interface{}{
+		"business_card_id": id,
+		"actor":            requestActor(c),
+		"remote_addr":      c.ClientIP(),
+	})
+
+	if _, err := h.service.DeleteBusinessCard(c.Request.Context(), id); err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, services.ErrBusinessCardNotFound):
+			statusCode = http.StatusNotFound
+		case errors.Is(err, services.ErrBusinessCardBusy):
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, models.BusinessCardResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to delete business card: %v", err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BusinessCardResponse{
+		Success: true,
+	})
+}
+
+// @Summary Erase a contact's data
+// @Description Delete every business card whose personal or company email matches, including stored images, redact
+// @Description the webhook deliveries that carried them and return an erasure receipt. The receipt is stored for
+// @Description audits with a SHA-256 of the email instead of the email. Cards that are being processed are listed
+// @Description as skipped and the receipt is marked incomplete; repeat the request once they are done.
+// @Tags business-cards
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param X-User header string false "Name of the person handling the request, used when authentication is off"
+// @Param request body models.ErasureRequest true "Contact to erase"
+// @Success 200 {object} models.ErasureReceiptResponse
+// @Failure 400 {object} models.ErasureReceiptResponse
+// @Failure 500 {object} models.ErasureReceiptResponse
+// @Router /business-cards/erasure [post]
+func (h *BusinessCardHandler) EraseBusinessCards(c *gin.Context) {
+	var request models.ErasureRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		logger.LogError("EraseBusinessCards", err, map[string]interface{}{
+			"step":        "parse_json_request",
+			"remote_addr": c.ClientIP(),
+		})
+		c.JSON(http.StatusBadRequest, models.ErasureReceiptResponse{
+			Success: false,
+			Error:   "Invalid JSON request format",
+		})
+		return
+	}
+
+	receipt, err := h.service.EraseBusinessCardsByEmail(c.Request.Context(), request.Email, requestActor(c))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, services.ErrInvalidErasureRequest) {
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, models.ErasureReceiptResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to erase business cards: %v", err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ErasureReceiptResponse{
+		Success: true,
+		Data:    *receipt,
+	})
+}
+
+// @Summary List erasure receipts
+// @Description Get the stored receipts of every erasure request, oldest first
+// @Tags business-cards
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Success 200 {object} models.ErasureReceiptListResponse
+// @Failure 500 {object} models.ErasureReceiptListResponse
+// @Router /business-cards/erasure [get]
+func (h *BusinessCardHandler) GetErasureReceipts(c *gin.Context) {
+	receipts, err := h.service.GetAllErasureReceipts(c.Request.Context())
+	if err != nil {
+		logger.LogError("GetErasureReceipts", err, map[string]interface{}{
+			"remote_addr": c.ClientIP(),
+		})
+		c.JSON(http.StatusInternalServerError, models.ErasureReceiptListResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to get erasure receipts: %v", err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ErasureReceiptListResponse{
+		Success: true,
+		Data:    receipts,
+		Count:   len(receipts),
+	})
+}
+
+// @Summary Get an erasure receipt
+// @Description Get the stored receipt of an erasure request
+// @Tags business-cards
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "Erasure Receipt ID"
+// @Success 200 {object} models.ErasureReceiptResponse
+// @Failure 404 {object} models.ErasureReceiptResponse
+// @Failure 500 {object} models.ErasureReceiptResponse
+// @Router /business-cards/erasure/{id} [get]
+func (h *BusinessCardHandler) GetErasureReceipt(c *gin.Context) {
+	id := c.Param("id")
+
+	receipt, err := h.service.GetErasureReceipt(c.Request.Context(), id)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, services.ErrErasureReceiptNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, models.ErasureReceiptResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to get erasure receipt: %v", err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ErasureReceiptResponse{
+		Success: true,
+		Data:    *receipt,
+	})
+}
+
+// @Summary Export a business card as vCard
+// @Description Download a business card as an RFC 6350 vCard (4.0) or a vCard 3.0 for older address books
+// @Tags business-cards
+// @Produce text/vcard
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "Business Card ID"
+// @Param version query string false "3.0 or 4.0 (default)"
+// @Param photo query bool false "Embed the first card image as PHOTO"
+// @Success 200 {string} string "vCard"
+// @Failure 400 {object} models.BusinessCardResponse
+// @Failure 404 {object} models.BusinessCardResponse
+// @Failure 500 {object} models.BusinessCardResponse
+// @Router /business-cards/{id}/vcard [get]
+func (h *BusinessCardHandler) ExportBusinessCardVCard(c *gin.Context) {
+	id := c.Param("id")
+
+	options, err := parseVCardOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BusinessCardResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	vCard, err := h.service.ExportVCard(c.Request.Context(), id, options)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, services.ErrBusinessCardNotFound):
+			statusCode = http.StatusNotFound
+		case errors.Is(err, services.ErrUnsupportedVCardVersion):
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, models.BusinessCardResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to export business card: %v", err),
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="business-card-%s.vcf"`, id))
+	c.Data(http.StatusOK, "text/vcard; charset=utf-8", vCard)
+}
+
+// @Summary Export business cards as vCards
+// @Description Download every business card matching the filters as one vCard file. Only COMPLETED cards are
+// @Description exported unless another status is requested.
+// @Tags business-cards
+// @Produce text/vcard
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param version query string false "3.0 or 4.0 (default)"
+// @Param photo query bool false "Embed the first card image as PHOTO"
+// @Param status query string false "Only cards with this status (default COMPLETED)"
+// @Param company_name query string false "Only cards of this company (case-insensitive exact match)"
+// @Param created_after query string false "Only cards created at or after this RFC 3339 time"
+// @Param created_before query string false "Only cards created at or before this RFC 3339 time"
+// @Param sort_by query string false "created_at (default) or processed_at"
+// @Param order query string false "desc (default) or asc"
+// @Success 200 {string} string "vCards"
+// @Failure 400 {object} models.BusinessCardListResponse
+// @Failure 500 {object} models.BusinessCardListResponse
+// @Router /business-cards/vcard [get]
+func (h *BusinessCardHandler) ExportBusinessCardsVCard(c *gin.Context) {
+	query, err := parseBusinessCardQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BusinessCardListResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+	if query.Status == "" {
+		query.Status = models.StatusCompleted
+	}
+
+	options, err := parseVCardOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BusinessCardListResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.Header("Content-Type", "text/vcard; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="business-cards.vcf"`)
+
+	count, err := h.service.ExportVCards(c.Request.Context(), c.Writer, query, options)
+	if err != nil {
+		// Once vCards are streamed the status is sent, the client only sees a truncated file
+		if c.Writer.Written() {
+			logger.LogError("ExportBusinessCardsVCard", err, map[string]interface{}{
+				"step":     "stream_vcards",
+				"exported": count,
+			})
+			return
+		}
+
+		c.Writer.Header().Del("Content-Disposition")
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, services.ErrInvalidQuery) || errors.Is(err, services.ErrUnsupportedVCardVersion) {
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, models.BusinessCardListResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to export business cards: %v", err),
+		})
+	}
+}
+
+// @Summary Export business cards as a spreadsheet
+// @Description Stream every business card matching the filters as CSV or XLSX. Columns follow a preset (default: every
+// @Description field, salesforce, hubspot or google for their contact import formats) or a custom mapping given as
+// @Description columns=field:Header, repeated or comma-separated, e.g. columns=personal_data.email:Email,company_data.name:Company.
+// @Description Only COMPLETED cards are exported unless another status is requested.
+// @Tags business-cards
+// @Produce text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param format query string false "csv (default) or xlsx"
+// @Param preset query string false "default, salesforce, hubspot or google"
+// @Param columns query []string false "Custom column mapping (field:Header), overrides the preset" collectionFormat(multi)
+// @Param status query string false "Only cards with this status (default COMPLETED)"
+// @Param company_name query string false "Only cards of this company (case-insensitive exact match)"
+// @Param created_after query string false "Only cards created at or after this RFC 3339 time"
+// @Param created_before query string false "Only cards created at or before this RFC 3339 time"
+// @Param sort_by query string false "created_at (default) or processed_at"
+// @Param order query string false "desc (default) or asc"
+// @Success 200 {file} file "Spreadsheet"
+// @Failure 400 {object} models.BusinessCardListResponse
+// @Failure 500 {object} models.BusinessCardListResponse
+// @Router /business-cards/export [get]
+func (h *BusinessCardHandler) ExportBusinessCards(c *gin.Context) {
+	query, err := parseBusinessCardQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BusinessCardListResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+	if query.Status == "" {
+		query.Status = models.StatusCompleted
+	}
+
+	options := services.ExportOptions{
+		Format: strings.ToLower(c.DefaultQuery("format", services.ExportFormatCSV)),
+		Preset: c.Query("preset"),
+	}
+	for _, columns := range c.QueryArray("columns") {
+		for _, column := range strings.Split(columns, ",") {
+			if strings.TrimSpace(column) != "" {
+				options.Columns = append(options.Columns, column)
+			}
+		}
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if options.Format == services.ExportFormatXLSX {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="business-cards.%s"`, options.Format))
+
+	count, err := h.service.ExportBusinessCards(c.Request.Context(), c.Writer, query, options)
+	if err != nil {
+		// Once rows are streamed the status is sent, the client only sees a truncated file
+		if c.Writer.Written() {
+			logger.LogError("ExportBusinessCards", err, map[string]interface{}{
+				"step":     "stream_export",
+				"exported": count,
+			})
+			return
+		}
+
+		c.Writer.Header().Del("Content-Disposition")
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, services.ErrInvalidQuery) || errors.Is(err, services.ErrInvalidExport) {
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, models.BusinessCardListResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to export business cards: %v", err),
+		})
+	}
+}
+
+// maxImportFileSize bounds the size of an uploaded import file
+const maxImportFileSize = 10 << 20
+
+// @Summary Import business cards from a vCard or CSV file
+// @Description Create business cards without images from contacts. The file is sent as multipart form field "file"
+// @Description or as the raw request body. CSV files need a header row; field paths such as personal_data.email and
+// @Description the headers of the export presets are recognized. Contacts are normalized like extraction results,
+// @Description and duplicates of stored cards or of earlier rows are reported instead of imported.
+// @Tags business-cards
+// @Accept multipart/form-data,text/vcard,text/csv
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param file formData file false "vCard (.vcf) or CSV file"
+// @Param format query string false "vcard or csv, detected from the file name or content type when omitted"
+// @Success 200 {object} models.ImportReportResponse
+// @Failure 400 {object} models.ImportReportResponse
+// @Failure 500 {object} models.ImportReportResponse
+// @Router /business-cards/import [post]
+func (h *BusinessCardHandler) ImportBusinessCards(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportFileSize)
+
+	format := c.Query("format")
+	body := io.Reader(c.Request.Body)
+	if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ImportReportResponse{
+				Success: false,
+				Error:   fmt.Sprintf("Invalid import file: %v", err),
+			})
+			return
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ImportReportResponse{
+				Success: false,
+				Error:   fmt.Sprintf("Invalid import file: %v", err),
+			})
+			return
+		}
+		defer file.Close()
+
+		body = file
+		if format == "" {
+			format = importFormat(fileHeader.Filename, fileHeader.Header.Get("Content-Type"))
+		}
+	} else if format == "" {
+		format = importFormat("", c.ContentType())
+	}
+
+	report, err := h.service.ImportBusinessCards(c.Request.Context(), body, format)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		var maxBytesErr *http.MaxBytesError
+		if errors.Is(err, services.ErrInvalidImport) || errors.As(err, &maxBytesErr) {
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, models.ImportReportResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to import business cards: %v", err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ImportReportResponse{
+		Success: true,
+		Data:    *report,
+	})
+}
+
+// importFormat detects the import format from a file name or content type
+func importFormat(fileName string, contentType string) string {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".vcf", ".vcard":
+		return services.ImportFormatVCard
+	case ".csv":
+		return services.ImportFormatCSV
+	}
+
+	switch strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0])) {
+	case "text/vcard", "text/x-vcard", "text/directory":
+		return services.ImportFormatVCard
+	case "text/csv":
+		return services.ImportFormatCSV
+	}
+	return ""
+}
+
+// parseVCardOptions reads the vCard version and photo parameters from the query string
+func parseVCardOptions(c *gin.Context) (services.VCardOptions, error) {
+	options := services.VCardOptions{
+		Version: c.Query("version"),
+	}
+
+	if photo := c.Query("photo"); photo != "" {
+		value, err := strconv.ParseBool(photo)
+		if err != nil {
+			return options, fmt.Errorf("photo must be true or false")
+		}
+		options.IncludePhoto = value
+	}
+
+	return options, nil
+}
+
+// requestActor identifies who made a request, for audit records. An authenticated caller is
+// identified by its credential, so the X-User header only names the actor when auth is off.
+func requestActor(c *gin.Context) string {
+	if principal, ok := middleware.PrincipalFromContext(c); ok {
+		return principal.Name
+	}
+	if actor := strings.TrimSpace(c.GetHeader("X-User")); actor != "" {
+		return actor
+	}
+	return "anonymous"
+}
+
 // isValidImageType checks if the content type is a valid image type
 func isValidImageType(contentType string) bool {
 	validTypes := []string{
@@ -294,12 +1041,15 @@ func isValidImageType(contentType string) bool {
 }
 
 // @Summary Retry failed business card processing
-// @Description Retry processing a failed business card
+// @Description Retry processing a FAILED or DEAD_LETTER business card
 // @Tags business-cards
 // @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Param id path string true "Business Card ID"
 // @Success 200 {object} models.BusinessCardResponse
 // @Failure 400 {object} models.BusinessCardResponse
+// @Failure 429 {object} models.ErrorResponse
 // @Failure 500 {object} models.BusinessCardResponse
 // @Router /business-cards/{id}/retry [post]
 func (h *BusinessCardHandler) RetryFailedBusinessCard(c *gin.Context) {
@@ -322,6 +1072,10 @@ func (h *BusinessCardHandler) RetryFailedBusinessCard(c *gin.Context) {
 	}
 
 	businessCard, err := h.service.RetryFailedProcessing(c.Request.Context(), id)
+	if errors.Is(err, services.ErrExtractionBusy) {
+		middleware.AbortTooManyRequests(c, h.service.ExtractionRetryAfter(), "Too many business cards are being processed")
+		return
+	}
 	if err != nil {
 		logger.LogError("RetryFailedBusinessCard", err, map[string]interface{}{
 			"step":             "retry_failed_processing",
@@ -356,6 +1110,8 @@ func (h *BusinessCardHandler) RetryFailedBusinessCard(c *gin.Context) {
 // @Description Retrieve all failed business cards
 // @Tags business-cards
 // @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Success 200 {object} models.BusinessCardListResponse
 // @Failure 500 {object} models.BusinessCardListResponse
 // @Router /business-cards/failed [get]
@@ -395,3 +1151,44 @@ func (h *BusinessCardHandler) GetFailedBusinessCards(c *gin.Context) {
 		Count:   len(responseCards),
 	})
 }
+
+// @Summary Get dead-lettered business cards
+// @Description Retrieve business cards that exhausted their automatic retries. They keep their last error and can
+// @Description still be retried manually.
+// @Tags business-cards
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Success 200 {object} models.BusinessCardListResponse
+// @Failure 500 {object} models.BusinessCardListResponse
+// @Router /business-cards/dead-letter [get]
+func (h *BusinessCardHandler) GetDeadLetterBusinessCards(c *gin.Context) {
+	logger.LogInfo("GetDeadLetterBusinessCards", "Retrieving dead-lettered business cards", map[string]interface{}{
+		"remote_addr": c.ClientIP(),
+	})
+
+	businessCards, err := h.service.GetDeadLetterBusinessCards(c.Request.Context())
+	if err != nil {
+		logger.LogError("GetDeadLetterBusinessCards", err, map[string]interface{}{
+			"step": "get_dead_letter_business_cards",
+		})
+		c.JSON(http.StatusInternalServerError, models.BusinessCardListResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to retrieve dead-lettered business cards: %v", err),
+		})
+		return
+	}
+
+	// Remove image data from response to keep it lightweight
+	for i := range businessCards {
+		for j := range businessCards[i].Images {
+			businessCards[i].Images[j].Data = nil
+		}
+	}
+
+	c.JSON(http.StatusOK, models.BusinessCardListResponse{
+		Success: true,
+		Data:    businessCards,
+		Count:   len(businessCards),
+	})
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	"business-card-reader/internal/models"
+)
+
+func TestGetBusinessCardsPaginates(t *testing.T) {
+	for backend, newRepository := range repositoryBackends {
+		t.Run(backend, func(t *testing.T) {
+			repository := newRepository(t)
+			router, apiKeys := newTenantRouter(t, repository, "acme")
+
+			created := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+			var want []string
+			for i := 0; i < 5; i++ {
+				businessCard := models.BusinessCard{
+					ID:        fmt.Sprintf("card-%d", i),
+					TenantID:  "acme",
+					Status:    models.StatusCompleted,
+					CreatedAt: created.Add(time.Duration(i) * time.Minute),
+					Images:    []models.ImageData{{FileName: "front.jpg", ContentType: "image/jpeg", Data: []byte("jpeg bytes")}},
+				}
+				businessCard.CompanyData.Name = "Acme Corp"
+				if i == 2 {
+					businessCard.CompanyData.Name = "Globex"
+				}
+				if err := repository.SaveBusinessCard(context.Background(), &businessCard); err != nil {
+					t.Fatal(err)
+				}
+				if i > 0 && i != 2 {
+					want = append(want, businessCard.ID)
+				}
+			}
+
+			// Filters travel with every page request; the cursor only marks the position
+			filters := url.Values{
+				"status":        {"completed"},
+				"company_name":  {"ACME corp"},
+				"created_after": {created.Add(time.Minute).Format(time.RFC3339)},
+				"order":         {"asc"},
+				"limit":         {"2"},
+			}
+			var got []string
+			for pages := 0; ; pages++ {
+				if pages > 5 {
+					t.Fatal("cursor never ends")
+				}
+				response := serve(router, apiKeys["acme"], http.MethodGet, "/api/v1/business-cards?"+filters.Encode(), "")
+				if response.Code != http.StatusOK {
+					t.Fatalf("status %d: %s", response.Code, response.Body)
+				}
+				var page models.BusinessCardListResponse
+				if err := json.Unmarshal(response.Body.Bytes(), &page); err != nil {
+					t.Fatal(err)
+				}
+				if page.Count != len(page.Data) || len(page.Data) > 2 {
+					t.Errorf("page of %d cards with count %d, want at most the limit", len(page.Data), page.Count)
+				}
+				for _, businessCard := range page.Data {
+					got = append(got, businessCard.ID)
+					if len(businessCard.Images) != 1 || businessCard.Images[0].Data != nil {
+						t.Errorf("listing returned the image bytes of %s", businessCard.ID)
+					}
+				}
+				if page.NextCursor == "" {
+					break
+				}
+				filters.Set("cursor", page.NextCursor)
+			}
+
+			if !slices.Equal(got, want) {
+				t.Errorf("listed %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestGetBusinessCardsRejectsInvalidQueries(t *testing.T) {
+	router, apiKeys := newTenantRouter(t, repositoryBackends["memory"](t), "acme")
+
+	for _, query := range []string{
+		"limit=0",
+		"limit=ten",
+		"created_after=yesterday",
+		"created_after=2024-02-01T00:00:00Z&created_before=2024-01-01T00:00:00Z",
+		"sort_by=name",
+		"order=sideways",
+		"cursor=not-a-cursor!",
+	} {
+		t.Run(query, func(t *testing.T) {
+			response := serve(router, apiKeys["acme"], http.MethodGet, "/api/v1/business-cards?"+query, "")
+			if response.Code != http.StatusBadRequest {
+				t.Errorf("status %d, want 400: %s", response.Code, response.Body)
+			}
+		})
+	}
+}
+
+func TestUpdateBusinessCardStatusCodes(t *testing.T) {
+	repository := repositoryBackends["memory"](t)
+	router, apiKeys := newTenantRouter(t, repository, "acme")
+	for id, status := range map[string]string{"done": models.StatusCompleted, "busy": models.StatusProcessing} {
+		businessCard := models.BusinessCard{ID: id, TenantID: "acme", Status: status, CreatedAt: time.Now()}
+		if err := repository.SaveBusinessCard(context.Background(), &businessCard); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		id         string
+		body       string
+		wantStatus int
+	}{
+		{"correction", "done", `{"personal_data":{"email":"jane@acme.com"}}`, http.StatusOK},
+		{"invalid email", "done", `{"personal_data":{"email":"jane-at-acme"}}`, http.StatusBadRequest},
+		{"no fields", "done", `{}`, http.StatusBadRequest},
+		{"malformed body", "done", `{"personal_data":`, http.StatusBadRequest},
+		{"in processing", "busy", `{"personal_data":{"email":"jane@acme.com"}}`, http.StatusConflict},
+		{"unknown card", "missing", `{"personal_data":{"email":"jane@acme.com"}}`, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := serve(router, apiKeys["acme"], http.MethodPatch, "/api/v1/business-cards/"+tt.id, tt.body)
+			if response.Code != tt.wantStatus {
+				t.Errorf("status %d, want %d: %s", response.Code, tt.wantStatus, response.Body)
+			}
+		})
+	}
+}
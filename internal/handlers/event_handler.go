@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"business-card-reader/internal/logger"
+	"business-card-reader/internal/models"
+	"business-card-reader/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// sseHeartbeatInterval keeps idle streams open through proxies that drop silent connections
+const sseHeartbeatInterval = 15 * time.Second
+
+// Event names written to the SSE streams
+const (
+	sseEventStatus = "status"
+	sseEventEnd    = "end"
+)
+
+type EventHandler struct {
+	service *services.BusinessCardService
+	broker  *services.EventBroker
+}
+
+func NewEventHandler(service *services.BusinessCardService, broker *services.EventBroker) *EventHandler {
+	return &EventHandler{
+		service: service,
+		broker:  broker,
+	}
+}
+
+// @Summary Stream status changes of a business card
+// @Description Server-Sent Events stream. The current status is sent first as a "status" event, followed by one
+// @Description "status" event per transition (PENDING, PROCESSING, RETRYING, COMPLETED, NEEDS_REVIEW, FAILED, DEAD_LETTER).
+// @Description Once the card is COMPLETED, NEEDS_REVIEW, FAILED or DEAD_LETTER an "end" event is sent and the stream is closed.
+// @Tags business-cards
+// @Produce text/event-stream
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "Business Card ID"
+// @Success 200 {object} models.BusinessCardEvent
+// @Failure 404 {object} models.BusinessCardResponse
+// @Router /business-cards/{id}/events [get]
+func (h *EventHandler) StreamBusinessCardEvents(c *gin.Context) {
+	id := c.Param("id")
+
+	// Subscribe before reading the current state so no transition is missed in between
+	events, unsubscribe := h.broker.Subscribe(services.TenantFromContext(c.Request.Context()), id)
+	defer unsubscribe()
+
+	businessCard, err := h.service.GetBusinessCardRecord(c.Request.Context(), id)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, services.ErrBusinessCardNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, models.BusinessCardResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Business card not found: %v", err),
+		})
+		return
+	}
+
+	logger.LogInfo("StreamBusinessCardEvents", "Event stream opened", map[string]interface{}{
+		"business_card_id": id,
+		"remote_addr":      c.ClientIP(),
+	})
+
+	startEventStream(c)
+
+	writeStatusEvent(c, models.BusinessCardEvent{
+		BusinessCardID: businessCard.ID,
+		Status:         businessCard.Status,
+		Timestamp:      time.Now(),
+		BusinessCard:   *businessCard,
+	})
+	if isFinalStatus(businessCard.Status) {
+		writeEndEvent(c)
+		return
+	}
+
+	streamEvents(c, events, true)
+}
+
+// @Summary Stream status changes of all business cards
+// @Description Server-Sent Events stream with one "status" event per business card transition, for dashboards
+// @Tags business-cards
+// @Produce text/event-stream
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Success 200 {object} models.BusinessCardEvent
+// @Router /business-cards/events [get]
+func (h *EventHandler) StreamAllEvents(c *gin.Context) {
+	events, unsubscribe := h.broker.Subscribe(services.TenantFromContext(c.Request.Context()), "")
+	defer unsubscribe()
+
+	logger.LogInfo("StreamAllEvents", "Event stream opened", map[string]interface{}{
+		"remote_addr": c.ClientIP(),
+	})
+
+	startEventStream(c)
+	streamEvents(c, events, false)
+}
+
+// streamEvents forwards events until the client disconnects or the broker closes the
+// subscription. With stopOnFinal the stream also ends after a final status.
+func streamEvents(c *gin.Context, events <-chan models.BusinessCardEvent, stopOnFinal bool) {
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			writeStatusEvent(c, event)
+			if stopOnFinal && isFinalStatus(event.Status) {
+				writeEndEvent(c)
+				return
+			}
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": heartbeat\n\n")
+			c.Writer.Flush()
+		}
+	}
+}
+
+func startEventStream(c *gin.Context) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+}
+
+func writeStatusEvent(c *gin.Context, event models.BusinessCardEvent) {
+	c.SSEvent(sseEventStatus, event)
+	c.Writer.Flush()
+}
+
+func writeEndEvent(c *gin.Context) {
+	// Lets EventSource clients close instead of reconnecting to a finished card
+	c.SSEvent(sseEventEnd, gin.H{})
+	c.Writer.Flush()
+}
+
+func isFinalStatus(status string) bool {
+	return status == models.StatusCompleted || status == models.StatusNeedsReview || status == models.StatusFailed ||
+		status == models.StatusDeadLetter
+}
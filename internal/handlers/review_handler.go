@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"business-card-reader/internal/logger"
+	"business-card-reader/internal/models"
+	"business-card-reader/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReviewHandler struct {
+	service *services.BusinessCardService
+}
+
+func NewReviewHandler(service *services.BusinessCardService) *ReviewHandler {
+	return &ReviewHandler{
+		service: service,
+	}
+}
+
+// @Summary Get the review queue
+// @Description List the business cards that need a person to confirm them before they go to the CRM, oldest first:
+// @Description NEEDS_REVIEW cards waiting for a reviewer and IN_REVIEW cards claimed by one.
+// @Tags review
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Success 200 {object} models.ReviewQueueResponse
+// @Failure 500 {object} models.ReviewQueueResponse
+// @Router /review-queue [get]
+func (h *ReviewHandler) GetReviewQueue(c *gin.Context) {
+	businessCards, err := h.service.GetReviewQueue(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ReviewQueueResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to retrieve review queue: %v", err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ReviewQueueResponse{
+		Success: true,
+		Data:    businessCards,
+		Count:   len(businessCards),
+	})
+}
+
+// @Summary Claim a business card for review
+// @Description Assign a NEEDS_REVIEW business card to the reviewer named in the X-User header and move it to IN_REVIEW.
+// @Tags review
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "Business Card ID"
+// @Param X-User header string false "Name of the reviewer, used when authentication is off"
+// @Success 200 {object} models.BusinessCardResponse
+// @Failure 404 {object} models.BusinessCardResponse
+// @Failure 409 {object} models.BusinessCardResponse
+// @Failure 500 {object} models.BusinessCardResponse
+// @Router /review-queue/{id}/claim [post]
+func (h *ReviewHandler) ClaimReview(c *gin.Context) {
+	id := c.Param("id")
+
+	businessCard, err := h.service.ClaimReview(c.Request.Context(), id, requestActor(c))
+	if err != nil {
+		c.JSON(reviewErrorStatus(err), models.BusinessCardResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to claim business card: %v", err),
+		})
+		return
+	}
+
+	// Remove image data from response to keep it lightweight
+	for i := range businessCard.Images {
+		businessCard.Images[i].Data = nil
+	}
+
+	c.JSON(http.StatusOK, models.BusinessCardResponse{
+		Success: true,
+		Data:    *businessCard,
+	})
+}
+
+// @Summary Approve a business card
+// @Description Approve a NEEDS_REVIEW business card, or an IN_REVIEW one claimed by the reviewer named in the X-User
+// @Description header, and move it to COMPLETED. Corrections in the body are applied first, like a PATCH of the card.
+// @Tags review
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "Business Card ID"
+// @Param X-User header string false "Name of the reviewer, used when authentication is off"
+// @Param request body models.ReviewApprovalRequest false "Optional corrections"
+// @Success 200 {object} models.BusinessCardResponse
+// @Failure 400 {object} models.BusinessCardResponse
+// @Failure 404 {object} models.BusinessCardResponse
+// @Failure 409 {object} models.BusinessCardResponse
+// @Failure 500 {object} models.BusinessCardResponse
+// @Router /review-queue/{id}/approve [post]
+func (h *ReviewHandler) ApproveReview(c *gin.Context) {
+	id := c.Param("id")
+
+	var request models.ReviewApprovalRequest
+	if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		logger.LogError("ApproveReview", err, map[string]interface{}{
+			"step":             "parse_json_request",
+			"business_card_id": id,
+			"remote_addr":      c.ClientIP(),
+		})
+		c.JSON(http.StatusBadRequest, models.BusinessCardResponse{
+			Success: false,
+			Error:   "Invalid JSON request format",
+		})
+		return
+	}
+
+	businessCard, err := h.service.ApproveReview(c.Request.Context(), id, request.Corrections, requestActor(c))
+	if err != nil {
+		c.JSON(reviewErrorStatus(err), models.BusinessCardResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to approve business card: %v", err),
+		})
+		return
+	}
+
+	// Remove image data from response to keep it lightweight
+	for i := range businessCard.Images {
+		businessCard.Images[i].Data = nil
+	}
+
+	c.JSON(http.StatusOK, models.BusinessCardResponse{
+		Success: true,
+		Data:    *businessCard,
+	})
+}
+
+func reviewErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrBusinessCardNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrInvalidBusinessCardUpdate):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrReviewConflict):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"business-card-reader/internal/models"
+	"business-card-reader/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestReviewHandlerStatusCodes(t *testing.T) {
+	repository := services.NewMemoryRepository()
+	for _, id := range []string{"card", "other"} {
+		businessCard := models.BusinessCard{ID: id, Status: models.StatusNeedsReview, CreatedAt: time.Now()}
+		businessCard.PersonalData.FullName = "Jane Doe"
+		if err := repository.SaveBusinessCard(context.Background(), &businessCard); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Without authentication the reviewer is named in the X-User header
+	handler := NewReviewHandler(services.NewBusinessCardService(repository, unusedExtractor{}))
+	router := gin.New()
+	router.GET("/review-queue", handler.GetReviewQueue)
+	router.POST("/review-queue/:id/claim", handler.ClaimReview)
+	router.POST("/review-queue/:id/approve", handler.ApproveReview)
+
+	tests := []struct {
+		name       string
+		path       string
+		reviewer   string
+		body       string
+		wantStatus int
+	}{
+		{"claim", "/review-queue/card/claim", "alice", "", http.StatusOK},
+		{"claim by another reviewer", "/review-queue/card/claim", "bob", "", http.StatusConflict},
+		{"approve by another reviewer", "/review-queue/card/approve", "bob", "", http.StatusConflict},
+		{"malformed corrections", "/review-queue/card/approve", "alice", `{"corrections":`, http.StatusBadRequest},
+		{"invalid corrections", "/review-queue/card/approve", "alice", `{"corrections":{"personal_data":{"email":"jane-at-acme"}}}`, http.StatusBadRequest},
+		{"approve with corrections", "/review-queue/card/approve", "alice", `{"corrections":{"personal_data":{"email":"jane@acme.com"}}}`, http.StatusOK},
+		{"approve twice", "/review-queue/card/approve", "alice", "", http.StatusConflict},
+		{"approve without body or claim", "/review-queue/other/approve", "bob", "", http.StatusOK},
+		{"unknown card", "/review-queue/missing/claim", "alice", "", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			request.Header.Set("X-User", tt.reviewer)
+			if tt.body != "" {
+				request.Header.Set("Content-Type", "application/json")
+			}
+			response := httptest.NewRecorder()
+			router.ServeHTTP(response, request)
+
+			if response.Code != tt.wantStatus {
+				t.Errorf("status %d, want %d: %s", response.Code, tt.wantStatus, response.Body)
+			}
+		})
+	}
+
+	t.Run("queue is empty after the approvals", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/review-queue", nil))
+
+		var queue models.ReviewQueueResponse
+		if err := json.Unmarshal(response.Body.Bytes(), &queue); err != nil {
+			t.Fatal(err)
+		}
+		if response.Code != http.StatusOK || queue.Count != 0 {
+			t.Errorf("status %d with %d cards in the queue", response.Code, queue.Count)
+		}
+	})
+}
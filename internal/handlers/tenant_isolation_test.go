@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"business-card-reader/internal/config"
+	"business-card-reader/internal/middleware"
+	"business-card-reader/internal/models"
+	"business-card-reader/internal/services"
+	"business-card-reader/internal/services/dynamotest"
+
+	"github.com/gin-gonic/gin"
+)
+
+// unusedExtractor fails every extraction; the isolation tests never run one
+type unusedExtractor struct{}
+
+func (unusedExtractor) Name() string { return "unused" }
+
+func (unusedExtractor) ExtractBusinessCardData(ctx context.Context, images []models.ImageData) (*models.BusinessCard, error) {
+	return nil, context.Canceled
+}
+
+var repositoryBackends = map[string]func(t *testing.T) services.Repository{
+	"memory": func(t *testing.T) services.Repository {
+		return services.NewMemoryRepository()
+	},
+	"sqlite": func(t *testing.T) services.Repository {
+		repository, err := services.NewSQLiteRepository(filepath.Join(t.TempDir(), "cards.db"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { repository.Close() })
+		return repository
+	},
+	"dynamodb": func(t *testing.T) services.Repository {
+		return services.NewDynamoServiceWithClient(dynamotest.NewClient(), "business-card-reader")
+	},
+}
+
+// webhookTestConfig allowlists the receiver host of the tests, which the sandbox can't resolve
+func webhookTestConfig() *config.Config {
+	cfg := &config.Config{}
+	cfg.Webhooks.AllowedHosts = []string{"hooks.example.com"}
+	return cfg
+}
+
+// newTenantRouter serves the tenant-scoped routes behind API key authentication and returns an
+// admin key of each tenant
+func newTenantRouter(t *testing.T, repository services.Repository, tenants ...string) (*gin.Engine, map[string]*models.APIKey) {
+	t.Helper()
+	if err := repository.CreateTableIfNotExists(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	businessCardService := services.NewBusinessCardService(repository, unusedExtractor{})
+	webhookService := services.NewWebhookService(repository, webhookTestConfig())
+	apiKeyService := services.NewAPIKeyService(repository)
+
+	cfg := &config.Config{}
+	cfg.Auth.Mode = middleware.AuthModeAPIKey
+	authenticator, err := middleware.NewAuthenticator(cfg, apiKeyService)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	apiKeys := make(map[string]*models.APIKey, len(tenants))
+	for _, tenantID := range tenants {
+		apiKey, err := apiKeyService.CreateAPIKey(services.WithTenant(context.Background(), tenantID), models.APIKeyRequest{
+			Name:   tenantID + " admin",
+			Scopes: []string{models.ScopeAdmin},
+		}, "test")
+		if err != nil {
+			t.Fatal(err)
+		}
+		apiKeys[tenantID] = apiKey
+	}
+
+	handler := NewBusinessCardHandler(businessCardService)
+	webhookHandler := NewWebhookHandler(webhookService)
+	apiKeyHandler := NewAPIKeyHandler(apiKeyService)
+
+	router := gin.New()
+	api := router.Group("/api/v1", authenticator.Authenticate())
+	{
+		api.GET("/business-cards", handler.GetBusinessCards)
+		api.GET("/business-cards/:id", handler.GetBusinessCardByID)
+		api.PATCH("/business-cards/:id", handler.UpdateBusinessCard)
+		api.DELETE("/business-cards/:id", handler.DeleteBusinessCard)
+
+		api.POST("/api-keys", apiKeyHandler.CreateAPIKey)
+		api.GET("/api-keys", apiKeyHandler.GetAPIKeys)
+		api.DELETE("/api-keys/:id", apiKeyHandler.RevokeAPIKey)
+
+		api.POST("/webhooks", webhookHandler.CreateWebhook)
+		api.GET("/webhooks", webhookHandler.GetWebhooks)
+		api.GET("/webhooks/:id", webhookHandler.GetWebhookByID)
+		api.DELETE("/webhooks/:id", webhookHandler.DeleteWebhook)
+		api.GET("/webhooks/:id/deliveries", webhookHandler.GetWebhookDeliveries)
+	}
+
+	return router, apiKeys
+}
+
+// serve sends a request with the API key and returns the response
+func serve(router *gin.Engine, apiKey *models.APIKey, method string, path string, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, path, strings.NewReader(body))
+	request.Header.Set(middleware.APIKeyHeader, apiKey.Key)
+	if body != "" {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestTenantIsolation(t *testing.T) {
+	for backend, newRepository := range repositoryBackends {
+		t.Run(backend, func(t *testing.T) {
+			repository := newRepository(t)
+			router, apiKeys := newTenantRouter(t, repository, "acme", "globex")
+			acme, globex := apiKeys["acme"], apiKeys["globex"]
+
+			businessCard := models.BusinessCard{ID: "card-1", TenantID: "acme", Status: models.StatusCompleted, CreatedAt: time.Now()}
+			businessCard.PersonalData.FullName = "Jane Doe"
+			if err := repository.SaveBusinessCard(services.WithTenant(context.Background(), "acme"), &businessCard); err != nil {
+				t.Fatal(err)
+			}
+
+			response := serve(router, acme, http.MethodPost, "/api/v1/webhooks", `{"url":"https://hooks.example.com/cards","events":["business_card.completed"]}`)
+			if response.Code != http.StatusCreated {
+				t.Fatalf("create webhook: status %d, body %s", response.Code, response.Body)
+			}
+			var webhook models.WebhookSubscriptionResponse
+			if err := json.Unmarshal(response.Body.Bytes(), &webhook); err != nil {
+				t.Fatal(err)
+			}
+
+			notFound := []struct {
+				method string
+				path   string
+				body   string
+			}{
+				{http.MethodGet, "/api/v1/business-cards/card-1", ""},
+				{http.MethodPatch, "/api/v1/business-cards/card-1", `{"personal_data":{"full_name":"John Roe"}}`},
+				{http.MethodDelete, "/api/v1/business-cards/card-1", ""},
+				{http.MethodGet, "/api/v1/webhooks/" + webhook.Data.ID, ""},
+				{http.MethodGet, "/api/v1/webhooks/" + webhook.Data.ID + "/deliveries", ""},
+				{http.MethodDelete, "/api/v1/webhooks/" + webhook.Data.ID, ""},
+				{http.MethodDelete, "/api/v1/api-keys/" + acme.ID, ""},
+			}
+			for _, request := range notFound {
+				t.Run(request.method+" "+request.path, func(t *testing.T) {
+					if response := serve(router, globex, request.method, request.path, request.body); response.Code != http.StatusNotFound {
+						t.Errorf("another tenant got status %d, want 404: %s", response.Code, response.Body)
+					}
+				})
+			}
+
+			lists := []string{"/api/v1/business-cards", "/api/v1/webhooks", "/api/v1/api-keys"}
+			for _, path := range lists {
+				t.Run("GET "+path, func(t *testing.T) {
+					response := serve(router, globex, http.MethodGet, path, "")
+					var list struct {
+						Data []struct {
+							ID string `json:"id"`
+						} `json:"data"`
+					}
+					if err := json.Unmarshal(response.Body.Bytes(), &list); err != nil || response.Code != http.StatusOK {
+						t.Fatalf("status %d, body %s", response.Code, response.Body)
+					}
+					// The tenant's own key is the only record it may see
+					for _, record := range list.Data {
+						if record.ID != globex.ID {
+							t.Errorf("another tenant lists %s", record.ID)
+						}
+					}
+				})
+			}
+
+			t.Run("owner keeps its records", func(t *testing.T) {
+				for _, path := range []string{"/api/v1/business-cards/card-1", "/api/v1/webhooks/" + webhook.Data.ID} {
+					if response := serve(router, acme, http.MethodGet, path, ""); response.Code != http.StatusOK {
+						t.Errorf("GET %s: status %d, body %s", path, response.Code, response.Body)
+					}
+				}
+				response := serve(router, acme, http.MethodGet, "/api/v1/business-cards/card-1", "")
+				var stored models.BusinessCardResponse
+				if err := json.Unmarshal(response.Body.Bytes(), &stored); err != nil {
+					t.Fatal(err)
+				}
+				if stored.Data.PersonalData.FullName != "Jane Doe" {
+					t.Errorf("another tenant changed the business card to %q", stored.Data.PersonalData.FullName)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"business-card-reader/internal/logger"
+	"business-card-reader/internal/models"
+	"business-card-reader/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	service *services.WebhookService
+}
+
+func NewWebhookHandler(service *services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		service: service,
+	}
+}
+
+// @Summary Register a webhook
+// @Description Subscribe an endpoint to business card events (business_card.completed, business_card.failed,
+// @Description business_card.retried, business_card.dead_lettered, business_card.needs_review). Deliveries are signed with X-Webhook-Signature: sha256=HMAC-SHA256(secret,
+// @Description "<X-Webhook-Timestamp>.<body>"). The secret is generated when omitted and only returned here.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param request body models.WebhookSubscriptionRequest true "Webhook subscription"
+// @Success 201 {object} models.WebhookSubscriptionResponse
+// @Failure 400 {object} models.WebhookSubscriptionResponse
+// @Failure 500 {object} models.WebhookSubscriptionResponse
+// @Router /webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var request models.WebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		logger.LogError("CreateWebhook", err, map[string]interface{}{
+			"step":        "parse_json_request",
+			"remote_addr": c.ClientIP(),
+		})
+		c.JSON(http.StatusBadRequest, models.WebhookSubscriptionResponse{
+			Success: false,
+			Error:   "Invalid JSON request format",
+		})
+		return
+	}
+
+	subscription, err := h.service.CreateSubscription(c.Request.Context(), request)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, services.ErrInvalidWebhookSubscription) {
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, models.WebhookSubscriptionResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to create webhook: %v", err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.WebhookSubscriptionResponse{
+		Success: true,
+		Data:    *subscription,
+	})
+}
+
+// @Summary Get all webhooks
+// @Description Retrieve all webhook subscriptions
+// @Tags webhooks
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Success 200 {object} models.WebhookSubscriptionListResponse
+// @Failure 500 {object} models.WebhookSubscriptionListResponse
+// @Router /webhooks [get]
+func (h *WebhookHandler) GetWebhooks(c *gin.Context) {
+	subscriptions, err := h.service.GetAllSubscriptions(c.Request.Context())
+	if err != nil {
+		logger.LogError("GetWebhooks", err, map[string]interface{}{
+			"step": "get_all_subscriptions",
+		})
+		c.JSON(http.StatusInternalServerError, models.WebhookSubscriptionListResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to retrieve webhooks: %v", err),
+		})
+		return
+	}
+
+	// Secrets are only disclosed when the subscription is created
+	for i := range subscriptions {
+		subscriptions[i].Secret = ""
+	}
+
+	c.JSON(http.StatusOK, models.WebhookSubscriptionListResponse{
+		Success: true,
+		Data:    subscriptions,
+		Count:   len(subscriptions),
+	})
+}
+
+// @Summary Get webhook by ID
+// @Description Retrieve a specific webhook subscription by its ID
+// @Tags webhooks
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} models.WebhookSubscriptionResponse
+// @Failure 404 {object} models.WebhookSubscriptionResponse
+// @Failure 500 {object} models.WebhookSubscriptionResponse
+// @Router /webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhookByID(c *gin.Context) {
+	id := c.Param("id")
+
+	subscription, err := h.service.GetSubscription(c.Request.Context(), id)
+	if err != nil {
+		logger.LogError("GetWebhookByID", err, map[string]interface{}{
+			"step":            "get_subscription",
+			"subscription_id": id,
+		})
+		c.JSON(webhookErrorStatus(err), models.WebhookSubscriptionResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to retrieve webhook: %v", err),
+		})
+		return
+	}
+
+	subscription.Secret = ""
+
+	c.JSON(http.StatusOK, models.WebhookSubscriptionResponse{
+		Success: true,
+		Data:    *subscription,
+	})
+}
+
+// @Summary Delete a webhook
+// @Description Remove a webhook subscription and its delivery log
+// @Tags webhooks
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} models.WebhookSubscriptionResponse
+// @Failure 404 {object} models.WebhookSubscriptionResponse
+// @Failure 500 {object} models.WebhookSubscriptionResponse
+// @Router /webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.service.DeleteSubscription(c.Request.Context(), id); err != nil {
+		logger.LogError("DeleteWebhook", err, map[string]interface{}{
+			"step":            "delete_subscription",
+			"subscription_id": id,
+		})
+		c.JSON(webhookErrorStatus(err), models.WebhookSubscriptionResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to delete webhook: %v", err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.WebhookSubscriptionResponse{
+		Success: true,
+	})
+}
+
+// @Summary Get webhook delivery log
+// @Description Retrieve the deliveries of a webhook subscription, including attempts and last errors
+// @Tags webhooks
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} models.WebhookDeliveryListResponse
+// @Failure 404 {object} models.WebhookDeliveryListResponse
+// @Failure 500 {object} models.WebhookDeliveryListResponse
+// @Router /webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) GetWebhookDeliveries(c *gin.Context) {
+	id := c.Param("id")
+
+	deliveries, err := h.service.GetDeliveries(c.Request.Context(), id)
+	if err != nil {
+		logger.LogError("GetWebhookDeliveries", err, map[string]interface{}{
+			"step":            "get_deliveries",
+			"subscription_id": id,
+		})
+		c.JSON(webhookErrorStatus(err), models.WebhookDeliveryListResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to retrieve webhook deliveries: %v", err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.WebhookDeliveryListResponse{
+		Success: true,
+		Data:    deliveries,
+		Count:   len(deliveries),
+	})
+}
+
+func webhookErrorStatus(err error) int {
+	if errors.Is(err, services.ErrWebhookSubscriptionNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,248 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"business-card-reader/internal/config"
+	"business-card-reader/internal/logger"
+	"business-card-reader/internal/models"
+	"business-card-reader/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Supported authentication modes for config.Config.Auth.Mode
+const (
+	AuthModeNone   = "none"
+	AuthModeAPIKey = "api_key"
+	AuthModeJWT    = "jwt"
+)
+
+// APIKeyHeader carries the API key. With AUTH_MODE=api_key "Authorization: Bearer <key>" is
+// accepted as well; with AUTH_MODE=jwt the bearer token is a JWT.
+const APIKeyHeader = "X-API-Key"
+
+// principalContextKey stores the authenticated models.Principal in the gin context
+const principalContextKey = "principal"
+
+// BootstrapPrincipalID identifies requests authenticated with the API_ADMIN_KEY bootstrap key
+const BootstrapPrincipalID = "bootstrap"
+
+// Authenticator checks the credentials of every request and the roles required by each route.
+// With AUTH_MODE=none both are no-ops and every route is open.
+type Authenticator struct {
+	mode    string
+	apiKeys *services.APIKeyService
+	jwt     *jwtVerifier
+	// adminKeyHash is the SHA-256 of the bootstrap admin key, used to create the first API keys
+	adminKeyHash [sha256.Size]byte
+	hasAdminKey  bool
+}
+
+func NewAuthenticator(cfg *config.Config, apiKeys *services.APIKeyService) (*Authenticator, error) {
+	mode := strings.ToLower(cfg.Auth.Mode)
+	if mode != AuthModeNone && mode != AuthModeAPIKey && mode != AuthModeJWT {
+		return nil, fmt.Errorf("unsupported auth mode %q, available modes: %s, %s, %s", cfg.Auth.Mode, AuthModeNone, AuthModeAPIKey, AuthModeJWT)
+	}
+
+	authenticator := &Authenticator{
+		mode:    mode,
+		apiKeys: apiKeys,
+	}
+	if mode == AuthModeJWT {
+		verifier, err := newJWTVerifier(cfg)
+		if err != nil {
+			return nil, err
+		}
+		authenticator.jwt = verifier
+	}
+	if cfg.Auth.AdminKey != "" {
+		authenticator.adminKeyHash = sha256.Sum256([]byte(cfg.Auth.AdminKey))
+		authenticator.hasAdminKey = true
+	}
+
+	logger.LogInfo("NewAuthenticator", "Authentication configured", map[string]interface{}{
+		"mode":          mode,
+		"bootstrap_key": authenticator.hasAdminKey,
+	})
+	switch {
+	case mode == AuthModeNone:
+		logger.LogWarn("NewAuthenticator", "AUTHENTICATION IS DISABLED: AUTH_MODE=none leaves every route open to anyone who can reach the server, use it for local development only", map[string]interface{}{
+			"mode": mode,
+		})
+	case mode == AuthModeAPIKey && !authenticator.hasAdminKey:
+		logger.LogWarn("NewAuthenticator", "API_ADMIN_KEY is not set, only API keys created earlier can sign in", map[string]interface{}{
+			"mode": mode,
+		})
+	}
+
+	return authenticator, nil
+}
+
+// Enabled reports whether requests must be authenticated
+func (a *Authenticator) Enabled() bool {
+	return a.mode != AuthModeNone
+}
+
+// Authenticate rejects requests without a valid credential with 401, stores the principal of the
+// others for RequireRole and the handlers and scopes the request context to its tenant. With
+// authentication off every request belongs to the default tenant.
+func (a *Authenticator) Authenticate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !a.Enabled() {
+			c.Next()
+			return
+		}
+
+		key := strings.TrimSpace(c.GetHeader(APIKeyHeader))
+		if a.mode == AuthModeJWT {
+			if key == "" {
+				a.authenticateJWT(c)
+				return
+			}
+		} else if key == "" {
+			key = bearerToken(c)
+		}
+		if key == "" {
+			abortUnauthorized(c, "API key required")
+			return
+		}
+
+		if a.hasAdminKey {
+			hash := sha256.Sum256([]byte(key))
+			if subtle.ConstantTimeCompare(hash[:], a.adminKeyHash[:]) == 1 {
+				setPrincipal(c, &models.Principal{
+					ID:       BootstrapPrincipalID,
+					Name:     BootstrapPrincipalID,
+					Roles:    []string{models.RoleAdmin},
+					TenantID: services.DefaultTenant,
+				})
+				c.Next()
+				return
+			}
+		}
+
+		apiKey, err := a.apiKeys.Authenticate(c.Request.Context(), key)
+		if errors.Is(err, services.ErrInvalidAPIKey) {
+			logger.LogWarn("Authenticate", "Rejected invalid API key", map[string]interface{}{
+				"path":        c.Request.URL.Path,
+				"remote_addr": c.ClientIP(),
+			})
+			abortUnauthorized(c, "Invalid API key")
+			return
+		}
+		if err != nil {
+			logger.LogError("Authenticate", err, map[string]interface{}{
+				"path": c.Request.URL.Path,
+			})
+			c.AbortWithStatusJSON(http.StatusInternalServerError, models.ErrorResponse{
+				Success: false,
+				Error:   "Failed to check API key",
+			})
+			return
+		}
+
+		tenantID := apiKey.TenantID
+		if tenantID == "" {
+			tenantID = services.DefaultTenant
+		}
+		setPrincipal(c, &models.Principal{
+			ID:       apiKey.ID,
+			Name:     apiKey.Name,
+			Roles:    services.ScopeRoles(apiKey.Scopes),
+			TenantID: tenantID,
+		})
+		c.Next()
+	}
+}
+
+// authenticateJWT authenticates the request with the bearer JWT issued by the identity provider
+func (a *Authenticator) authenticateJWT(c *gin.Context) {
+	token := bearerToken(c)
+	if token == "" {
+		abortUnauthorized(c, "Bearer token required")
+		return
+	}
+
+	principal, err := a.jwt.verify(c.Request.Context(), token)
+	if err != nil {
+		logger.LogWarn("Authenticate", "Rejected invalid bearer token", map[string]interface{}{
+			"path":        c.Request.URL.Path,
+			"remote_addr": c.ClientIP(),
+			"error":       err.Error(),
+		})
+		abortUnauthorized(c, "Invalid bearer token")
+		return
+	}
+
+	setPrincipal(c, principal)
+	c.Next()
+}
+
+// RequireRole rejects requests whose principal lacks the role with 403. Roles include the ones
+// below them, admin includes every role.
+func (a *Authenticator) RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !a.Enabled() {
+			c.Next()
+			return
+		}
+
+		principal, ok := PrincipalFromContext(c)
+		if !ok {
+			abortUnauthorized(c, "Authentication required")
+			return
+		}
+
+		if !services.HasRole(principal.Roles, role) {
+			logger.LogWarn("RequireRole", "Rejected request without required role", map[string]interface{}{
+				"path":          c.Request.URL.Path,
+				"principal":     principal.ID,
+				"required_role": role,
+			})
+			c.AbortWithStatusJSON(http.StatusForbidden, models.ErrorResponse{
+				Success: false,
+				Error:   fmt.Sprintf("Caller lacks the %s role", role),
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// PrincipalFromContext returns the authenticated caller of the request
+func PrincipalFromContext(c *gin.Context) (*models.Principal, bool) {
+	value, ok := c.Get(principalContextKey)
+	if !ok {
+		return nil, false
+	}
+	principal, ok := value.(*models.Principal)
+	return principal, ok
+}
+
+func setPrincipal(c *gin.Context, principal *models.Principal) {
+	c.Set(principalContextKey, principal)
+	c.Request = c.Request.WithContext(services.WithTenant(c.Request.Context(), principal.TenantID))
+}
+
+func bearerToken(c *gin.Context) string {
+	scheme, credentials, found := strings.Cut(c.GetHeader("Authorization"), " ")
+	if found && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(credentials)
+	}
+	return ""
+}
+
+func abortUnauthorized(c *gin.Context, message string) {
+	c.Header("WWW-Authenticate", `Bearer realm="business-card-reader"`)
+	c.AbortWithStatusJSON(http.StatusUnauthorized, models.ErrorResponse{
+		Success: false,
+		Error:   message,
+	})
+}
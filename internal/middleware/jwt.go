@@ -0,0 +1,444 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"business-card-reader/internal/config"
+	"business-card-reader/internal/logger"
+	"business-card-reader/internal/models"
+	"business-card-reader/internal/services"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwtLeeway tolerates clock skew between the identity provider and this service
+const jwtLeeway = 30 * time.Second
+
+// jwksRefreshInterval is how long keys fetched from a JWKS URL are used before fetching them again
+const jwksRefreshInterval = time.Hour
+
+// jwksMinRefreshInterval bounds how often tokens signed with an unknown key can trigger a fetch
+const jwksMinRefreshInterval = time.Minute
+
+// jwksFetchTimeout bounds a single request to the JWKS URL
+const jwksFetchTimeout = 10 * time.Second
+
+// jwtSigningMethods are the accepted asymmetric algorithms. HMAC and "none" are rejected, so a
+// public key can never be used as a shared secret.
+var jwtSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// errUnknownSigningKey is returned when no key matches the token's key ID
+var errUnknownSigningKey = errors.New("unknown signing key")
+
+// jwtVerifier validates bearer tokens issued by an OIDC provider and maps their claims to a principal
+type jwtVerifier struct {
+	keys        *jwtKeySet
+	parser      *jwt.Parser
+	rolesClaim  string
+	tenantClaim string
+	roleMapping map[string]string
+}
+
+func newJWTVerifier(cfg *config.Config) (*jwtVerifier, error) {
+	jwtConfig := cfg.Auth.JWT
+	if (jwtConfig.JWKSURL == "") == (jwtConfig.KeyFile == "") {
+		return nil, fmt.Errorf("jwt auth mode needs exactly one of JWT_JWKS_URL and JWT_KEY_FILE")
+	}
+	for claimValue, role := range jwtConfig.RoleMapping {
+		if !services.IsRole(role) {
+			return nil, fmt.Errorf("JWT_ROLE_MAPPING maps %q to unsupported role %q", claimValue, role)
+		}
+	}
+
+	keys := &jwtKeySet{
+		url:    jwtConfig.JWKSURL,
+		client: &http.Client{Timeout: jwksFetchTimeout},
+		keys:   make(map[string]interface{}),
+	}
+	if jwtConfig.KeyFile != "" {
+		if err := keys.loadFile(jwtConfig.KeyFile); err != nil {
+			return nil, err
+		}
+	} else if err := keys.refresh(context.Background()); err != nil {
+		// The identity provider may come up after this service, keys are fetched again on first use
+		logger.LogWarn("NewAuthenticator", "Could not fetch JWKS, retrying on first request", map[string]interface{}{
+			"jwks_url": jwtConfig.JWKSURL,
+			"error":    err.Error(),
+		})
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(jwtSigningMethods),
+		jwt.WithLeeway(jwtLeeway),
+		jwt.WithExpirationRequired(),
+	}
+	if jwtConfig.Issuer != "" {
+		options = append(options, jwt.WithIssuer(jwtConfig.Issuer))
+	}
+	if jwtConfig.Audience != "" {
+		options = append(options, jwt.WithAudience(jwtConfig.Audience))
+	}
+
+	return &jwtVerifier{
+		keys:        keys,
+		parser:      jwt.NewParser(options...),
+		rolesClaim:  jwtConfig.RolesClaim,
+		tenantClaim: jwtConfig.TenantClaim,
+		roleMapping: jwtConfig.RoleMapping,
+	}, nil
+}
+
+// verify checks the token's signature, expiry, issuer and audience and returns its principal
+func (v *jwtVerifier) verify(ctx context.Context, tokenString string) (*models.Principal, error) {
+	claims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		keyID, _ := token.Header["kid"].(string)
+		return v.keys.key(ctx, keyID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return nil, errors.New("token has no subject")
+	}
+
+	name := subject
+	for _, claim := range []string{"preferred_username", "email"} {
+		if value, ok := claims[claim].(string); ok && value != "" {
+			name = value
+			break
+		}
+	}
+
+	tenantID := services.DefaultTenant
+	if value, ok := claimValue(claims, v.tenantClaim).(string); ok && value != "" {
+		if err := services.ValidateTenantID(value); err != nil {
+			return nil, err
+		}
+		tenantID = value
+	}
+
+	return &models.Principal{
+		ID:       subject,
+		Name:     name,
+		Roles:    v.roles(claims),
+		TenantID: tenantID,
+	}, nil
+}
+
+// roles maps the values of the roles claim to roles through the role mapping. Values that are
+// already role names are kept, others are ignored.
+func (v *jwtVerifier) roles(claims jwt.MapClaims) []string {
+	var values []string
+	switch value := claimValue(claims, v.rolesClaim).(type) {
+	case string:
+		values = strings.FieldsFunc(value, func(r rune) bool { return r == ' ' || r == ',' })
+	case []interface{}:
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+
+	var roles []string
+	for _, value := range values {
+		role, ok := v.roleMapping[value]
+		if !ok {
+			role = value
+		}
+		if services.IsRole(role) && !slices.Contains(roles, role) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// claimValue looks up a claim by its dotted path, such as realm_access.roles
+func claimValue(claims jwt.MapClaims, path string) interface{} {
+	var value interface{} = map[string]interface{}(claims)
+	for _, name := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = object[name]
+	}
+	return value
+}
+
+// jwtKeySet holds the public keys tokens are verified with, by key ID. Keys from a JWKS URL are
+// fetched again periodically and when a token names an unknown key, which picks up key rotations.
+type jwtKeySet struct {
+	url       string
+	client    *http.Client
+	mu        sync.RWMutex
+	keys      map[string]interface{}
+	fetchedAt time.Time
+}
+
+func (s *jwtKeySet) key(ctx context.Context, keyID string) (interface{}, error) {
+	if key, ok := s.lookup(keyID); ok {
+		return key, nil
+	}
+	if s.url == "" {
+		return nil, errUnknownSigningKey
+	}
+
+	s.mu.RLock()
+	recentlyFetched := time.Since(s.fetchedAt) < jwksMinRefreshInterval
+	s.mu.RUnlock()
+	if recentlyFetched {
+		return nil, errUnknownSigningKey
+	}
+
+	if err := s.refresh(ctx); err != nil {
+		return nil, err
+	}
+	if key, ok := s.lookup(keyID); ok {
+		return key, nil
+	}
+	return nil, errUnknownSigningKey
+}
+
+// lookup returns the key with the ID. A set of one key also verifies tokens without key ID and,
+// when it was loaded from a file, tokens naming any key ID: a PEM key has none, and a local
+// stand-in can't be expected to sign with the ID of the file's key.
+func (s *jwtKeySet) lookup(keyID string) (interface{}, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.url != "" && time.Since(s.fetchedAt) > jwksRefreshInterval {
+		return nil, false
+	}
+	if key, ok := s.keys[keyID]; ok {
+		return key, true
+	}
+	if (keyID == "" || s.url == "") && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+func (s *jwtKeySet) refresh(ctx context.Context) error {
+	s.mu.Lock()
+	s.fetchedAt = time.Now()
+	s.mu.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create JWKS request: %w", err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch JWKS: status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read JWKS: %w", err)
+	}
+
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.mu.Unlock()
+
+	logger.LogInfo("JWKS", "Signing keys refreshed", map[string]interface{}{
+		"jwks_url": s.url,
+		"keys":     len(keys),
+	})
+
+	return nil
+}
+
+// loadFile reads a static JWKS document or a PEM public key or certificate, which lets a local
+// stand-in replace the identity provider
+func (s *jwtKeySet) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read JWT key file: %w", err)
+	}
+
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		keys, err := parseJWKS(trimmed)
+		if err != nil {
+			return err
+		}
+		s.keys = keys
+		return nil
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return fmt.Errorf("JWT key file %s is neither a JWKS document nor PEM", path)
+	}
+
+	var key interface{}
+	switch block.Type {
+	case "CERTIFICATE":
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("failed to parse JWT key file certificate: %w", err)
+		}
+		key = certificate.PublicKey
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse JWT key file: %w", err)
+	}
+
+	s.keys = map[string]interface{}{"": key}
+	return nil
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWKS returns the signing keys of a JWKS document by key ID, skipping encryption keys and
+// key types that can't verify an accepted algorithm
+func parseJWKS(data []byte) (map[string]interface{}, error) {
+	var document struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS: %w", err)
+	}
+
+	keys := make(map[string]interface{})
+	for _, jwk := range document.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			logger.LogWarn("JWKS", "Skipping unusable key", map[string]interface{}{
+				"kid":   jwk.Kid,
+				"kty":   jwk.Kty,
+				"error": err.Error(),
+			})
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("JWKS has no usable signing keys")
+	}
+	return keys, nil
+}
+
+func (k jsonWebKey) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeKeyParameter(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeKeyParameter(k.E)
+		if err != nil {
+			return nil, err
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() < 2 || exponent.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		var exchangeCurve ecdh.Curve
+		switch k.Crv {
+		case "P-256":
+			curve, exchangeCurve = elliptic.P256(), ecdh.P256()
+		case "P-384":
+			curve, exchangeCurve = elliptic.P384(), ecdh.P384()
+		case "P-521":
+			curve, exchangeCurve = elliptic.P521(), ecdh.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeKeyParameter(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeKeyParameter(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) != size || len(y) != size {
+			return nil, errors.New("invalid EC coordinates")
+		}
+		// Rejects points that aren't on the curve
+		if _, err := exchangeCurve.NewPublicKey(append(append([]byte{4}, x...), y...)); err != nil {
+			return nil, fmt.Errorf("invalid EC point: %w", err)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeKeyParameter(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeKeyParameter(value string) ([]byte, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil || len(decoded) == 0 {
+		return nil, errors.New("invalid key parameter")
+	}
+	return decoded, nil
+}
@@ -0,0 +1,299 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"business-card-reader/internal/config"
+	"business-card-reader/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func generateRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// signToken signs claims for the subject "jane" with the key, naming the key ID unless it is empty
+func signToken(t *testing.T, key *rsa.PrivateKey, keyID string, claims jwt.MapClaims) string {
+	t.Helper()
+	tokenClaims := jwt.MapClaims{
+		"sub":   "jane",
+		"email": "jane@acme.com",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}
+	for name, value := range claims {
+		tokenClaims[name] = value
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, tokenClaims)
+	if keyID != "" {
+		token.Header["kid"] = keyID
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+// jwksDocument publishes the public keys by key ID
+func jwksDocument(t *testing.T, keys map[string]*rsa.PrivateKey) []byte {
+	t.Helper()
+	var document struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	for keyID, key := range keys {
+		document.Keys = append(document.Keys, jsonWebKey{
+			Kty: "RSA",
+			Kid: keyID,
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+	data, err := json.Marshal(document)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func writeKeyFile(t *testing.T, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "jwt-key")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func jwtConfig() *config.Config {
+	cfg := &config.Config{}
+	cfg.Auth.Mode = AuthModeJWT
+	cfg.Auth.JWT.Issuer = "https://id.example.com"
+	cfg.Auth.JWT.RolesClaim = "realm_access.roles"
+	cfg.Auth.JWT.TenantClaim = "tenant_id"
+	cfg.Auth.JWT.RoleMapping = map[string]string{"card-scanners": models.RoleScanner}
+	return cfg
+}
+
+func TestJWTStaticKeyFile(t *testing.T) {
+	key := generateRSAKey(t)
+	otherKey := generateRSAKey(t)
+
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFiles := map[string][]byte{
+		"PEM public key":     pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}),
+		"PKCS #1 public key": pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&key.PublicKey)}),
+		"JWKS with one key":  jwksDocument(t, map[string]*rsa.PrivateKey{"file-key": key}),
+	}
+
+	valid := jwt.MapClaims{"iss": "https://id.example.com"}
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{name: "without key ID", token: signToken(t, key, "", valid)},
+		{name: "with the identity provider's key ID", token: signToken(t, key, "idp-2024", valid)},
+		{name: "signed with another key", token: signToken(t, otherKey, "", valid), wantErr: true},
+		{name: "wrong issuer", token: signToken(t, key, "", jwt.MapClaims{"iss": "https://evil.example.com"}), wantErr: true},
+		{name: "expired", token: signToken(t, key, "", jwt.MapClaims{"iss": "https://id.example.com", "exp": time.Now().Add(-time.Hour).Unix()}), wantErr: true},
+		{name: "HMAC with the public key as secret", token: func() string {
+			signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+				"sub": "jane", "iss": "https://id.example.com", "exp": time.Now().Add(time.Hour).Unix(),
+			}).SignedString(keyFiles["PEM public key"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return signed
+		}(), wantErr: true},
+	}
+
+	for fileName, data := range keyFiles {
+		t.Run(fileName, func(t *testing.T) {
+			cfg := jwtConfig()
+			cfg.Auth.JWT.KeyFile = writeKeyFile(t, data)
+			verifier, err := newJWTVerifier(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					principal, err := verifier.verify(context.Background(), tt.token)
+					if tt.wantErr {
+						if err == nil {
+							t.Errorf("accepted token as %+v", principal)
+						}
+						return
+					}
+					if err != nil {
+						t.Fatal(err)
+					}
+					if principal.ID != "jane" || principal.Name != "jane@acme.com" {
+						t.Errorf("principal = %+v", principal)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestJWTKeyFileWithSeveralKeysNeedsKeyID(t *testing.T) {
+	first, second := generateRSAKey(t), generateRSAKey(t)
+	cfg := jwtConfig()
+	cfg.Auth.JWT.KeyFile = writeKeyFile(t, jwksDocument(t, map[string]*rsa.PrivateKey{"first": first, "second": second}))
+	verifier, err := newJWTVerifier(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims := jwt.MapClaims{"iss": "https://id.example.com"}
+	if _, err := verifier.verify(context.Background(), signToken(t, second, "second", claims)); err != nil {
+		t.Errorf("token naming its key: %v", err)
+	}
+	for _, keyID := range []string{"", "third"} {
+		if _, err := verifier.verify(context.Background(), signToken(t, second, keyID, claims)); !errors.Is(err, errUnknownSigningKey) {
+			t.Errorf("key ID %q: got %v, want errUnknownSigningKey", keyID, err)
+		}
+	}
+}
+
+// jwksServer publishes the current keys and counts the fetches
+type jwksServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	keys    map[string]*rsa.PrivateKey
+	fetches atomic.Int32
+}
+
+func newJWKSServer(t *testing.T, keys map[string]*rsa.PrivateKey) *jwksServer {
+	t.Helper()
+	server := &jwksServer{keys: keys}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.fetches.Add(1)
+		server.mu.Lock()
+		document := jwksDocument(t, server.keys)
+		server.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(document)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (s *jwksServer) rotate(keys map[string]*rsa.PrivateKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keys
+}
+
+func TestJWTJWKS(t *testing.T) {
+	current, next := generateRSAKey(t), generateRSAKey(t)
+	server := newJWKSServer(t, map[string]*rsa.PrivateKey{"current": current})
+
+	cfg := jwtConfig()
+	cfg.Auth.JWT.JWKSURL = server.URL
+	authenticator, err := NewAuthenticator(cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier := authenticator.jwt
+
+	router := gin.New()
+	router.GET("/cards", authenticator.Authenticate(), authenticator.RequireRole(models.RoleScanner), func(c *gin.Context) {
+		principal, _ := PrincipalFromContext(c)
+		c.JSON(http.StatusOK, principal)
+	})
+	request := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/cards", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	claims := jwt.MapClaims{
+		"iss":          "https://id.example.com",
+		"tenant_id":    "acme",
+		"realm_access": map[string]interface{}{"roles": []string{"card-scanners", "offline_access"}},
+	}
+
+	t.Run("token signed with a published key", func(t *testing.T) {
+		response := request(signToken(t, current, "current", claims))
+		if response.Code != http.StatusOK {
+			t.Fatalf("status %d, body %s", response.Code, response.Body)
+		}
+		var principal models.Principal
+		if err := json.Unmarshal(response.Body.Bytes(), &principal); err != nil {
+			t.Fatal(err)
+		}
+		if principal.TenantID != "acme" || !slices.Equal(principal.Roles, []string{models.RoleScanner}) {
+			t.Errorf("principal = %+v", principal)
+		}
+	})
+
+	t.Run("token without the required role", func(t *testing.T) {
+		if response := request(signToken(t, current, "current", jwt.MapClaims{"iss": "https://id.example.com"})); response.Code != http.StatusForbidden {
+			t.Errorf("status %d, want 403", response.Code)
+		}
+	})
+
+	t.Run("unknown key ID doesn't refetch right away", func(t *testing.T) {
+		fetches := server.fetches.Load()
+		if response := request(signToken(t, next, "next", claims)); response.Code != http.StatusUnauthorized {
+			t.Errorf("status %d, want 401", response.Code)
+		}
+		if server.fetches.Load() != fetches {
+			t.Error("unknown key ID fetched the JWKS again within the minimum refresh interval")
+		}
+	})
+
+	t.Run("rotated key is fetched", func(t *testing.T) {
+		server.rotate(map[string]*rsa.PrivateKey{"current": current, "next": next})
+		verifier.keys.mu.Lock()
+		verifier.keys.fetchedAt = time.Now().Add(-2 * jwksMinRefreshInterval)
+		verifier.keys.mu.Unlock()
+
+		fetches := server.fetches.Load()
+		if response := request(signToken(t, next, "next", claims)); response.Code != http.StatusOK {
+			t.Errorf("status %d, body %s", response.Code, response.Body)
+		}
+		if server.fetches.Load() != fetches+1 {
+			t.Errorf("fetched %d times, want once", server.fetches.Load()-fetches)
+		}
+	})
+
+	t.Run("key ID is required with several published keys", func(t *testing.T) {
+		if _, err := verifier.verify(context.Background(), signToken(t, next, "", claims)); !errors.Is(err, errUnknownSigningKey) {
+			t.Errorf("got %v, want errUnknownSigningKey", err)
+		}
+	})
+}
@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"os"
+	"testing"
+
+	"business-card-reader/internal/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMain(m *testing.M) {
+	logger.Init()
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
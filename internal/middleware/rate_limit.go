@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"business-card-reader/internal/config"
+	"business-card-reader/internal/logger"
+	"business-card-reader/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+// rateLimiterIdleTTL is how long the bucket of a client without requests is kept. By then a bucket
+// is full again, so dropping it loses nothing.
+const rateLimiterIdleTTL = 10 * time.Minute
+
+// rateLimiterCleanupInterval is how often idle buckets are dropped
+const rateLimiterCleanupInterval = time.Minute
+
+// RateLimiter gives every API key or JWT subject and every client IP a token bucket, so a single
+// misbehaving client can't use up the extraction provider's quota
+type RateLimiter struct {
+	keyLimit rate.Limit
+	keyBurst int
+	ipLimit  rate.Limit
+	ipBurst  int
+
+	mu          sync.Mutex
+	buckets     map[string]*rateBucket
+	lastCleanup time.Time
+}
+
+type rateBucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+func NewRateLimiter(cfg *config.Config) *RateLimiter {
+	limiter := &RateLimiter{
+		keyLimit:    perMinute(cfg.RateLimit.KeyRequestsPerMinute),
+		keyBurst:    max(cfg.RateLimit.KeyBurst, 1),
+		ipLimit:     perMinute(cfg.RateLimit.IPRequestsPerMinute),
+		ipBurst:     max(cfg.RateLimit.IPBurst, 1),
+		buckets:     make(map[string]*rateBucket),
+		lastCleanup: time.Now(),
+	}
+
+	logger.LogInfo("NewRateLimiter", "Rate limits configured", map[string]interface{}{
+		"key_requests_per_minute": cfg.RateLimit.KeyRequestsPerMinute,
+		"key_burst":               limiter.keyBurst,
+		"ip_requests_per_minute":  cfg.RateLimit.IPRequestsPerMinute,
+		"ip_burst":                limiter.ipBurst,
+	})
+
+	return limiter
+}
+
+// perMinute converts a rate per minute to a rate.Limit, 0 turning the limit off
+func perMinute(requests float64) rate.Limit {
+	if requests <= 0 {
+		return rate.Inf
+	}
+	return rate.Limit(requests / 60)
+}
+
+// Limit rejects requests over the client's rate with 429 and a Retry-After header. It takes a token
+// from the client IP's bucket and, after Authenticate, from the bucket of the API key or JWT
+// subject; a rejected request takes none.
+func (l *RateLimiter) Limit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		now := time.Now()
+
+		keys := []string{"ip:" + c.ClientIP()}
+		limits := []rate.Limit{l.ipLimit}
+		bursts := []int{l.ipBurst}
+		if principal, ok := PrincipalFromContext(c); ok {
+			keys = append(keys, "principal:"+principal.TenantID+"/"+principal.ID)
+			limits = append(limits, l.keyLimit)
+			bursts = append(bursts, l.keyBurst)
+		}
+
+		reservations := make([]*rate.Reservation, 0, len(keys))
+		var delay time.Duration
+		for i, key := range keys {
+			if limits[i] == rate.Inf {
+				continue
+			}
+			reservation := l.bucket(key, limits[i], bursts[i], now).ReserveN(now, 1)
+			reservations = append(reservations, reservation)
+			delay = max(delay, reservation.DelayFrom(now))
+		}
+		if delay == 0 {
+			c.Next()
+			return
+		}
+
+		for _, reservation := range reservations {
+			reservation.CancelAt(now)
+		}
+
+		logger.LogWarn("RateLimit", "Rejected request over rate limit", map[string]interface{}{
+			"path":        c.Request.URL.Path,
+			"clients":     keys,
+			"retry_after": delay.String(),
+		})
+		AbortTooManyRequests(c, delay, "Rate limit exceeded")
+	}
+}
+
+func (l *RateLimiter) bucket(key string, limit rate.Limit, burst int, now time.Time) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastCleanup) > rateLimiterCleanupInterval {
+		for bucketKey, bucket := range l.buckets {
+			if now.Sub(bucket.lastSeen) > rateLimiterIdleTTL {
+				delete(l.buckets, bucketKey)
+			}
+		}
+		l.lastCleanup = now
+	}
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &rateBucket{limiter: rate.NewLimiter(limit, burst)}
+		l.buckets[key] = bucket
+	}
+	bucket.lastSeen = now
+	return bucket.limiter
+}
+
+// AbortTooManyRequests answers 429 with a Retry-After header of the delay rounded up to seconds
+func AbortTooManyRequests(c *gin.Context, retryAfter time.Duration, message string) {
+	seconds := max(int(math.Ceil(retryAfter.Seconds())), 1)
+	c.Header("Retry-After", strconv.Itoa(seconds))
+	c.AbortWithStatusJSON(http.StatusTooManyRequests, models.ErrorResponse{
+		Success: false,
+		Error:   message,
+	})
+}
@@ -0,0 +1,160 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"business-card-reader/internal/config"
+	"business-card-reader/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newRateLimitedRouter stands in for Authenticate with the principal named in the X-Principal header
+func newRateLimitedRouter(cfg *config.Config) *gin.Engine {
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if id := c.GetHeader("X-Principal"); id != "" {
+			setPrincipal(c, &models.Principal{ID: id, TenantID: "acme"})
+		}
+	}, NewRateLimiter(cfg).Limit())
+	router.POST("/business-cards", func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+	return router
+}
+
+func rateLimitedRequest(router *gin.Engine, ip string, principal string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodPost, "/business-cards", nil)
+	request.RemoteAddr = ip + ":40000"
+	if principal != "" {
+		request.Header.Set("X-Principal", principal)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestRateLimiter(t *testing.T) {
+	type request struct {
+		ip         string
+		principal  string
+		wantStatus int
+	}
+
+	tests := []struct {
+		name     string
+		limits   func(cfg *config.Config)
+		requests []request
+	}{
+		{
+			name: "per client ip",
+			limits: func(cfg *config.Config) {
+				cfg.RateLimit.IPRequestsPerMinute = 1
+				cfg.RateLimit.IPBurst = 2
+			},
+			requests: []request{
+				{"203.0.113.1", "", http.StatusCreated},
+				{"203.0.113.1", "", http.StatusCreated},
+				{"203.0.113.1", "", http.StatusTooManyRequests},
+				{"203.0.113.2", "", http.StatusCreated},
+			},
+		},
+		{
+			name: "per principal",
+			limits: func(cfg *config.Config) {
+				cfg.RateLimit.KeyRequestsPerMinute = 1
+				cfg.RateLimit.KeyBurst = 1
+			},
+			requests: []request{
+				{"203.0.113.1", "key-a", http.StatusCreated},
+				{"203.0.113.2", "key-a", http.StatusTooManyRequests},
+				{"203.0.113.1", "key-b", http.StatusCreated},
+				// Unauthenticated requests only count against the IP
+				{"203.0.113.1", "", http.StatusCreated},
+			},
+		},
+		{
+			name: "rejected requests take no token",
+			limits: func(cfg *config.Config) {
+				cfg.RateLimit.KeyRequestsPerMinute = 1
+				cfg.RateLimit.KeyBurst = 1
+				cfg.RateLimit.IPRequestsPerMinute = 1
+				cfg.RateLimit.IPBurst = 2
+			},
+			requests: []request{
+				{"203.0.113.1", "key-a", http.StatusCreated},
+				{"203.0.113.1", "key-a", http.StatusTooManyRequests},
+				{"203.0.113.1", "key-b", http.StatusCreated},
+				{"203.0.113.1", "key-c", http.StatusTooManyRequests},
+				{"203.0.113.2", "key-c", http.StatusCreated},
+			},
+		},
+		{
+			name:   "zero turns the limits off",
+			limits: func(cfg *config.Config) {},
+			requests: []request{
+				{"203.0.113.1", "key-a", http.StatusCreated},
+				{"203.0.113.1", "key-a", http.StatusCreated},
+				{"203.0.113.1", "key-a", http.StatusCreated},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			tt.limits(cfg)
+			router := newRateLimitedRouter(cfg)
+
+			for i, request := range tt.requests {
+				response := rateLimitedRequest(router, request.ip, request.principal)
+				if response.Code != request.wantStatus {
+					t.Fatalf("request %d from %s as %q: status %d, want %d", i+1, request.ip, request.principal, response.Code, request.wantStatus)
+				}
+				if response.Code != http.StatusTooManyRequests {
+					continue
+				}
+
+				// A token of one per minute is a minute away
+				retryAfter, err := strconv.Atoi(response.Header().Get("Retry-After"))
+				if err != nil || retryAfter < 55 || retryAfter > 60 {
+					t.Errorf("request %d: Retry-After %q, want about a minute", i+1, response.Header().Get("Retry-After"))
+				}
+				var body models.ErrorResponse
+				if err := json.Unmarshal(response.Body.Bytes(), &body); err != nil || body.Success || body.Error == "" {
+					t.Errorf("request %d: body %s", i+1, response.Body)
+				}
+			}
+		})
+	}
+}
+
+func TestAbortTooManyRequestsRoundsUp(t *testing.T) {
+	tests := []struct {
+		retryAfter time.Duration
+		want       string
+	}{
+		{0, "1"},
+		{200 * time.Millisecond, "1"},
+		{time.Second, "1"},
+		{1500 * time.Millisecond, "2"},
+		{time.Minute, "60"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.retryAfter.String(), func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(recorder)
+			AbortTooManyRequests(c, tt.retryAfter, "busy")
+
+			if recorder.Code != http.StatusTooManyRequests || recorder.Header().Get("Retry-After") != tt.want {
+				t.Errorf("status %d with Retry-After %q, want 429 with %q", recorder.Code, recorder.Header().Get("Retry-After"), tt.want)
+			}
+		})
+	}
+}
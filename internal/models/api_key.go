@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+)
+
+// APIKey authenticates a client. Only the SHA-256 hash of the key is stored; the key itself is
+// returned once, when it is created.
+type APIKey struct {
+	ID         string     `json:"id" dynamodbav:"id"`
+	Name       string     `json:"name" dynamodbav:"name"`
+	TenantID   string     `json:"tenant_id,omitempty" dynamodbav:"tenant_id,omitempty"`
+	Key        string     `json:"key,omitempty" dynamodbav:"-"`
+	KeyHash    string     `json:"key_hash,omitempty" dynamodbav:"key_hash"`
+	Scopes     []string   `json:"scopes" dynamodbav:"scopes"`
+	CreatedBy  string     `json:"created_by" dynamodbav:"created_by"`
+	CreatedAt  time.Time  `json:"created_at" dynamodbav:"created_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" dynamodbav:"revoked_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" dynamodbav:"last_used_at,omitempty"`
+}
+
+// API key scopes. Each scope grants roles, see services.ScopeRoles.
+const (
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+	ScopeAdmin = "admin"
+)
+
+// APIKeyRequest represents the payload for creating an API key
+type APIKeyRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+	// TenantID defaults to the tenant of the caller. Only the bootstrap key can create keys
+	// for other tenants.
+	TenantID string `json:"tenant_id,omitempty"`
+}
+
+// APIKeyResponse represents the API key API response
+type APIKeyResponse struct {
+	Success bool   `json:"success"`
+	Data    APIKey `json:"data,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// APIKeyListResponse represents the API key list API response
+type APIKeyListResponse struct {
+	Success bool     `json:"success"`
+	Data    []APIKey `json:"data,omitempty"`
+	Count   int      `json:"count"`
+	Error   string   `json:"error,omitempty"`
+}
@@ -0,0 +1,30 @@
+package models
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	// ID identifies the credential, such as the API key ID or the token subject
+	ID    string   `json:"id"`
+	Name  string   `json:"name"`
+	Roles []string `json:"roles"`
+	// TenantID scopes every business card the caller can see or change
+	TenantID string `json:"tenant_id"`
+}
+
+// Roles decide which routes a principal may call. Admin includes every other role, scanner and
+// reviewer include viewer.
+const (
+	// RoleViewer reads, exports and streams business cards
+	RoleViewer = "viewer"
+	// RoleScanner uploads, imports, retries and deletes business cards
+	RoleScanner = "scanner"
+	// RoleReviewer corrects, merges and approves business cards
+	RoleReviewer = "reviewer"
+	// RoleAdmin manages erasure, webhooks and API keys
+	RoleAdmin = "admin"
+)
+
+// ErrorResponse is returned when a request is rejected before reaching a handler
+type ErrorResponse struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
@@ -6,17 +6,48 @@ import (
 
 // BusinessCard represents the complete business card data structure
 type BusinessCard struct {
-	ID            string       `json:"id" dynamodbav:"id"`
-	PersonalData  PersonalData `json:"personal_data" dynamodbav:"personal_data"`
-	CompanyData   CompanyData  `json:"company_data" dynamodbav:"company_data"`
-	Images        []ImageData  `json:"images" dynamodbav:"images"`
-	ExtractedText string       `json:"extracted_text" dynamodbav:"extracted_text"`
-	ProcessedAt   time.Time    `json:"processed_at" dynamodbav:"processed_at"`
-	CreatedAt     time.Time    `json:"created_at" dynamodbav:"created_at"`
-	Status        string       `json:"status" dynamodbav:"status"`
-	Error         string       `json:"error,omitempty" dynamodbav:"error,omitempty"`
-	RetryCount    int          `json:"retry_count" dynamodbav:"retry_count"`
-	LastRetryAt   *time.Time   `json:"last_retry_at,omitempty" dynamodbav:"last_retry_at,omitempty"`
+	ID                 string              `json:"id" dynamodbav:"id"`
+	TenantID           string              `json:"tenant_id,omitempty" dynamodbav:"tenant_id,omitempty"`
+	PersonalData       PersonalData        `json:"personal_data" dynamodbav:"personal_data"`
+	CompanyData        CompanyData         `json:"company_data" dynamodbav:"company_data"`
+	Images             []ImageData         `json:"images" dynamodbav:"images"`
+	ExtractedText      string              `json:"extracted_text" dynamodbav:"extracted_text"`
+	ExtractionProvider string              `json:"extraction_provider,omitempty" dynamodbav:"extraction_provider,omitempty"`
+	Source             string              `json:"source,omitempty" dynamodbav:"source,omitempty"`
+	ProcessedAt        time.Time           `json:"processed_at" dynamodbav:"processed_at"`
+	CreatedAt          time.Time           `json:"created_at" dynamodbav:"created_at"`
+	Status             string              `json:"status" dynamodbav:"status"`
+	Error              string              `json:"error,omitempty" dynamodbav:"error,omitempty"`
+	RetryCount         int                 `json:"retry_count" dynamodbav:"retry_count"`
+	LastRetryAt        *time.Time          `json:"last_retry_at,omitempty" dynamodbav:"last_retry_at,omitempty"`
+	VerifiedFields     []string            `json:"verified_fields,omitempty" dynamodbav:"verified_fields,omitempty"`
+	ChangeLog          []FieldChange       `json:"change_log,omitempty" dynamodbav:"change_log,omitempty"`
+	MergedFrom         []string            `json:"merged_from,omitempty" dynamodbav:"merged_from,omitempty"`
+	PhoneNumbers       []PhoneNumber       `json:"phone_numbers,omitempty" dynamodbav:"phone_numbers,omitempty"`
+	ValidationWarnings []ValidationWarning `json:"validation_warnings,omitempty" dynamodbav:"validation_warnings,omitempty"`
+	// FieldConfidence holds the extractor's 0-1 confidence per field path, such as
+	// personal_data.email. Fields without a score weren't rated or were set by a person.
+	FieldConfidence map[string]float64 `json:"field_confidence,omitempty" dynamodbav:"field_confidence,omitempty"`
+	Review          *Review            `json:"review,omitempty" dynamodbav:"review,omitempty"`
+}
+
+// ValidationWarning flags a field value that a post-processor couldn't validate or canonicalize.
+// The value itself is kept as extracted.
+type ValidationWarning struct {
+	Field     string `json:"field" dynamodbav:"field"`
+	Processor string `json:"processor" dynamodbav:"processor"`
+	Message   string `json:"message" dynamodbav:"message"`
+}
+
+// PhoneNumber is the normalized form of one phone field. The field itself keeps the original text;
+// numbers that can't be parsed are flagged with Valid false and the reason.
+type PhoneNumber struct {
+	Field    string `json:"field" dynamodbav:"field"`
+	Original string `json:"original" dynamodbav:"original"`
+	E164     string `json:"e164,omitempty" dynamodbav:"e164,omitempty"`
+	Region   string `json:"region,omitempty" dynamodbav:"region,omitempty"`
+	Valid    bool   `json:"valid" dynamodbav:"valid"`
+	Error    string `json:"error,omitempty" dynamodbav:"error,omitempty"`
 }
 
 // PersonalData contains personal information extracted from business card
@@ -64,7 +95,9 @@ type ImageData struct {
 	FileName    string    `json:"file_name" dynamodbav:"file_name"`
 	ContentType string    `json:"content_type" dynamodbav:"content_type"`
 	Size        int64     `json:"size" dynamodbav:"size"`
-	Data        []byte    `json:"data" dynamodbav:"data"`
+	ObjectKey   string    `json:"object_key,omitempty" dynamodbav:"object_key,omitempty"`
+	Checksum    string    `json:"checksum,omitempty" dynamodbav:"checksum,omitempty"`
+	Data        []byte    `json:"data,omitempty" dynamodbav:"data,omitempty"`
 	Base64Data  string    `json:"base64_data" dynamodbav:"-"`
 	UploadedAt  time.Time `json:"uploaded_at" dynamodbav:"uploaded_at"`
 }
@@ -106,12 +139,20 @@ type BusinessCardResponse struct {
 
 // BusinessCardListResponse represents the list API response
 type BusinessCardListResponse struct {
-	Success bool           `json:"success"`
-	Data    []BusinessCard `json:"data,omitempty"`
-	Count   int            `json:"count"`
-	Error   string         `json:"error,omitempty"`
+	Success    bool           `json:"success"`
+	Data       []BusinessCard `json:"data,omitempty"`
+	Count      int            `json:"count"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+	Error      string         `json:"error,omitempty"`
 }
 
+// BusinessCardSource tells where a business card came from. Records without a source are scans.
+const (
+	SourceScan        = "scan"
+	SourceVCardImport = "vcard_import"
+	SourceCSVImport   = "csv_import"
+)
+
 // BusinessCardStatus represents the possible states of a business card
 const (
 	StatusPending    = "PENDING"
@@ -119,4 +160,9 @@ const (
 	StatusCompleted  = "COMPLETED"
 	StatusFailed     = "FAILED"
 	StatusRetrying   = "RETRYING"
+	StatusDeadLetter = "DEAD_LETTER"
+	// StatusNeedsReview marks an extracted card with fields below the confidence threshold
+	StatusNeedsReview = "NEEDS_REVIEW"
+	// StatusInReview marks a NEEDS_REVIEW card claimed by a reviewer
+	StatusInReview = "IN_REVIEW"
 )
@@ -0,0 +1,31 @@
+package models
+
+// DuplicateCandidate is a business card that probably describes the same contact as another one
+type DuplicateCandidate struct {
+	BusinessCard BusinessCard `json:"business_card"`
+	Score        float64      `json:"score"`
+	Reasons      []string     `json:"reasons"`
+}
+
+// DuplicateListResponse represents the duplicate candidates API response
+type DuplicateListResponse struct {
+	Success bool                 `json:"success"`
+	Data    []DuplicateCandidate `json:"data,omitempty"`
+	Count   int                  `json:"count"`
+	Error   string               `json:"error,omitempty"`
+}
+
+// DuplicateReason tells which data of two business cards matched
+const (
+	DuplicateReasonEmail          = "email"
+	DuplicateReasonPhone          = "phone"
+	DuplicateReasonNameAndCompany = "name_and_company"
+)
+
+// BusinessCardMergeRequest merges duplicates into a business card. Fields maps a field path, such
+// as personal_data.email, to the ID of the business card whose value is kept; fields that aren't
+// listed keep the target's value, or the first non-empty value of the duplicates.
+type BusinessCardMergeRequest struct {
+	DuplicateIDs []string          `json:"duplicate_ids" binding:"required"`
+	Fields       map[string]string `json:"fields,omitempty"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+)
+
+// ErasureRequest represents a request to erase every business card of a contact
+type ErasureRequest struct {
+	Email string `json:"email"`
+}
+
+// ErasureReceipt documents the outcome of an erasure request. Receipts are stored for audits
+// without the email address itself; its SHA-256 identifies the request instead.
+type ErasureReceipt struct {
+	ID       string `json:"id" dynamodbav:"id"`
+	TenantID string `json:"tenant_id,omitempty" dynamodbav:"tenant_id,omitempty"`
+	// Email is only returned to the requester and never stored
+	Email string `json:"email,omitempty" dynamodbav:"-"`
+	// EmailSHA256 is the hex SHA-256 of the trimmed, lowercased email address
+	EmailSHA256               string    `json:"email_sha256" dynamodbav:"email_sha256"`
+	RequestedBy               string    `json:"requested_by" dynamodbav:"requested_by"`
+	RequestedAt               time.Time `json:"requested_at" dynamodbav:"requested_at"`
+	CompletedAt               time.Time `json:"completed_at" dynamodbav:"completed_at"`
+	ErasedBusinessCardIDs     []string  `json:"erased_business_card_ids" dynamodbav:"erased_business_card_ids"`
+	SkippedBusinessCardIDs    []string  `json:"skipped_business_card_ids,omitempty" dynamodbav:"skipped_business_card_ids,omitempty"`
+	ImagesDeleted             int       `json:"images_deleted" dynamodbav:"images_deleted"`
+	WebhookDeliveriesRedacted int       `json:"webhook_deliveries_redacted" dynamodbav:"webhook_deliveries_redacted"`
+	Complete                  bool      `json:"complete" dynamodbav:"complete"`
+}
+
+// ErasureReceiptResponse represents the erasure API response
+type ErasureReceiptResponse struct {
+	Success bool           `json:"success"`
+	Data    ErasureReceipt `json:"data,omitempty"`
+	Error   string         `json:"error,omitempty"`
+}
+
+// ErasureReceiptListResponse represents the erasure receipt list API response
+type ErasureReceiptListResponse struct {
+	Success bool             `json:"success"`
+	Data    []ErasureReceipt `json:"data,omitempty"`
+	Count   int              `json:"count"`
+	Error   string           `json:"error,omitempty"`
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// BusinessCardEvent describes a status change of a business card
+type BusinessCardEvent struct {
+	BusinessCardID string       `json:"business_card_id"`
+	Status         string       `json:"status"`
+	PreviousStatus string       `json:"previous_status,omitempty"`
+	Timestamp      time.Time    `json:"timestamp"`
+	BusinessCard   BusinessCard `json:"business_card"`
+}
@@ -0,0 +1,36 @@
+package models
+
+// ImportReport summarizes an import and the outcome of every row
+type ImportReport struct {
+	Format         string            `json:"format"`
+	Total          int               `json:"total"`
+	Imported       int               `json:"imported"`
+	Duplicates     int               `json:"duplicates"`
+	Failed         int               `json:"failed"`
+	IgnoredColumns []string          `json:"ignored_columns,omitempty"`
+	Rows           []ImportRowResult `json:"rows"`
+}
+
+// ImportRowResult is the outcome of importing one CSV row or vCard. Row numbers start at 1;
+// for CSV files they count data rows after the header.
+type ImportRowResult struct {
+	Row            int    `json:"row"`
+	Status         string `json:"status"`
+	BusinessCardID string `json:"business_card_id,omitempty"`
+	DuplicateOf    string `json:"duplicate_of,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+// ImportReportResponse represents the import API response
+type ImportReportResponse struct {
+	Success bool         `json:"success"`
+	Data    ImportReport `json:"data,omitempty"`
+	Error   string       `json:"error,omitempty"`
+}
+
+// ImportRowStatus represents the possible outcomes of an imported row
+const (
+	ImportRowImported  = "imported"
+	ImportRowDuplicate = "duplicate"
+	ImportRowFailed    = "failed"
+)
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// BusinessCardQuery filters, sorts and paginates a business card listing. Email matches the
+// normalized personal email; only duplicate search sets it, as a listing parameter it would put
+// email addresses in the request logs.
+type BusinessCardQuery struct {
+	Status        string
+	CompanyName   string
+	Email         string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	SortBy        string
+	SortOrder     string
+	Limit         int
+	Cursor        string
+}
+
+// BusinessCardPage is one page of a business card listing. NextCursor is empty on the last page.
+type BusinessCardPage struct {
+	BusinessCards []BusinessCard
+	NextCursor    string
+}
+
+// Sort fields and orders accepted by BusinessCardQuery
+const (
+	SortByCreatedAt   = "created_at"
+	SortByProcessedAt = "processed_at"
+
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+)
+
+// Review records the human review of a business card held in the review queue
+type Review struct {
+	State      string     `json:"state" dynamodbav:"state"`
+	Reviewer   string     `json:"reviewer" dynamodbav:"reviewer"`
+	ClaimedAt  *time.Time `json:"claimed_at,omitempty" dynamodbav:"claimed_at,omitempty"`
+	ApprovedAt *time.Time `json:"approved_at,omitempty" dynamodbav:"approved_at,omitempty"`
+	// Corrected tells whether the reviewer changed fields when approving
+	Corrected bool `json:"corrected" dynamodbav:"corrected"`
+}
+
+// ReviewState represents the progress of a review
+const (
+	ReviewStateClaimed  = "claimed"
+	ReviewStateApproved = "approved"
+)
+
+// ReviewApprovalRequest optionally corrects fields while approving a business card
+type ReviewApprovalRequest struct {
+	Corrections *BusinessCardUpdateRequest `json:"corrections,omitempty"`
+}
+
+// ReviewQueueResponse represents the review queue API response
+type ReviewQueueResponse struct {
+	Success bool           `json:"success"`
+	Data    []BusinessCard `json:"data,omitempty"`
+	Count   int            `json:"count"`
+	Error   string         `json:"error,omitempty"`
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+)
+
+// FieldChange records one manual correction of an extracted field
+type FieldChange struct {
+	Field     string    `json:"field" dynamodbav:"field"`
+	OldValue  string    `json:"old_value" dynamodbav:"old_value"`
+	NewValue  string    `json:"new_value" dynamodbav:"new_value"`
+	ChangedBy string    `json:"changed_by" dynamodbav:"changed_by"`
+	ChangedAt time.Time `json:"changed_at" dynamodbav:"changed_at"`
+}
+
+// BusinessCardUpdateRequest represents a partial correction of the extracted data. Only the
+// fields present in the payload are changed and marked as verified.
+type BusinessCardUpdateRequest struct {
+	PersonalData PersonalDataUpdate `json:"personal_data"`
+	CompanyData  CompanyDataUpdate  `json:"company_data"`
+}
+
+// PersonalDataUpdate contains the personal fields of a BusinessCardUpdateRequest
+type PersonalDataUpdate struct {
+	FullName   *string `json:"full_name,omitempty"`
+	FirstName  *string `json:"first_name,omitempty"`
+	LastName   *string `json:"last_name,omitempty"`
+	JobTitle   *string `json:"job_title,omitempty"`
+	Department *string `json:"department,omitempty"`
+	Email      *string `json:"email,omitempty"`
+	Phone      *string `json:"phone,omitempty"`
+	Mobile     *string `json:"mobile,omitempty"`
+	LinkedIn   *string `json:"linkedin,omitempty"`
+	Website    *string `json:"website,omitempty"`
+}
+
+// CompanyDataUpdate contains the company fields of a BusinessCardUpdateRequest
+type CompanyDataUpdate struct {
+	Name        *string           `json:"name,omitempty"`
+	Industry    *string           `json:"industry,omitempty"`
+	Website     *string           `json:"website,omitempty"`
+	Email       *string           `json:"email,omitempty"`
+	Phone       *string           `json:"phone,omitempty"`
+	Address     AddressUpdate     `json:"address"`
+	SocialMedia SocialMediaUpdate `json:"social_media"`
+}
+
+// AddressUpdate contains the address fields of a BusinessCardUpdateRequest
+type AddressUpdate struct {
+	Street     *string `json:"street,omitempty"`
+	City       *string `json:"city,omitempty"`
+	State      *string `json:"state,omitempty"`
+	PostalCode *string `json:"postal_code,omitempty"`
+	Country    *string `json:"country,omitempty"`
+	Full       *string `json:"full,omitempty"`
+}
+
+// SocialMediaUpdate contains the social media fields of a BusinessCardUpdateRequest
+type SocialMediaUpdate struct {
+	LinkedIn  *string `json:"linkedin,omitempty"`
+	Twitter   *string `json:"twitter,omitempty"`
+	Facebook  *string `json:"facebook,omitempty"`
+	Instagram *string `json:"instagram,omitempty"`
+}
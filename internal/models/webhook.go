@@ -0,0 +1,99 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookSubscription is a registered endpoint that receives business card events
+type WebhookSubscription struct {
+	ID          string    `json:"id" dynamodbav:"id"`
+	TenantID    string    `json:"tenant_id,omitempty" dynamodbav:"tenant_id,omitempty"`
+	URL         string    `json:"url" dynamodbav:"url"`
+	Secret      string    `json:"secret,omitempty" dynamodbav:"secret"`
+	Events      []string  `json:"events" dynamodbav:"events"`
+	Description string    `json:"description,omitempty" dynamodbav:"description,omitempty"`
+	Active      bool      `json:"active" dynamodbav:"active"`
+	CreatedAt   time.Time `json:"created_at" dynamodbav:"created_at"`
+}
+
+// WebhookDelivery tracks sending one event to one subscription, including its retries
+type WebhookDelivery struct {
+	ID             string          `json:"id" dynamodbav:"id"`
+	SubscriptionID string          `json:"subscription_id" dynamodbav:"subscription_id"`
+	Event          string          `json:"event" dynamodbav:"event"`
+	BusinessCardID string          `json:"business_card_id" dynamodbav:"business_card_id"`
+	Payload        json.RawMessage `json:"payload" dynamodbav:"payload" swaggertype:"object"`
+	Status         string          `json:"status" dynamodbav:"status"`
+	Attempts       int             `json:"attempts" dynamodbav:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at" dynamodbav:"next_attempt_at"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at,omitempty" dynamodbav:"last_attempt_at,omitempty"`
+	LastStatusCode int             `json:"last_status_code,omitempty" dynamodbav:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty" dynamodbav:"last_error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at" dynamodbav:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty" dynamodbav:"delivered_at,omitempty"`
+}
+
+// WebhookEvent is the JSON body posted to subscribers
+type WebhookEvent struct {
+	ID           string       `json:"id"`
+	Event        string       `json:"event"`
+	CreatedAt    time.Time    `json:"created_at"`
+	BusinessCard BusinessCard `json:"business_card"`
+}
+
+// RedactedWebhookEvent replaces the payload of a delivery once its business card was erased
+type RedactedWebhookEvent struct {
+	ID             string    `json:"id"`
+	Event          string    `json:"event"`
+	CreatedAt      time.Time `json:"created_at"`
+	BusinessCardID string    `json:"business_card_id"`
+	Redacted       bool      `json:"redacted"`
+}
+
+// WebhookSubscriptionRequest represents the payload for registering a webhook
+type WebhookSubscriptionRequest struct {
+	URL         string   `json:"url"`
+	Events      []string `json:"events"`
+	Secret      string   `json:"secret,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+// WebhookSubscriptionResponse represents the webhook API response
+type WebhookSubscriptionResponse struct {
+	Success bool                `json:"success"`
+	Data    WebhookSubscription `json:"data,omitempty"`
+	Error   string              `json:"error,omitempty"`
+}
+
+// WebhookSubscriptionListResponse represents the webhook list API response
+type WebhookSubscriptionListResponse struct {
+	Success bool                  `json:"success"`
+	Data    []WebhookSubscription `json:"data,omitempty"`
+	Count   int                   `json:"count"`
+	Error   string                `json:"error,omitempty"`
+}
+
+// WebhookDeliveryListResponse represents the delivery log API response
+type WebhookDeliveryListResponse struct {
+	Success bool              `json:"success"`
+	Data    []WebhookDelivery `json:"data,omitempty"`
+	Count   int               `json:"count"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// Webhook event types
+const (
+	WebhookEventCompleted    = "business_card.completed"
+	WebhookEventFailed       = "business_card.failed"
+	WebhookEventRetried      = "business_card.retried"
+	WebhookEventDeadLettered = "business_card.dead_lettered"
+	WebhookEventNeedsReview  = "business_card.needs_review"
+)
+
+// WebhookDeliveryStatus represents the possible states of a webhook delivery
+const (
+	DeliveryStatusPending   = "PENDING"
+	DeliveryStatusDelivered = "DELIVERED"
+	DeliveryStatusFailed    = "FAILED"
+)
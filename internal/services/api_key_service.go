@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"business-card-reader/internal/logger"
+	"business-card-reader/internal/models"
+)
+
+// APIKeyPrefix starts every API key, which has the form bcr_<id>_<secret>
+const APIKeyPrefix = "bcr"
+
+// lastUsedInterval bounds how often authenticating with a key updates its last use
+const lastUsedInterval = time.Minute
+
+// ErrInvalidAPIKeyRequest is returned when an API key request fails validation
+var ErrInvalidAPIKeyRequest = errors.New("invalid API key request")
+
+// ErrInvalidAPIKey is returned when a presented API key is malformed, unknown or revoked
+var ErrInvalidAPIKey = errors.New("invalid API key")
+
+// apiKeyScopes lists the scopes from least to most privileged
+var apiKeyScopes = []string{models.ScopeRead, models.ScopeWrite, models.ScopeAdmin}
+
+// APIKeyService creates, revokes and checks API keys
+type APIKeyService struct {
+	repository APIKeyRepository
+}
+
+func NewAPIKeyService(repository APIKeyRepository) *APIKeyService {
+	return &APIKeyService{
+		repository: repository,
+	}
+}
+
+// CreateAPIKey generates and stores a new key for the requested tenant, by default the context's.
+// The returned APIKey carries the key itself, which can't be recovered later.
+func (a *APIKeyService) CreateAPIKey(ctx context.Context, request models.APIKeyRequest, actor string) (*models.APIKey, error) {
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidAPIKeyRequest)
+	}
+	if len(name) > maxFieldLength {
+		return nil, fmt.Errorf("%w: name must be at most %d characters", ErrInvalidAPIKeyRequest, maxFieldLength)
+	}
+	if len(request.Scopes) == 0 {
+		return nil, fmt.Errorf("%w: at least one scope is required", ErrInvalidAPIKeyRequest)
+	}
+	for _, scope := range request.Scopes {
+		if !slices.Contains(apiKeyScopes, scope) {
+			return nil, fmt.Errorf("%w: unsupported scope %q, available scopes: %s", ErrInvalidAPIKeyRequest, scope, strings.Join(apiKeyScopes, ", "))
+		}
+	}
+	tenantID := request.TenantID
+	if tenantID == "" {
+		tenantID = TenantFromContext(ctx)
+	}
+	if err := ValidateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAPIKeyRequest, err)
+	}
+
+	id, err := randomHex(8)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return nil, err
+	}
+	key := APIKeyPrefix + "_" + id + "_" + secret
+
+	apiKey := &models.APIKey{
+		ID:        id,
+		Name:      name,
+		TenantID:  tenantID,
+		KeyHash:   hashAPIKey(key),
+		Scopes:    request.Scopes,
+		CreatedBy: actor,
+		CreatedAt: time.Now(),
+	}
+
+	if err := a.repository.SaveAPIKey(ctx, apiKey); err != nil {
+		logger.LogError("CreateAPIKey", err, map[string]interface{}{
+			"api_key_id": id,
+		})
+		return nil, fmt.Errorf("failed to save API key: %w", err)
+	}
+
+	logger.LogInfo("CreateAPIKey", "API key created", map[string]interface{}{
+		"api_key_id": id,
+		"name":       name,
+		"tenant_id":  tenantID,
+		"scopes":     request.Scopes,
+		"actor":      actor,
+	})
+
+	apiKey.Key = key
+	return apiKey, nil
+}
+
+// GetAPIKeys returns the keys of the context's tenant
+func (a *APIKeyService) GetAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	apiKeys, err := a.repository.GetAllAPIKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantID := TenantFromContext(ctx)
+	var scoped []models.APIKey
+	for _, apiKey := range apiKeys {
+		if recordTenant(apiKey.TenantID) == tenantID {
+			scoped = append(scoped, apiKey)
+		}
+	}
+	return scoped, nil
+}
+
+// RevokeAPIKey disables a key of the context's tenant. The record is kept so its use stays traceable.
+func (a *APIKeyService) RevokeAPIKey(ctx context.Context, id string, actor string) (*models.APIKey, error) {
+	apiKey, err := a.repository.GetAPIKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if recordTenant(apiKey.TenantID) != TenantFromContext(ctx) {
+		return nil, ErrAPIKeyNotFound
+	}
+
+	if apiKey.RevokedAt == nil {
+		now := time.Now()
+		apiKey.RevokedAt = &now
+		if err := a.repository.SaveAPIKey(ctx, apiKey); err != nil {
+			logger.LogError("RevokeAPIKey", err, map[string]interface{}{
+				"api_key_id": id,
+			})
+			return nil, fmt.Errorf("failed to save API key: %w", err)
+		}
+	}
+
+	logger.LogInfo("RevokeAPIKey", "API key revoked", map[string]interface{}{
+		"api_key_id": id,
+		"actor":      actor,
+	})
+
+	return apiKey, nil
+}
+
+// Authenticate returns the active API key matching the presented key
+func (a *APIKeyService) Authenticate(ctx context.Context, key string) (*models.APIKey, error) {
+	prefix, rest, _ := strings.Cut(key, "_")
+	id, _, _ := strings.Cut(rest, "_")
+	if prefix != APIKeyPrefix || id == "" {
+		return nil, ErrInvalidAPIKey
+	}
+
+	apiKey, err := a.repository.GetAPIKey(ctx, id)
+	if errors.Is(err, ErrAPIKeyNotFound) {
+		return nil, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashAPIKey(key)), []byte(apiKey.KeyHash)) != 1 || apiKey.RevokedAt != nil {
+		return nil, ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= lastUsedInterval {
+		apiKey.LastUsedAt = &now
+		if err := a.repository.SaveAPIKey(ctx, apiKey); err != nil {
+			logger.LogWarn("Authenticate", "Could not record API key use", map[string]interface{}{
+				"api_key_id": id,
+				"error":      err.Error(),
+			})
+		}
+	}
+
+	return apiKey, nil
+}
+
+func hashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+func randomHex(size int) (string, error) {
+	value := make([]byte, size)
+	if _, err := rand.Read(value); err != nil {
+		return "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	return hex.EncodeToString(value), nil
+}
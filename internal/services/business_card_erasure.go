@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"business-card-reader/internal/logger"
+	"business-card-reader/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidErasureRequest is returned when an erasure request has no usable email address
+var ErrInvalidErasureRequest = errors.New("invalid erasure request")
+
+// DeliveryRedactor removes the personal data of erased business cards from stored webhook
+// deliveries. It returns the number of deliveries that were redacted.
+type DeliveryRedactor interface {
+	RedactDeliveries(ctx context.Context, businessCardIDs []string, email string) (int, error)
+}
+
+// SetDeliveryRedactor makes erasure requests redact the webhook deliveries of the erased cards
+func (b *BusinessCardService) SetDeliveryRedactor(redactor DeliveryRedactor) {
+	b.deliveryRedactor = redactor
+}
+
+// DeleteBusinessCard removes a business card together with the image bytes kept in the image
+// store. It returns the number of images that were deleted.
+func (b *BusinessCardService) DeleteBusinessCard(ctx context.Context, id string) (int, error) {
+	logger.LogInfo("DeleteBusinessCard", "Deleting business card", map[string]interface{}{
+		"business_card_id": id,
+	})
+
+	businessCard, err := b.repository.GetBusinessCard(ctx, id)
+	if err != nil {
+		logger.LogError("DeleteBusinessCard", err, map[string]interface{}{
+			"step":             "get_business_card",
+			"business_card_id": id,
+		})
+		return 0, fmt.Errorf("failed to get business card: %w", err)
+	}
+
+	return b.deleteBusinessCard(ctx, businessCard)
+}
+
+// EraseBusinessCardsByEmail deletes every business card whose personal or company email matches,
+// including their images, redacts the webhook deliveries that carried them and stores a receipt
+// of what was erased. Cards that are being processed can't be deleted safely and are reported as
+// skipped.
+func (b *BusinessCardService) EraseBusinessCardsByEmail(ctx context.Context, email string, actor string) (*models.ErasureReceipt, error) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return nil, fmt.Errorf("%w: email is required", ErrInvalidErasureRequest)
+	}
+
+	receipt := &models.ErasureReceipt{
+		ID:                    uuid.New().String(),
+		TenantID:              TenantFromContext(ctx),
+		Email:                 email,
+		EmailSHA256:           erasureEmailHash(email),
+		RequestedBy:           actor,
+		RequestedAt:           time.Now(),
+		ErasedBusinessCardIDs: []string{},
+	}
+
+	logger.LogInfo("EraseBusinessCardsByEmail", "Starting erasure", map[string]interface{}{
+		"erasure_id": receipt.ID,
+		"actor":      actor,
+	})
+
+	businessCards, err := b.repository.GetAllBusinessCards(ctx)
+	if err != nil {
+		logger.LogError("EraseBusinessCardsByEmail", err, map[string]interface{}{
+			"step":       "get_all_business_cards",
+			"erasure_id": receipt.ID,
+		})
+		return nil, fmt.Errorf("failed to find business cards: %w", err)
+	}
+
+	var matches []*models.BusinessCard
+	var matchedIDs []string
+	for i := range businessCards {
+		businessCard := &businessCards[i]
+		if !strings.EqualFold(strings.TrimSpace(businessCard.PersonalData.Email), email) &&
+			!strings.EqualFold(strings.TrimSpace(businessCard.CompanyData.Email), email) {
+			continue
+		}
+		matches = append(matches, businessCard)
+		matchedIDs = append(matchedIDs, businessCard.ID)
+	}
+
+	// Deliveries are redacted before the cards go, so a failure here leaves everything in place
+	// for the repeated request
+	if b.deliveryRedactor != nil {
+		redacted, err := b.deliveryRedactor.RedactDeliveries(ctx, matchedIDs, email)
+		if err != nil {
+			logger.LogError("EraseBusinessCardsByEmail", err, map[string]interface{}{
+				"step":       "redact_webhook_deliveries",
+				"erasure_id": receipt.ID,
+			})
+			return nil, fmt.Errorf("failed to redact webhook deliveries: %w", err)
+		}
+		receipt.WebhookDeliveriesRedacted = redacted
+	}
+
+	for _, businessCard := range matches {
+		imagesDeleted, err := b.deleteBusinessCard(ctx, businessCard)
+		switch {
+		case errors.Is(err, ErrBusinessCardBusy):
+			receipt.SkippedBusinessCardIDs = append(receipt.SkippedBusinessCardIDs, businessCard.ID)
+			continue
+		case errors.Is(err, ErrBusinessCardNotFound):
+			continue // Deleted concurrently
+		case err != nil:
+			logger.LogError("EraseBusinessCardsByEmail", err, map[string]interface{}{
+				"step":             "delete_business_card",
+				"erasure_id":       receipt.ID,
+				"business_card_id": businessCard.ID,
+			})
+			return nil, fmt.Errorf("failed to erase business card %s: %w", businessCard.ID, err)
+		}
+
+		receipt.ErasedBusinessCardIDs = append(receipt.ErasedBusinessCardIDs, businessCard.ID)
+		receipt.ImagesDeleted += imagesDeleted
+	}
+
+	receipt.CompletedAt = time.Now()
+	receipt.Complete = len(receipt.SkippedBusinessCardIDs) == 0
+
+	if err := b.repository.SaveErasureReceipt(ctx, receipt); err != nil {
+		logger.LogError("EraseBusinessCardsByEmail", err, map[string]interface{}{
+			"step":       "save_receipt",
+			"erasure_id": receipt.ID,
+		})
+		return nil, fmt.Errorf("failed to save erasure receipt: %w", err)
+	}
+
+	// The email itself is personal data and stays out of the logs
+	logger.LogInfo("EraseBusinessCardsByEmail", "Erasure completed", map[string]interface{}{
+		"erasure_id":          receipt.ID,
+		"actor":               actor,
+		"erased_cards":        len(receipt.ErasedBusinessCardIDs),
+		"skipped_cards":       len(receipt.SkippedBusinessCardIDs),
+		"images_deleted":      receipt.ImagesDeleted,
+		"deliveries_redacted": receipt.WebhookDeliveriesRedacted,
+		"business_card_ids":   receipt.ErasedBusinessCardIDs,
+	})
+
+	return receipt, nil
+}
+
+// GetErasureReceipt returns a stored receipt of the context's tenant. Other tenants' receipts are
+// reported as not found.
+func (b *BusinessCardService) GetErasureReceipt(ctx context.Context, id string) (*models.ErasureReceipt, error) {
+	receipt, err := b.repository.GetErasureReceipt(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if recordTenant(receipt.TenantID) != TenantFromContext(ctx) {
+		return nil, ErrErasureReceiptNotFound
+	}
+	return receipt, nil
+}
+
+// GetAllErasureReceipts returns the stored receipts of the context's tenant, oldest first
+func (b *BusinessCardService) GetAllErasureReceipts(ctx context.Context) ([]models.ErasureReceipt, error) {
+	receipts, err := b.repository.GetAllErasureReceipts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantID := TenantFromContext(ctx)
+	scoped := []models.ErasureReceipt{}
+	for _, receipt := range receipts {
+		if recordTenant(receipt.TenantID) == tenantID {
+			scoped = append(scoped, receipt)
+		}
+	}
+	return scoped, nil
+}
+
+// erasureEmailHash identifies an erased email address in stored receipts without keeping it
+func erasureEmailHash(email string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email))))
+	return hex.EncodeToString(sum[:])
+}
+
+// deleteBusinessCard purges a business card that no extraction is working on
+func (b *BusinessCardService) deleteBusinessCard(ctx context.Context, businessCard *models.BusinessCard) (int, error) {
+	// An extraction in flight would write the record back when it finishes
+	if err := checkNotProcessing(businessCard); err != nil {
+		return 0, err
+	}
+	return b.purgeBusinessCard(ctx, businessCard)
+}
+
+// purgeBusinessCard deletes the stored images before the record, so a failure never leaves
+// image bytes behind without a record pointing at them
+func (b *BusinessCardService) purgeBusinessCard(ctx context.Context, businessCard *models.BusinessCard) (int, error) {
+	for _, image := range businessCard.Images {
+		if image.ObjectKey == "" {
+			continue
+		}
+		if b.imageStore == nil {
+			return 0, fmt.Errorf("image %s is in an image store but none is configured", image.ObjectKey)
+		}
+
+		err := b.imageStore.DeleteImage(ctx, image.ObjectKey)
+		if err != nil && !errors.Is(err, ErrImageNotFound) {
+			logger.LogError("DeleteBusinessCard", err, map[string]interface{}{
+				"step":             "delete_image",
+				"business_card_id": businessCard.ID,
+				"object_key":       image.ObjectKey,
+			})
+			return 0, fmt.Errorf("failed to delete image %s: %w", image.ObjectKey, err)
+		}
+	}
+
+	if err := b.repository.DeleteBusinessCard(ctx, businessCard.ID); err != nil {
+		logger.LogError("DeleteBusinessCard", err, map[string]interface{}{
+			"step":             "delete_record",
+			"business_card_id": businessCard.ID,
+		})
+		return 0, fmt.Errorf("failed to delete business card: %w", err)
+	}
+
+	// Inline images are gone with the record
+	imagesDeleted := len(businessCard.Images)
+
+	logger.LogInfo("DeleteBusinessCard", "Business card deleted successfully", map[string]interface{}{
+		"business_card_id": businessCard.ID,
+		"images_deleted":   imagesDeleted,
+	})
+
+	return imagesDeleted, nil
+}
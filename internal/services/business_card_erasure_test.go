@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"business-card-reader/internal/models"
+)
+
+func TestEraseBusinessCardsByEmail(t *testing.T) {
+	ctx := WithTenant(context.Background(), "acme")
+	repository := NewMemoryRepository()
+	service := NewBusinessCardService(repository, &stubExtractor{})
+	webhookService := NewWebhookService(repository, webhookTestConfig())
+	service.SetDeliveryRedactor(webhookService)
+
+	subscription, err := webhookService.CreateSubscription(ctx, models.WebhookSubscriptionRequest{
+		URL:    "https://hooks.example.com/cards",
+		Events: []string{models.WebhookEventCompleted},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	jane := models.BusinessCard{ID: "jane", TenantID: "acme", Status: models.StatusCompleted, CreatedAt: now}
+	jane.PersonalData.Email = "Jane@Example.com"
+	john := models.BusinessCard{ID: "john", TenantID: "acme", Status: models.StatusCompleted, CreatedAt: now}
+	john.PersonalData.Email = "john@example.com"
+	// Deleted before the erasure, only its delivery still holds the email
+	gone := models.BusinessCard{ID: "gone", TenantID: "acme", Status: models.StatusCompleted, CreatedAt: now}
+	gone.CompanyData.Email = "jane@example.com"
+
+	for _, businessCard := range []models.BusinessCard{jane, john} {
+		if err := repository.SaveBusinessCard(ctx, &businessCard); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, businessCard := range []models.BusinessCard{jane, john, gone} {
+		webhookService.OnBusinessCardEvent(ctx, models.BusinessCardEvent{
+			BusinessCardID: businessCard.ID,
+			Status:         models.StatusCompleted,
+			Timestamp:      now,
+			BusinessCard:   businessCard,
+		})
+	}
+
+	receipt, err := service.EraseBusinessCardsByEmail(ctx, " jane@example.com ", "dpo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(receipt.ErasedBusinessCardIDs) != 1 || receipt.ErasedBusinessCardIDs[0] != "jane" {
+		t.Errorf("erased %v, want [jane]", receipt.ErasedBusinessCardIDs)
+	}
+	if receipt.WebhookDeliveriesRedacted != 2 {
+		t.Errorf("redacted %d deliveries, want 2", receipt.WebhookDeliveriesRedacted)
+	}
+	if !receipt.Complete {
+		t.Error("receipt is incomplete")
+	}
+
+	deliveries, err := webhookService.GetDeliveries(ctx, subscription.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != 3 {
+		t.Fatalf("got %d deliveries, want 3", len(deliveries))
+	}
+	for _, delivery := range deliveries {
+		erased := delivery.BusinessCardID != "john"
+		if got := strings.Contains(string(delivery.Payload), "example.com"); got == erased {
+			t.Errorf("delivery of %s still carries an email: %v, payload %s", delivery.BusinessCardID, got, delivery.Payload)
+		}
+		if !erased {
+			continue
+		}
+
+		var payload models.RedactedWebhookEvent
+		if err := json.Unmarshal(delivery.Payload, &payload); err != nil {
+			t.Fatal(err)
+		}
+		if !payload.Redacted || payload.BusinessCardID != delivery.BusinessCardID || payload.ID != delivery.ID {
+			t.Errorf("redacted payload = %+v", payload)
+		}
+		if delivery.Status != models.DeliveryStatusFailed {
+			t.Errorf("pending delivery of %s has status %s, want it cancelled", delivery.BusinessCardID, delivery.Status)
+		}
+	}
+
+	t.Run("receipt is stored without the email", func(t *testing.T) {
+		stored, err := service.GetErasureReceipt(ctx, receipt.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stored.Email != "" {
+			t.Errorf("stored receipt keeps email %q", stored.Email)
+		}
+		if stored.EmailSHA256 != erasureEmailHash("JANE@example.com") || stored.WebhookDeliveriesRedacted != 2 {
+			t.Errorf("stored receipt = %+v", stored)
+		}
+
+		receipts, err := service.GetAllErasureReceipts(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(receipts) != 1 || receipts[0].ID != receipt.ID {
+			t.Errorf("receipts = %+v", receipts)
+		}
+	})
+
+	t.Run("receipts of other tenants are not found", func(t *testing.T) {
+		other := WithTenant(context.Background(), "globex")
+		if _, err := service.GetErasureReceipt(other, receipt.ID); !errors.Is(err, ErrErasureReceiptNotFound) {
+			t.Errorf("got %v, want ErrErasureReceiptNotFound", err)
+		}
+		receipts, err := service.GetAllErasureReceipts(other)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(receipts) != 0 {
+			t.Errorf("other tenant sees %d receipts", len(receipts))
+		}
+	})
+
+	t.Run("repeated erasure redacts nothing twice", func(t *testing.T) {
+		again, err := service.EraseBusinessCardsByEmail(ctx, "jane@example.com", "dpo")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if again.WebhookDeliveriesRedacted != 0 || len(again.ErasedBusinessCardIDs) != 0 {
+			t.Errorf("repeated erasure = %+v", again)
+		}
+	})
+}
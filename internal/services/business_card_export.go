@@ -0,0 +1,162 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"business-card-reader/internal/logger"
+	"business-card-reader/internal/models"
+)
+
+// ExportVCard renders one business card as a vCard
+func (b *BusinessCardService) ExportVCard(ctx context.Context, id string, options VCardOptions) ([]byte, error) {
+	options, err := normalizeVCardOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	businessCard, err := b.repository.GetBusinessCard(ctx, id)
+	if err != nil {
+		logger.LogError("ExportVCard", err, map[string]interface{}{
+			"step":             "get_business_card",
+			"business_card_id": id,
+		})
+		return nil, fmt.Errorf("failed to get business card: %w", err)
+	}
+
+	if options.IncludePhoto {
+		if businessCard.Images, err = b.loadImages(ctx, businessCard.Images[:min(len(businessCard.Images), 1)]); err != nil {
+			return nil, err
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := WriteVCard(&buffer, businessCard, options); err != nil {
+		return nil, fmt.Errorf("failed to render vCard: %w", err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// ExportVCards streams every business card matching the query filters to w as one vCard file, one
+// listing page at a time. The query's limit and cursor are ignored. Invalid options are rejected
+// before anything is written. It returns the number of exported cards.
+func (b *BusinessCardService) ExportVCards(ctx context.Context, w io.Writer, query models.BusinessCardQuery, options VCardOptions) (int, error) {
+	options, err := normalizeVCardOptions(options)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	err = b.forEachBusinessCard(ctx, query, func(businessCard *models.BusinessCard) error {
+		if options.IncludePhoto {
+			images, err := b.loadImages(ctx, businessCard.Images[:min(len(businessCard.Images), 1)])
+			if err != nil {
+				return err
+			}
+			businessCard.Images = images
+		}
+
+		count++
+		return WriteVCard(w, businessCard, options)
+	})
+	if err != nil {
+		logger.LogError("ExportVCards", err, map[string]interface{}{
+			"step":     "write_vcards",
+			"exported": count,
+		})
+		return count, fmt.Errorf("failed to export vCards: %w", err)
+	}
+
+	logger.LogInfo("ExportVCards", "Business cards exported", map[string]interface{}{
+		"count":   count,
+		"version": options.Version,
+	})
+
+	return count, nil
+}
+
+// ExportBusinessCards streams every business card matching the query filters to w as a CSV or
+// XLSX spreadsheet, one listing page at a time. The query's limit and cursor are ignored. Invalid
+// options are rejected before anything is written. It returns the number of exported cards.
+func (b *BusinessCardService) ExportBusinessCards(ctx context.Context, w io.Writer, query models.BusinessCardQuery, options ExportOptions) (int, error) {
+	options, err := normalizeExportOptions(options)
+	if err != nil {
+		return 0, err
+	}
+	columns, err := resolveExportColumns(options)
+	if err != nil {
+		return 0, err
+	}
+
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = column.Header
+	}
+
+	// The header row waits for the first page, so a failing query can still be reported as an error
+	writer := newRowWriter(w, options.Format)
+	count := 0
+	err = b.forEachBusinessCard(ctx, query, func(businessCard *models.BusinessCard) error {
+		if count == 0 {
+			if err := writer.WriteRow(headers); err != nil {
+				return err
+			}
+		}
+		count++
+		return writer.WriteRow(exportRow(businessCard, columns))
+	})
+	if err == nil && count == 0 {
+		err = writer.WriteRow(headers)
+	}
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		logger.LogError("ExportBusinessCards", err, map[string]interface{}{
+			"step":     "write_rows",
+			"format":   options.Format,
+			"exported": count,
+		})
+		return count, fmt.Errorf("failed to export business cards: %w", err)
+	}
+
+	logger.LogInfo("ExportBusinessCards", "Business cards exported", map[string]interface{}{
+		"count":  count,
+		"format": options.Format,
+		"preset": options.Preset,
+	})
+
+	return count, nil
+}
+
+// forEachBusinessCard walks all pages of a listing query, calling fn for every business card
+func (b *BusinessCardService) forEachBusinessCard(ctx context.Context, query models.BusinessCardQuery, fn func(businessCard *models.BusinessCard) error) error {
+	query.Limit = MaxPageSize
+	query.Cursor = ""
+
+	query, err := normalizeBusinessCardQuery(query)
+	if err != nil {
+		return err
+	}
+
+	for {
+		page, err := b.repository.ListBusinessCards(ctx, query)
+		if err != nil {
+			return err
+		}
+
+		for i := range page.BusinessCards {
+			if err := fn(&page.BusinessCards[i]); err != nil {
+				return err
+			}
+		}
+
+		if page.NextCursor == "" {
+			return nil
+		}
+		query.Cursor = page.NextCursor
+	}
+}
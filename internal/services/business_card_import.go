@@ -0,0 +1,290 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"business-card-reader/internal/logger"
+	"business-card-reader/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Supported import formats
+const (
+	ImportFormatVCard = "vcard"
+	ImportFormatCSV   = "csv"
+)
+
+// MaxImportRows bounds the number of contacts in one import file
+const MaxImportRows = 5000
+
+// ErrInvalidImport is returned for unknown formats and files that can't be read as a whole
+var ErrInvalidImport = errors.New("invalid import")
+
+// importEntry is one contact read from an import file, or the reason it couldn't be read
+type importEntry struct {
+	businessCard *models.BusinessCard
+	err          error
+}
+
+// importHeaders maps lower-cased CSV headers to field paths. Field paths themselves and the
+// headers of every export preset are recognized, so exported files can be imported again.
+var importHeaders = buildImportHeaders()
+
+func buildImportHeaders() map[string]string {
+	headers := make(map[string]string)
+	for _, field := range editableFields {
+		headers[field.path] = field.path
+	}
+	for _, preset := range slices.Sorted(maps.Keys(exportPresets)) {
+		for _, column := range exportPresets[preset] {
+			if column.Field == "" {
+				continue
+			}
+			if _, exists := headers[strings.ToLower(column.Header)]; !exists {
+				headers[strings.ToLower(column.Header)] = column.Field
+			}
+		}
+	}
+	return headers
+}
+
+// ImportBusinessCards creates business cards without images from a vCard or CSV file. Every
+// contact is normalized like an extraction result and checked against the stored business cards
+// and the earlier rows of the file; duplicates are reported and not imported. Rows that can't be
+// read or fail validation are reported too, without stopping the import.
+func (b *BusinessCardService) ImportBusinessCards(ctx context.Context, r io.Reader, format string) (*models.ImportReport, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+
+	report := &models.ImportReport{
+		Format: format,
+		Rows:   []models.ImportRowResult{},
+	}
+
+	var entries []importEntry
+	var source string
+	var err error
+	switch format {
+	case ImportFormatVCard:
+		source = models.SourceVCardImport
+		entries, err = readVCardImport(r)
+	case ImportFormatCSV:
+		source = models.SourceCSVImport
+		entries, report.IgnoredColumns, err = readCSVImport(r)
+	default:
+		return nil, fmt.Errorf("%w: format must be vcard or csv", ErrInvalidImport)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) > MaxImportRows {
+		return nil, fmt.Errorf("%w: at most %d contacts can be imported at once", ErrInvalidImport, MaxImportRows)
+	}
+
+	logger.LogInfo("ImportBusinessCards", "Starting import", map[string]interface{}{
+		"format": format,
+		"rows":   len(entries),
+	})
+
+	existing, err := b.repository.GetAllBusinessCards(ctx)
+	if err != nil {
+		logger.LogError("ImportBusinessCards", err, map[string]interface{}{
+			"step": "get_all_business_cards",
+		})
+		return nil, fmt.Errorf("failed to load business cards for duplicate detection: %w", err)
+	}
+	duplicates := newDuplicateIndex(existing)
+
+	for i, entry := range entries {
+		result := models.ImportRowResult{Row: i + 1}
+		businessCard, err := entry.businessCard, entry.err
+		if err == nil {
+			err = prepareImportedBusinessCard(businessCard, source)
+		}
+		if err == nil {
+			b.postProcess(businessCard)
+		}
+		duplicateOf := ""
+		if err == nil {
+			duplicateOf = duplicates.find(businessCard)
+		}
+
+		switch {
+		case err != nil:
+			result.Status = models.ImportRowFailed
+			result.Error = err.Error()
+			report.Failed++
+		case duplicateOf != "":
+			result.Status = models.ImportRowDuplicate
+			result.DuplicateOf = duplicateOf
+			report.Duplicates++
+		default:
+			if err := b.saveBusinessCard(ctx, businessCard); err != nil {
+				logger.LogError("ImportBusinessCards", err, map[string]interface{}{
+					"step":             "save_business_card",
+					"business_card_id": businessCard.ID,
+					"row":              result.Row,
+				})
+				result.Status = models.ImportRowFailed
+				result.Error = "failed to save business card"
+				report.Failed++
+				break
+			}
+
+			duplicates.add(businessCard)
+			b.notifyListeners(ctx, businessCard, "")
+
+			result.Status = models.ImportRowImported
+			result.BusinessCardID = businessCard.ID
+			report.Imported++
+		}
+
+		report.Rows = append(report.Rows, result)
+	}
+	report.Total = len(report.Rows)
+
+	logger.LogInfo("ImportBusinessCards", "Import completed", map[string]interface{}{
+		"format":     format,
+		"imported":   report.Imported,
+		"duplicates": report.Duplicates,
+		"failed":     report.Failed,
+	})
+
+	return report, nil
+}
+
+// prepareImportedBusinessCard normalizes and validates an imported contact and turns it into a
+// COMPLETED business card
+func prepareImportedBusinessCard(businessCard *models.BusinessCard, source string) error {
+	normalizeBusinessCardData(businessCard)
+
+	if businessCard.PersonalData.FullName == "" && businessCard.PersonalData.Email == "" && businessCard.CompanyData.Name == "" {
+		return fmt.Errorf("a name, email or company is required")
+	}
+
+	for _, field := range editableFields {
+		value := *field.value(businessCard)
+		if len(value) > maxFieldLength {
+			return fmt.Errorf("%s must be at most %d characters", field.path, maxFieldLength)
+		}
+		if value == "" || field.validate == nil {
+			continue
+		}
+		if err := field.validate(value); err != nil {
+			return fmt.Errorf("%s %v", field.path, err)
+		}
+	}
+
+	now := time.Now()
+	businessCard.ID = uuid.New().String()
+	businessCard.Images = []models.ImageData{}
+	businessCard.Source = source
+	businessCard.Status = models.StatusCompleted
+	businessCard.CreatedAt = now
+	businessCard.ProcessedAt = now
+
+	return nil
+}
+
+func readVCardImport(r io.Reader) ([]importEntry, error) {
+	vCards, err := parseVCards(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	if len(vCards) == 0 {
+		return nil, fmt.Errorf("%w: no vCards found", ErrInvalidImport)
+	}
+
+	entries := make([]importEntry, len(vCards))
+	for i, vCard := range vCards {
+		if vCard.err != nil {
+			entries[i].err = vCard.err
+			continue
+		}
+		entries[i].businessCard = businessCardFromVCard(vCard.properties)
+	}
+	return entries, nil
+}
+
+// readCSVImport reads a CSV file with a header row. Columns that don't map to an editable field
+// are returned as ignored.
+func readCSVImport(r io.Reader) ([]importEntry, []string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: failed to read CSV header: %v", ErrInvalidImport, err)
+	}
+
+	fields := make([]string, len(header))
+	var ignored []string
+	mapped := false
+	for i, column := range header {
+		if i == 0 {
+			column = strings.TrimPrefix(column, "\ufeff")
+		}
+		column = strings.TrimSpace(column)
+		if path, ok := importHeaders[strings.ToLower(column)]; ok {
+			fields[i] = path
+			mapped = true
+		} else if column != "" {
+			ignored = append(ignored, column)
+		}
+	}
+	if !mapped {
+		return nil, nil, fmt.Errorf("%w: no CSV column matches a business card field", ErrInvalidImport)
+	}
+
+	values := make(map[string]func(businessCard *models.BusinessCard) *string, len(editableFields))
+	for _, field := range editableFields {
+		values[field.path] = field.value
+	}
+
+	var entries []importEntry
+	for len(entries) <= MaxImportRows {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			entries = append(entries, importEntry{err: fmt.Errorf("malformed CSV: %v", parseErr.Err)})
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read CSV file: %w", err)
+		}
+
+		businessCard := &models.BusinessCard{}
+		for i, value := range record {
+			if i >= len(fields) || fields[i] == "" {
+				continue
+			}
+			// The first non-empty column wins when several map to the same field
+			if target := values[fields[i]](businessCard); *target == "" {
+				*target = unescapeCSVFormula(value)
+			}
+		}
+		entries = append(entries, importEntry{businessCard: businessCard})
+	}
+
+	return entries, ignored, nil
+}
+
+// unescapeCSVFormula removes the quote added by escapeCSVFormula
+func unescapeCSVFormula(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune("=@+-\t\r", rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
@@ -0,0 +1,254 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"business-card-reader/internal/models"
+)
+
+func TestVCardRoundTrip(t *testing.T) {
+	businessCard := &models.BusinessCard{}
+	personal := &businessCard.PersonalData
+	personal.FullName = "Anna Smith-Jones"
+	personal.FirstName = "Anna"
+	personal.LastName = "Smith-Jones"
+	personal.JobTitle = "Head of Sales, EMEA"
+	personal.Department = "Sales; Marketing"
+	personal.Email = "anna@acme.com"
+	personal.Phone = "+49301234567"
+	personal.Mobile = "+491717654321"
+	personal.LinkedIn = "linkedin.com/in/annasmith"
+	personal.Website = "annasmith.example"
+	company := &businessCard.CompanyData
+	company.Name = "Acme Corp"
+	company.Email = "info@acme.com"
+	company.Phone = "+49301234500"
+	company.Website = "www.acme.com"
+	company.Address = models.Address{
+		Street:     "Hauptstraße 5",
+		City:       "Berlin",
+		PostalCode: "10115",
+		Country:    "Germany",
+		Full:       "Hauptstraße 5\n10115 Berlin \"Mitte\"",
+	}
+	company.SocialMedia.LinkedIn = "linkedin.com/company/acme"
+	company.SocialMedia.Twitter = "@acme"
+	company.SocialMedia.Instagram = "instagram.com/acme"
+
+	for _, version := range []string{VCardVersion30, VCardVersion40} {
+		t.Run(version, func(t *testing.T) {
+			var buffer bytes.Buffer
+			if err := WriteVCard(&buffer, businessCard, VCardOptions{Version: version}); err != nil {
+				t.Fatal(err)
+			}
+
+			vCards, err := parseVCards(&buffer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(vCards) != 1 || vCards[0].err != nil {
+				t.Fatalf("parsed %+v", vCards)
+			}
+			parsed := businessCardFromVCard(vCards[0].properties)
+			if parsed.PersonalData != businessCard.PersonalData {
+				t.Errorf("personal data:\ngot  %+v\nwant %+v", parsed.PersonalData, businessCard.PersonalData)
+			}
+			if parsed.CompanyData != businessCard.CompanyData {
+				t.Errorf("company data:\ngot  %+v\nwant %+v", parsed.CompanyData, businessCard.CompanyData)
+			}
+		})
+	}
+
+	t.Run("full name only", func(t *testing.T) {
+		nameOnly := &models.BusinessCard{}
+		nameOnly.PersonalData.FullName = "Anna Smith"
+
+		var buffer bytes.Buffer
+		if err := WriteVCard(&buffer, nameOnly, VCardOptions{Version: VCardVersion30}); err != nil {
+			t.Fatal(err)
+		}
+		vCards, err := parseVCards(&buffer)
+		if err != nil || len(vCards) != 1 {
+			t.Fatalf("parsed %+v (%v)", vCards, err)
+		}
+		if parsed := businessCardFromVCard(vCards[0].properties); parsed.PersonalData != nameOnly.PersonalData {
+			t.Errorf("personal data = %+v, want only the full name", parsed.PersonalData)
+		}
+	})
+}
+
+func TestParseVCardsFromOtherApplications(t *testing.T) {
+	file := strings.Join([]string{
+		"BEGIN:VCARD",
+		"VERSION:2.1",
+		"N;CHARSET=UTF-8;ENCODING=QUOTED-PRINTABLE:M=C3=BCller;J=C3=B6rg",
+		"TEL;CELL:+49 171 7654321",
+		"TEL;WORK:+49 30 1234567",
+		"ADR;HOME:;;Home Street 1;Potsdam;;;",
+		"ADR;WORK:;;Work Street 2;Berlin;;;",
+		"END:VCARD",
+		"BEGIN:VCARD",
+		"VERSION:3.0",
+		"FN:Broken",
+		"this line has no separator",
+		"END:VCARD",
+		"BEGIN:VCARD",
+		"VERSION:4.0",
+		"FN:Folded",
+		"  Name",
+	}, "\r\n")
+
+	vCards, err := parseVCards(strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vCards) != 3 {
+		t.Fatalf("parsed %d vCards, want 3", len(vCards))
+	}
+
+	if vCards[0].err != nil {
+		t.Fatal(vCards[0].err)
+	}
+	first := businessCardFromVCard(vCards[0].properties)
+	if first.PersonalData.LastName != "Müller" || first.PersonalData.FirstName != "Jörg" {
+		t.Errorf("quoted-printable name = %q %q", first.PersonalData.FirstName, first.PersonalData.LastName)
+	}
+	if first.PersonalData.Mobile != "+49 171 7654321" || first.PersonalData.Phone != "+49 30 1234567" {
+		t.Errorf("phones = %q, %q", first.PersonalData.Phone, first.PersonalData.Mobile)
+	}
+	if first.CompanyData.Address.City != "Berlin" {
+		t.Errorf("address = %+v, want the work address", first.CompanyData.Address)
+	}
+
+	if vCards[1].err == nil {
+		t.Error("vCard with a malformed line has no error")
+	}
+	if vCards[2].err == nil || !strings.Contains(vCards[2].err.Error(), "END:VCARD") {
+		t.Errorf("unterminated vCard: got %v, want the missing END", vCards[2].err)
+	}
+	if len(vCards[2].properties) < 2 || vCards[2].properties[1].text() != "Folded Name" {
+		t.Errorf("folded line = %+v", vCards[2].properties)
+	}
+}
+
+func TestImportBusinessCards(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		format        string
+		file          string
+		wantStatuses  []string
+		wantIgnored   []string
+		wantDuplicate map[int]string
+	}{
+		{
+			name:   "vcard",
+			format: "vCard",
+			file: strings.Join([]string{
+				"BEGIN:VCARD", "VERSION:4.0", "FN:Anna Smith", "EMAIL:anna@acme.com", "END:VCARD",
+				"BEGIN:VCARD", "VERSION:4.0", "FN:Jane Doe", "EMAIL:JANE@ACME.COM", "END:VCARD",
+				"BEGIN:VCARD", "VERSION:4.0", "FN:Anna S.", "EMAIL:Anna@Acme.com", "END:VCARD",
+				"BEGIN:VCARD", "VERSION:4.0", "TITLE:Nobody", "END:VCARD",
+				"BEGIN:VCARD", "VERSION:4.0", "FN:Bad Mail", "EMAIL:not-an-address", "END:VCARD",
+			}, "\r\n"),
+			wantStatuses:  []string{models.ImportRowImported, models.ImportRowDuplicate, models.ImportRowDuplicate, models.ImportRowFailed, models.ImportRowFailed},
+			wantDuplicate: map[int]string{1: "existing"},
+		},
+		{
+			name:   "csv",
+			format: "csv",
+			file: "\ufeffFirst Name,Last Name,Company,Email,Phone Number,Favourite Colour\n" +
+				"Anna,Smith,Acme Corp,anna@acme.com,+49 30 1234567,blue\n" +
+				"Jane,Doe,Acme,jane@acme.com,,green\n" +
+				"Ben,Jones,'=Globex,ben@globex.com,,\n" +
+				"\"Broken,quote,Acme,x@acme.com\n",
+			wantStatuses:  []string{models.ImportRowImported, models.ImportRowDuplicate, models.ImportRowImported, models.ImportRowFailed},
+			wantIgnored:   []string{"Favourite Colour"},
+			wantDuplicate: map[int]string{1: "existing"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := NewMemoryRepository()
+			existing := models.BusinessCard{ID: "existing", Status: models.StatusCompleted, CreatedAt: time.Now()}
+			existing.PersonalData.Email = "jane@acme.com"
+			if err := repository.SaveBusinessCard(ctx, &existing); err != nil {
+				t.Fatal(err)
+			}
+			service := NewBusinessCardService(repository, &stubExtractor{})
+
+			report, err := service.ImportBusinessCards(ctx, strings.NewReader(tt.file), tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var statuses []string
+			for i, row := range report.Rows {
+				statuses = append(statuses, row.Status)
+				if row.Row != i+1 {
+					t.Errorf("row %d is numbered %d", i+1, row.Row)
+				}
+				if row.Status == models.ImportRowFailed && row.Error == "" {
+					t.Errorf("row %d failed without an error", row.Row)
+				}
+				if want, ok := tt.wantDuplicate[i]; ok && row.DuplicateOf != want {
+					t.Errorf("row %d is a duplicate of %q, want %q", row.Row, row.DuplicateOf, want)
+				}
+			}
+			if !slices.Equal(statuses, tt.wantStatuses) {
+				t.Errorf("row statuses = %v, want %v", statuses, tt.wantStatuses)
+			}
+			if !slices.Equal(report.IgnoredColumns, tt.wantIgnored) {
+				t.Errorf("ignored columns = %v, want %v", report.IgnoredColumns, tt.wantIgnored)
+			}
+			if report.Total != len(tt.wantStatuses) || report.Imported+report.Duplicates+report.Failed != report.Total {
+				t.Errorf("report counts %d imported, %d duplicates, %d failed of %d", report.Imported, report.Duplicates, report.Failed, report.Total)
+			}
+
+			for _, row := range report.Rows {
+				if row.Status != models.ImportRowImported {
+					continue
+				}
+				stored, err := repository.GetBusinessCard(ctx, row.BusinessCardID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if stored.Status != models.StatusCompleted || stored.Source == "" || strings.HasPrefix(stored.CompanyData.Name, "'") {
+					t.Errorf("imported card %+v", stored)
+				}
+			}
+		})
+	}
+}
+
+func TestImportBusinessCardsRejectsFiles(t *testing.T) {
+	service := NewBusinessCardService(NewMemoryRepository(), &stubExtractor{})
+
+	tests := []struct {
+		name   string
+		format string
+		file   string
+	}{
+		{"unknown format", "xlsx", "First Name\nAnna\n"},
+		{"no vcards", "vcard", "just some text\n"},
+		{"empty csv", "csv", ""},
+		{"no known column", "csv", "Colour,Size\nblue,L\n"},
+		{"too many rows", "csv", "Email\n" + strings.Repeat("anna@acme.com\n", MaxImportRows+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.ImportBusinessCards(context.Background(), strings.NewReader(tt.file), tt.format); !errors.Is(err, ErrInvalidImport) {
+				t.Errorf("got %v, want ErrInvalidImport", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"business-card-reader/internal/logger"
+	"business-card-reader/internal/models"
+)
+
+// ErrInvalidMerge is returned for merge requests that name unknown fields or business cards
+var ErrInvalidMerge = errors.New("invalid merge")
+
+// maxMergeDuplicates bounds the number of business cards merged at once
+const maxMergeDuplicates = 20
+
+// FindDuplicates returns the other business cards that probably describe the same contact,
+// best matches first. Email, phone numbers and name with company are compared after
+// normalization, names with some tolerance for typos.
+//
+// The card is compared with every card of the tenant, so that a contact who changed both email
+// and company is still found by mobile number or name. A search therefore reads all of the
+// tenant's cards, one listing page at a time: on DynamoDB a Query of the tenant's partition of the
+// list index whose read capacity grows with the number of cards, on SQLite an indexed range read.
+// Only the matches are held in memory.
+func (b *BusinessCardService) FindDuplicates(ctx context.Context, id string) ([]models.DuplicateCandidate, error) {
+	businessCard, err := b.repository.GetBusinessCard(ctx, id)
+	if err != nil {
+		logger.LogError("FindDuplicates", err, map[string]interface{}{
+			"step":             "get_business_card",
+			"business_card_id": id,
+		})
+		return nil, fmt.Errorf("failed to get business card: %w", err)
+	}
+
+	candidates := []models.DuplicateCandidate{}
+	compared := 0
+	err = b.forEachBusinessCard(ctx, models.BusinessCardQuery{SortOrder: models.SortOrderAsc}, func(other *models.BusinessCard) error {
+		if other.ID == businessCard.ID {
+			return nil
+		}
+		compared++
+
+		score, reasons := matchDuplicate(businessCard, other)
+		if score < DuplicateScoreThreshold {
+			return nil
+		}
+
+		candidates = append(candidates, models.DuplicateCandidate{
+			BusinessCard: *other,
+			Score:        score,
+			Reasons:      reasons,
+		})
+		return nil
+	})
+	if err != nil {
+		logger.LogError("FindDuplicates", err, map[string]interface{}{
+			"step":             "compare_business_cards",
+			"business_card_id": id,
+		})
+		return nil, fmt.Errorf("failed to get business cards: %w", err)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].BusinessCard.CreatedAt.Before(candidates[j].BusinessCard.CreatedAt)
+	})
+
+	logger.LogInfo("FindDuplicates", "Duplicate search completed", map[string]interface{}{
+		"business_card_id": id,
+		"compared":         compared,
+		"candidates":       len(candidates),
+	})
+
+	return candidates, nil
+}
+
+// MergeBusinessCards folds duplicates into the business card with the given ID and deletes them.
+// For every field listed in the request the value of the chosen card is kept and marked as
+// verified; other fields keep the target's value, or take the first non-empty value of the
+// duplicates. The images of all cards are kept. Changed values are recorded in the change log
+// with the given actor.
+func (b *BusinessCardService) MergeBusinessCards(ctx context.Context, id string, request models.BusinessCardMergeRequest, actor string) (*models.BusinessCard, error) {
+	if err := validateMergeRequest(id, &request); err != nil {
+		return nil, err
+	}
+
+	logger.LogInfo("MergeBusinessCards", "Merging business cards", map[string]interface{}{
+		"business_card_id": id,
+		"duplicate_ids":    request.DuplicateIDs,
+		"actor":            actor,
+	})
+
+	businessCards := make(map[string]*models.BusinessCard, len(request.DuplicateIDs)+1)
+	for _, cardID := range append([]string{id}, request.DuplicateIDs...) {
+		businessCard, err := b.repository.GetBusinessCard(ctx, cardID)
+		if err != nil {
+			logger.LogError("MergeBusinessCards", err, map[string]interface{}{
+				"step":             "get_business_card",
+				"business_card_id": cardID,
+			})
+			return nil, fmt.Errorf("failed to get business card %s: %w", cardID, err)
+		}
+
+		// An extraction in flight would overwrite the merged data, or save a deleted card again
+		if err := checkNotProcessing(businessCard); err != nil {
+			return nil, fmt.Errorf("business card %s: %w", cardID, err)
+		}
+		businessCards[cardID] = businessCard
+	}
+
+	target := businessCards[id]
+	duplicates := make([]*models.BusinessCard, len(request.DuplicateIDs))
+	for i, duplicateID := range request.DuplicateIDs {
+		duplicates[i] = businessCards[duplicateID]
+	}
+
+	changes := applyMerge(target, duplicates, businessCards, request.Fields, actor, time.Now())
+	b.postProcess(target)
+
+	if err := b.saveBusinessCard(ctx, target); err != nil {
+		logger.LogError("MergeBusinessCards", err, map[string]interface{}{
+			"step":             "save_business_card",
+			"business_card_id": id,
+		})
+		return nil, fmt.Errorf("failed to save merged business card: %w", err)
+	}
+
+	// The images now belong to the target, so only the records of the duplicates are deleted
+	for _, duplicate := range duplicates {
+		if err := b.repository.DeleteBusinessCard(ctx, duplicate.ID); err != nil {
+			logger.LogError("MergeBusinessCards", err, map[string]interface{}{
+				"step":             "delete_duplicate",
+				"business_card_id": id,
+				"duplicate_id":     duplicate.ID,
+			})
+			return nil, fmt.Errorf("failed to delete merged business card %s: %w", duplicate.ID, err)
+		}
+	}
+
+	logger.LogInfo("MergeBusinessCards", "Business cards merged successfully", map[string]interface{}{
+		"business_card_id": id,
+		"merged":           len(duplicates),
+		"changed_fields":   len(changes),
+		"images":           len(target.Images),
+	})
+
+	return target, nil
+}
+
+func validateMergeRequest(id string, request *models.BusinessCardMergeRequest) error {
+	if len(request.DuplicateIDs) == 0 {
+		return fmt.Errorf("%w: duplicate_ids is required", ErrInvalidMerge)
+	}
+	if len(request.DuplicateIDs) > maxMergeDuplicates {
+		return fmt.Errorf("%w: at most %d business cards can be merged at once", ErrInvalidMerge, maxMergeDuplicates)
+	}
+
+	ids := []string{id}
+	for _, duplicateID := range request.DuplicateIDs {
+		if slices.Contains(ids, duplicateID) {
+			return fmt.Errorf("%w: business card %s is listed twice", ErrInvalidMerge, duplicateID)
+		}
+		ids = append(ids, duplicateID)
+	}
+
+	for path, sourceID := range request.Fields {
+		if !slices.ContainsFunc(editableFields, func(field editableField) bool { return field.path == path }) {
+			return fmt.Errorf("%w: unknown field %q", ErrInvalidMerge, path)
+		}
+		if !slices.Contains(ids, sourceID) {
+			return fmt.Errorf("%w: %s must come from one of the merged business cards", ErrInvalidMerge, path)
+		}
+	}
+
+	return nil
+}
+
+// applyMerge combines the duplicates into the target and returns the recorded changes
+func applyMerge(target *models.BusinessCard, duplicates []*models.BusinessCard, businessCards map[string]*models.BusinessCard, choices map[string]string, actor string, now time.Time) []models.FieldChange {
+	var changes []models.FieldChange
+	for _, field := range editableFields {
+		current := field.value(target)
+
+		var source *models.BusinessCard
+		if sourceID, chosen := choices[field.path]; chosen {
+			source = businessCards[sourceID]
+		} else if *current == "" {
+			for _, duplicate := range duplicates {
+				if *field.value(duplicate) != "" {
+					source = duplicate
+					break
+				}
+			}
+		}
+		if source == nil {
+			continue
+		}
+
+		value := *field.value(source)
+		if *current != value {
+			changes = append(changes, models.FieldChange{
+				Field:     field.path,
+				OldValue:  *current,
+				NewValue:  value,
+				ChangedBy: actor,
+				ChangedAt: now,
+			})
+			*current = value
+		}
+
+		// A chosen value was checked by a person, a filled-in one is as reliable as its source
+		_, chosen := choices[field.path]
+		if chosen || slices.Contains(source.VerifiedFields, field.path) {
+			if !slices.Contains(target.VerifiedFields, field.path) {
+				target.VerifiedFields = append(target.VerifiedFields, field.path)
+			}
+			delete(target.FieldConfidence, field.path)
+		} else if source != target {
+			setFieldConfidence(target, field.path, source.FieldConfidence)
+		}
+	}
+
+	for _, duplicate := range duplicates {
+		target.Images = append(target.Images, duplicate.Images...)
+		target.MergedFrom = append(target.MergedFrom, duplicate.ID)
+		target.MergedFrom = append(target.MergedFrom, duplicate.MergedFrom...)
+	}
+	target.ChangeLog = append(target.ChangeLog, changes...)
+
+	return changes
+}
+
+// setFieldConfidence copies the confidence score of a field from another card's scores
+func setFieldConfidence(businessCard *models.BusinessCard, path string, scores map[string]float64) {
+	score, ok := scores[path]
+	if !ok {
+		delete(businessCard.FieldConfidence, path)
+		return
+	}
+	if businessCard.FieldConfidence == nil {
+		businessCard.FieldConfidence = make(map[string]float64)
+	}
+	businessCard.FieldConfidence[path] = score
+}
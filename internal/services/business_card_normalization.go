@@ -0,0 +1,102 @@
+package services
+
+import (
+	"strings"
+
+	"business-card-reader/internal/models"
+)
+
+// normalizeBusinessCardData cleans up personal and company data the same way for every source:
+// extraction results and imported contacts. Whitespace is collapsed, emails are lower-cased and
+// missing name parts are derived from the others.
+func normalizeBusinessCardData(businessCard *models.BusinessCard) {
+	for _, field := range editableFields {
+		value := field.value(businessCard)
+		*value = collapseSpaces(*value)
+
+		switch {
+		case strings.HasSuffix(field.path, ".email"):
+			*value = strings.ToLower(strings.TrimPrefix(*value, "mailto:"))
+		case strings.HasSuffix(field.path, ".phone"), strings.HasSuffix(field.path, ".mobile"):
+			*value = strings.TrimPrefix(*value, "tel:")
+		}
+	}
+
+	personal := &businessCard.PersonalData
+	switch {
+	case personal.FullName == "" && (personal.FirstName != "" || personal.LastName != ""):
+		personal.FullName = strings.TrimSpace(personal.FirstName + " " + personal.LastName)
+
+		var sources []string
+		if personal.FirstName != "" {
+			sources = append(sources, "personal_data.first_name")
+		}
+		if personal.LastName != "" {
+			sources = append(sources, "personal_data.last_name")
+		}
+		deriveFieldConfidence(businessCard, "personal_data.full_name", sources...)
+	case personal.FullName != "" && personal.FirstName == "" && personal.LastName == "":
+		if i := strings.LastIndex(personal.FullName, " "); i > 0 {
+			personal.FirstName = personal.FullName[:i]
+			personal.LastName = personal.FullName[i+1:]
+			deriveFieldConfidence(businessCard, "personal_data.first_name", "personal_data.full_name")
+			deriveFieldConfidence(businessCard, "personal_data.last_name", "personal_data.full_name")
+		}
+	}
+}
+
+// collapseSpaces trims a value and reduces runs of spaces and tabs to one space. Line breaks,
+// as in full addresses, are kept.
+func collapseSpaces(value string) string {
+	lines := strings.Split(strings.TrimSpace(value), "\n")
+	for i, line := range lines {
+		lines[i] = strings.Join(strings.Fields(line), " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// duplicateIndex finds business cards that describe the same contact by their normalized email,
+// or by name and company. Unlike matchDuplicate it only finds exact matches, which is safe for
+// skipping imported contacts without review.
+type duplicateIndex struct {
+	ids map[string]string
+}
+
+func newDuplicateIndex(businessCards []models.BusinessCard) *duplicateIndex {
+	index := &duplicateIndex{ids: make(map[string]string)}
+	for i := range businessCards {
+		index.add(&businessCards[i])
+	}
+	return index
+}
+
+func duplicateKeys(businessCard *models.BusinessCard) []string {
+	var keys []string
+	if email := emailKey(businessCard.PersonalData.Email); email != "" {
+		keys = append(keys, "email:"+email)
+	}
+	name := personNameKey(businessCard.PersonalData.FullName)
+	company := companyKey(businessCard.CompanyData.Name)
+	if name != "" && company != "" {
+		keys = append(keys, "name:"+name+"|"+company)
+	}
+	return keys
+}
+
+func (d *duplicateIndex) add(businessCard *models.BusinessCard) {
+	for _, key := range duplicateKeys(businessCard) {
+		if _, exists := d.ids[key]; !exists {
+			d.ids[key] = businessCard.ID
+		}
+	}
+}
+
+// find returns the ID of a known duplicate of the business card, or ""
+func (d *duplicateIndex) find(businessCard *models.BusinessCard) string {
+	for _, key := range duplicateKeys(businessCard) {
+		if id, ok := d.ids[key]; ok {
+			return id
+		}
+	}
+	return ""
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"business-card-reader/internal/logger"
+	"business-card-reader/internal/models"
+)
+
+// ErrReviewConflict is returned when a business card isn't waiting for review or was claimed by
+// another reviewer
+var ErrReviewConflict = errors.New("review conflict")
+
+// GetReviewQueue returns the business cards waiting for a reviewer or being reviewed, oldest
+// first. Image bytes are not included.
+func (b *BusinessCardService) GetReviewQueue(ctx context.Context) ([]models.BusinessCard, error) {
+	var queue []models.BusinessCard
+	for _, status := range []string{models.StatusNeedsReview, models.StatusInReview} {
+		businessCards, err := b.repository.GetBusinessCardsByStatus(ctx, status)
+		if err != nil {
+			logger.LogError("GetReviewQueue", err, map[string]interface{}{
+				"status": status,
+			})
+			return nil, fmt.Errorf("failed to get %s business cards: %w", status, err)
+		}
+		queue = append(queue, businessCards...)
+	}
+
+	sort.SliceStable(queue, func(i, j int) bool {
+		return queue[i].CreatedAt.Before(queue[j].CreatedAt)
+	})
+	for i := range queue {
+		for j := range queue[i].Images {
+			queue[i].Images[j].Data = nil
+		}
+	}
+
+	logger.LogDebug("GetReviewQueue", "Retrieved review queue", map[string]interface{}{
+		"count": len(queue),
+	})
+
+	return queue, nil
+}
+
+// ClaimReview assigns a NEEDS_REVIEW business card to the reviewer and moves it to IN_REVIEW.
+// Claiming a card again as the same reviewer is allowed.
+func (b *BusinessCardService) ClaimReview(ctx context.Context, id string, reviewer string) (*models.BusinessCard, error) {
+	businessCard, err := b.repository.GetBusinessCard(ctx, id)
+	if err != nil {
+		logger.LogError("ClaimReview", err, map[string]interface{}{
+			"step":             "get_business_card",
+			"business_card_id": id,
+		})
+		return nil, fmt.Errorf("failed to get business card: %w", err)
+	}
+
+	switch {
+	case businessCard.Status == models.StatusInReview && businessCard.Review != nil && businessCard.Review.Reviewer == reviewer:
+		return businessCard, nil
+	case businessCard.Status == models.StatusInReview:
+		return nil, fmt.Errorf("%w: business card is claimed by another reviewer", ErrReviewConflict)
+	case businessCard.Status != models.StatusNeedsReview:
+		return nil, fmt.Errorf("%w: status is %s", ErrReviewConflict, businessCard.Status)
+	}
+
+	now := time.Now()
+	businessCard.Status = models.StatusInReview
+	businessCard.Review = &models.Review{
+		State:     models.ReviewStateClaimed,
+		Reviewer:  reviewer,
+		ClaimedAt: &now,
+	}
+
+	// Of concurrent claims only the first finds the card still waiting for review
+	err = b.saveBusinessCardIf(ctx, businessCard, BusinessCardCondition{Status: models.StatusNeedsReview})
+	if errors.Is(err, ErrBusinessCardChanged) {
+		return nil, fmt.Errorf("%w: business card was claimed by another reviewer", ErrReviewConflict)
+	}
+	if err != nil {
+		logger.LogError("ClaimReview", err, map[string]interface{}{
+			"step":             "save_business_card",
+			"business_card_id": id,
+		})
+		return nil, fmt.Errorf("failed to save business card: %w", err)
+	}
+
+	b.notifyListeners(ctx, businessCard, models.StatusNeedsReview)
+
+	logger.LogInfo("ClaimReview", "Business card claimed for review", map[string]interface{}{
+		"business_card_id": id,
+		"reviewer":         reviewer,
+	})
+
+	return businessCard, nil
+}
+
+// ApproveReview completes a business card waiting for review, applying the reviewer's
+// corrections first when given. Cards claimed by another reviewer can't be approved.
+func (b *BusinessCardService) ApproveReview(ctx context.Context, id string, corrections *models.BusinessCardUpdateRequest, reviewer string) (*models.BusinessCard, error) {
+	businessCard, err := b.repository.GetBusinessCard(ctx, id)
+	if err != nil {
+		logger.LogError("ApproveReview", err, map[string]interface{}{
+			"step":             "get_business_card",
+			"business_card_id": id,
+		})
+		return nil, fmt.Errorf("failed to get business card: %w", err)
+	}
+
+	previousStatus := businessCard.Status
+	switch {
+	case businessCard.Status == models.StatusInReview && businessCard.Review != nil && businessCard.Review.Reviewer != reviewer:
+		return nil, fmt.Errorf("%w: business card is claimed by another reviewer", ErrReviewConflict)
+	case businessCard.Status != models.StatusNeedsReview && businessCard.Status != models.StatusInReview:
+		return nil, fmt.Errorf("%w: status is %s", ErrReviewConflict, businessCard.Status)
+	}
+	// The approval is saved only while the card is still as read here, claimed by the same reviewer
+	condition := BusinessCardCondition{Status: businessCard.Status}
+	if businessCard.Review != nil {
+		condition.Reviewer = businessCard.Review.Reviewer
+	}
+
+	now := time.Now()
+	var changes []models.FieldChange
+	if corrections != nil {
+		changes, err = applyBusinessCardUpdate(businessCard, corrections, reviewer, now)
+		if err != nil {
+			logger.LogWarn("ApproveReview", "Rejected review corrections", map[string]interface{}{
+				"business_card_id": id,
+				"error":            err.Error(),
+			})
+			return nil, err
+		}
+		b.postProcess(businessCard)
+	}
+
+	review := businessCard.Review
+	if review == nil {
+		review = &models.Review{}
+	}
+	review.State = models.ReviewStateApproved
+	review.Reviewer = reviewer
+	review.ApprovedAt = &now
+	review.Corrected = review.Corrected || len(changes) > 0
+	businessCard.Review = review
+	businessCard.Status = models.StatusCompleted
+
+	err = b.saveBusinessCardIf(ctx, businessCard, condition)
+	if errors.Is(err, ErrBusinessCardChanged) {
+		return nil, fmt.Errorf("%w: business card was claimed or reviewed concurrently", ErrReviewConflict)
+	}
+	if err != nil {
+		logger.LogError("ApproveReview", err, map[string]interface{}{
+			"step":             "save_business_card",
+			"business_card_id": id,
+		})
+		return nil, fmt.Errorf("failed to save business card: %w", err)
+	}
+
+	b.notifyListeners(ctx, businessCard, previousStatus)
+
+	logger.LogInfo("ApproveReview", "Business card approved", map[string]interface{}{
+		"business_card_id": id,
+		"reviewer":         reviewer,
+		"changed_fields":   len(changes),
+	})
+
+	return businessCard, nil
+}
@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"business-card-reader/internal/models"
+)
+
+// lockstepRepository holds every GetBusinessCard until the given number of readers have read, so
+// concurrent reviewers all act on the same stored state
+type lockstepRepository struct {
+	Repository
+	reads sync.WaitGroup
+}
+
+func (l *lockstepRepository) GetBusinessCard(ctx context.Context, id string) (*models.BusinessCard, error) {
+	businessCard, err := l.Repository.GetBusinessCard(ctx, id)
+	l.reads.Done()
+	l.reads.Wait()
+	return businessCard, err
+}
+
+func TestConcurrentReviewsConflict(t *testing.T) {
+	ctx := WithTenant(context.Background(), "acme")
+
+	for backend, newRepository := range repositoryBackends {
+		t.Run(backend, func(t *testing.T) {
+			repository := newRepository(t)
+			if err := repository.CreateTableIfNotExists(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			waiting := models.BusinessCard{ID: "card", TenantID: "acme", Status: models.StatusNeedsReview, CreatedAt: time.Now()}
+			if err := repository.SaveBusinessCard(ctx, &waiting); err != nil {
+				t.Fatal(err)
+			}
+			lockstep := &lockstepRepository{Repository: repository}
+			service := NewBusinessCardService(lockstep, &stubExtractor{})
+
+			t.Run("two claims", func(t *testing.T) {
+				lockstep.reads.Add(2)
+				errs := make(chan error, 2)
+				for _, reviewer := range []string{"alice", "bob"} {
+					go func() {
+						_, err := service.ClaimReview(ctx, "card", reviewer)
+						errs <- err
+					}()
+				}
+
+				var claimed, conflicts int
+				for range 2 {
+					switch err := <-errs; {
+					case err == nil:
+						claimed++
+					case errors.Is(err, ErrReviewConflict):
+						conflicts++
+					default:
+						t.Fatal(err)
+					}
+				}
+				if claimed != 1 || conflicts != 1 {
+					t.Fatalf("%d claims succeeded and %d conflicted, want one each", claimed, conflicts)
+				}
+			})
+
+			stored, err := repository.GetBusinessCard(ctx, "card")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stored.Status != models.StatusInReview || stored.Review == nil {
+				t.Fatalf("stored card is %s with review %+v, want it claimed", stored.Status, stored.Review)
+			}
+			claimer := stored.Review.Reviewer
+
+			t.Run("two approvals", func(t *testing.T) {
+				lockstep.reads.Add(2)
+				errs := make(chan error, 2)
+				for range 2 {
+					go func() {
+						_, err := service.ApproveReview(ctx, "card", nil, claimer)
+						errs <- err
+					}()
+				}
+
+				var approved, conflicts int
+				for range 2 {
+					switch err := <-errs; {
+					case err == nil:
+						approved++
+					case errors.Is(err, ErrReviewConflict):
+						conflicts++
+					default:
+						t.Fatal(err)
+					}
+				}
+				if approved != 1 || conflicts != 1 {
+					t.Errorf("%d approvals succeeded and %d conflicted, want one each", approved, conflicts)
+				}
+			})
+		})
+	}
+}
+
+func TestGetReviewQueue(t *testing.T) {
+	ctx := context.Background()
+	repository := NewMemoryRepository()
+	created := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	for i, status := range []string{models.StatusInReview, models.StatusCompleted, models.StatusNeedsReview, models.StatusFailed, models.StatusNeedsReview} {
+		businessCard := models.BusinessCard{
+			ID:        fmt.Sprintf("card-%d", i),
+			Status:    status,
+			CreatedAt: created.Add(time.Duration(-i) * time.Minute),
+			Images:    []models.ImageData{{FileName: "front.jpg", ContentType: "image/jpeg", Data: []byte("jpeg bytes")}},
+		}
+		if err := repository.SaveBusinessCard(ctx, &businessCard); err != nil {
+			t.Fatal(err)
+		}
+	}
+	service := NewBusinessCardService(repository, &stubExtractor{})
+
+	queue, err := service.GetReviewQueue(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, businessCard := range queue {
+		ids = append(ids, businessCard.ID)
+		if businessCard.Images[0].Data != nil {
+			t.Errorf("queue returned the image bytes of %s", businessCard.ID)
+		}
+	}
+	if want := []string{"card-4", "card-2", "card-0"}; !slices.Equal(ids, want) {
+		t.Errorf("queue = %v, want %v", ids, want)
+	}
+}
+
+func TestReviewWorkflow(t *testing.T) {
+	ctx := context.Background()
+	repository := NewMemoryRepository()
+	for _, id := range []string{"claimed", "unclaimed", "done"} {
+		businessCard := models.BusinessCard{ID: id, Status: models.StatusNeedsReview, CreatedAt: time.Now()}
+		if id == "done" {
+			businessCard.Status = models.StatusCompleted
+		}
+		businessCard.PersonalData.FullName = "Jane Doe"
+		businessCard.PersonalData.Email = "jane@acme.cm"
+		if err := repository.SaveBusinessCard(ctx, &businessCard); err != nil {
+			t.Fatal(err)
+		}
+	}
+	service := NewBusinessCardService(repository, &stubExtractor{})
+
+	claimed, err := service.ClaimReview(ctx, "claimed", "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claimed.Status != models.StatusInReview || claimed.Review.State != models.ReviewStateClaimed || claimed.Review.ClaimedAt == nil {
+		t.Errorf("claimed card is %s with review %+v", claimed.Status, claimed.Review)
+	}
+	if _, err := service.ClaimReview(ctx, "claimed", "alice"); err != nil {
+		t.Errorf("claiming again as the same reviewer: %v", err)
+	}
+
+	email := "jane@acme.com"
+	invalid := "not-an-address"
+	tests := []struct {
+		name        string
+		id          string
+		reviewer    string
+		corrections *models.BusinessCardUpdateRequest
+		claim       bool
+		wantErr     error
+	}{
+		{name: "claimed by another reviewer", id: "claimed", reviewer: "bob", claim: true, wantErr: ErrReviewConflict},
+		{name: "approved by another reviewer", id: "claimed", reviewer: "bob", wantErr: ErrReviewConflict},
+		{name: "invalid corrections", id: "claimed", reviewer: "alice", corrections: &models.BusinessCardUpdateRequest{PersonalData: models.PersonalDataUpdate{Email: &invalid}}, wantErr: ErrInvalidBusinessCardUpdate},
+		{name: "not waiting for review", id: "done", reviewer: "alice", claim: true, wantErr: ErrReviewConflict},
+		{name: "already completed", id: "done", reviewer: "alice", wantErr: ErrReviewConflict},
+		{name: "unknown card", id: "missing", reviewer: "alice", wantErr: ErrBusinessCardNotFound},
+		{name: "approved with corrections", id: "claimed", reviewer: "alice", corrections: &models.BusinessCardUpdateRequest{PersonalData: models.PersonalDataUpdate{Email: &email}}},
+		{name: "approved without a claim", id: "unclaimed", reviewer: "bob"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if tt.claim {
+				_, err = service.ClaimReview(ctx, tt.id, tt.reviewer)
+			} else {
+				_, err = service.ApproveReview(ctx, tt.id, tt.corrections, tt.reviewer)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("got %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			stored, err := repository.GetBusinessCard(ctx, tt.id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			review := stored.Review
+			if stored.Status != models.StatusCompleted || review == nil || review.State != models.ReviewStateApproved || review.Reviewer != tt.reviewer || review.ApprovedAt == nil {
+				t.Errorf("approved card is %s with review %+v", stored.Status, review)
+				return
+			}
+			if review.Corrected != (tt.corrections != nil) {
+				t.Errorf("corrected = %v", review.Corrected)
+			}
+			if tt.corrections != nil {
+				if stored.PersonalData.Email != email || !slices.Contains(stored.VerifiedFields, "personal_data.email") {
+					t.Errorf("corrections weren't applied: %+v", stored)
+				}
+				if len(stored.ChangeLog) != 1 || stored.ChangeLog[0].ChangedBy != tt.reviewer {
+					t.Errorf("change log = %+v", stored.ChangeLog)
+				}
+			}
+		})
+	}
+}
@@ -2,6 +2,9 @@ package services
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"time"
 
@@ -12,37 +15,211 @@ import (
 )
 
 type BusinessCardService struct {
-	dynamoService *DynamoService
-	geminiService *GeminiService
+	repository        BusinessCardRepository
+	extractor         Extractor
+	fallbackExtractor Extractor
+	queue             *ProcessingQueue
+	listeners         []BusinessCardListener
+	imageStore        ImageStore
+	postProcessors    []PostProcessor
+	// confidenceThreshold sends extracted cards with a less certain field to NEEDS_REVIEW
+	confidenceThreshold float64
+	// extractionSlots holds a token per running extraction, nil when extractions aren't capped
+	extractionSlots chan struct{}
+	extractionWait  time.Duration
+	// deliveryRedactor scrubs erased cards from stored webhook payloads
+	deliveryRedactor DeliveryRedactor
 }
 
-func NewBusinessCardService(dynamoService *DynamoService, geminiService *GeminiService) *BusinessCardService {
-	logger.LogInfo("NewBusinessCardService", "Business card service initialized", map[string]interface{}{})
+func NewBusinessCardService(repository BusinessCardRepository, extractor Extractor) *BusinessCardService {
+	logger.LogInfo("NewBusinessCardService", "Business card service initialized", map[string]interface{}{
+		"extraction_provider": extractor.Name(),
+	})
 	return &BusinessCardService{
-		dynamoService: dynamoService,
-		geminiService: geminiService,
+		repository: repository,
+		extractor:  extractor,
 	}
 }
 
+// SetFallbackExtractor configures an extractor used automatically whenever the primary one fails
+func (b *BusinessCardService) SetFallbackExtractor(fallbackExtractor Extractor) {
+	logger.LogInfo("SetFallbackExtractor", "Fallback extraction provider configured", map[string]interface{}{
+		"provider": fallbackExtractor.Name(),
+	})
+	b.fallbackExtractor = fallbackExtractor
+}
+
+// SetImageStore moves image bytes out of the business card records into the given store
+func (b *BusinessCardService) SetImageStore(imageStore ImageStore) {
+	logger.LogInfo("SetImageStore", "Image store configured", map[string]interface{}{
+		"image_store": imageStore.Name(),
+	})
+	b.imageStore = imageStore
+}
+
 func (b *BusinessCardService) ProcessBusinessCard(ctx context.Context, images []models.ImageUpload) (*models.BusinessCard, error) {
+	businessCard, err := b.createBusinessCard(ctx, images)
+	if err != nil {
+		return nil, err
+	}
+
+	processedCard, err := b.processBusinessCard(ctx, businessCard, false)
+	if errors.Is(err, errExtractionNotStarted) {
+		// The client gets no card back and has to upload again, so the stored one isn't kept.
+		// It is still PENDING, but nothing else works on a card of a synchronous upload.
+		if _, deleteErr := b.purgeBusinessCard(context.WithoutCancel(ctx), businessCard); deleteErr != nil {
+			logger.LogError("ProcessBusinessCard", deleteErr, map[string]interface{}{
+				"step":             "discard_unextracted_card",
+				"business_card_id": businessCard.ID,
+			})
+		}
+		return nil, err
+	}
+	return processedCard, err
+}
+
+// SubmitBusinessCard stores a PENDING business card and hands it to the async processing
+// queue, returning without waiting for the extraction. When the queue doesn't take the card, it
+// is deleted with its images and only the error is returned.
+func (b *BusinessCardService) SubmitBusinessCard(ctx context.Context, images []models.ImageUpload) (*models.BusinessCard, error) {
+	if b.queue == nil {
+		return nil, fmt.Errorf("async processing is not enabled")
+	}
+
+	businessCard, err := b.createBusinessCard(ctx, images)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.queue.Enqueue(businessCard.TenantID, businessCard.ID); err != nil {
+		logger.LogError("SubmitBusinessCard", err, map[string]interface{}{
+			"step":             "enqueue",
+			"business_card_id": businessCard.ID,
+		})
+
+		// The client gets no card back and has to upload again, so the stored one isn't kept
+		if _, deleteErr := b.purgeBusinessCard(context.WithoutCancel(ctx), businessCard); deleteErr != nil {
+			logger.LogError("SubmitBusinessCard", deleteErr, map[string]interface{}{
+				"step":             "discard_unqueued_card",
+				"business_card_id": businessCard.ID,
+			})
+		}
+		return nil, err
+	}
+
+	logger.LogInfo("SubmitBusinessCard", "Business card queued for processing", map[string]interface{}{
+		"business_card_id": businessCard.ID,
+	})
+
+	return businessCard, nil
+}
+
+// ProcessPendingBusinessCard runs the extraction for a card previously stored by SubmitBusinessCard
+func (b *BusinessCardService) ProcessPendingBusinessCard(ctx context.Context, id string) (*models.BusinessCard, error) {
+	businessCard, err := b.repository.GetBusinessCard(ctx, id)
+	if err != nil {
+		logger.LogError("ProcessPendingBusinessCard", err, map[string]interface{}{
+			"step":             "get_business_card",
+			"business_card_id": id,
+		})
+		return nil, fmt.Errorf("failed to get business card: %w", err)
+	}
+
+	if businessCard.Status != models.StatusPending {
+		logger.LogWarn("ProcessPendingBusinessCard", "Business card is not pending, skipping", map[string]interface{}{
+			"business_card_id": id,
+			"current_status":   businessCard.Status,
+		})
+		return businessCard, nil
+	}
+
+	return b.processBusinessCard(ctx, businessCard, true)
+}
+
+// EnableAsyncProcessing starts a bounded worker pool for SubmitBusinessCard and requeues
+// cards of every tenant that were still PENDING when the service last stopped
+func (b *BusinessCardService) EnableAsyncProcessing(ctx context.Context, workers int, queueSize int) error {
+	b.queue = NewProcessingQueue(workers, queueSize, func(jobCtx context.Context, id string) {
+		if _, err := b.ProcessPendingBusinessCard(jobCtx, id); err != nil {
+			logger.LogError("ProcessingQueue", err, map[string]interface{}{
+				"business_card_id": id,
+			})
+		}
+	})
+	b.queue.Start()
+
+	pendingCards, err := b.repository.GetBusinessCardsByStatus(WithAllTenants(ctx), models.StatusPending)
+	if err != nil {
+		return fmt.Errorf("failed to load pending business cards: %w", err)
+	}
+
+	for _, pendingCard := range pendingCards {
+		if err := b.queue.Enqueue(recordTenant(pendingCard.TenantID), pendingCard.ID); err != nil {
+			logger.LogWarn("EnableAsyncProcessing", "Could not requeue pending business card", map[string]interface{}{
+				"business_card_id": pendingCard.ID,
+				"error":            err.Error(),
+			})
+		}
+	}
+
+	logger.LogInfo("EnableAsyncProcessing", "Async processing enabled", map[string]interface{}{
+		"workers":        workers,
+		"queue_size":     queueSize,
+		"requeued_cards": len(pendingCards),
+	})
+
+	return nil
+}
+
+// IsAsync reports whether uploads are processed in the background
+func (b *BusinessCardService) IsAsync() bool {
+	return b.queue != nil
+}
+
+// Shutdown waits for queued business cards to finish processing
+func (b *BusinessCardService) Shutdown() {
+	if b.queue != nil {
+		b.queue.Stop()
+	}
+}
+
+// createBusinessCard stores a new PENDING business card of the context's tenant for the uploaded images
+func (b *BusinessCardService) createBusinessCard(ctx context.Context, images []models.ImageUpload) (*models.BusinessCard, error) {
 	businessCardID := uuid.New().String()
+	tenantID := TenantFromContext(ctx)
 
 	logger.LogInfo("ProcessBusinessCard", "Starting business card processing", map[string]interface{}{
 		"business_card_id": businessCardID,
+		"tenant_id":        tenantID,
 		"image_count":      len(images),
 	})
 
 	// Convert uploads to image data
 	imageData := make([]models.ImageData, len(images))
 	for i, upload := range images {
+		checksum := sha256.Sum256(upload.Data)
 		imageData[i] = models.ImageData{
 			FileName:    upload.FileName,
 			ContentType: upload.ContentType,
+			Checksum:    hex.EncodeToString(checksum[:]),
 			Data:        upload.Data,
 			Size:        int64(len(upload.Data)),
 			UploadedAt:  time.Now(),
 		}
 
+		if b.imageStore != nil {
+			objectKey := imageObjectKey(tenantID, businessCardID, i, upload.ContentType)
+			if err := b.imageStore.PutImage(ctx, objectKey, upload.ContentType, upload.Data); err != nil {
+				logger.LogError("ProcessBusinessCard", err, map[string]interface{}{
+					"step":             "store_image",
+					"business_card_id": businessCardID,
+					"image_index":      i,
+				})
+				return nil, fmt.Errorf("failed to store image: %w", err)
+			}
+			imageData[i].ObjectKey = objectKey
+		}
+
 		logger.LogDebug("ProcessBusinessCard", "Image processed", map[string]interface{}{
 			"business_card_id": businessCardID,
 			"image_index":      i,
@@ -55,7 +232,9 @@ func (b *BusinessCardService) ProcessBusinessCard(ctx context.Context, images []
 	// Create initial business card record
 	businessCard := &models.BusinessCard{
 		ID:        businessCardID,
+		TenantID:  tenantID,
 		Images:    imageData,
+		Source:    models.SourceScan,
 		Status:    models.StatusPending,
 		CreatedAt: time.Now(),
 	}
@@ -66,7 +245,7 @@ func (b *BusinessCardService) ProcessBusinessCard(ctx context.Context, images []
 		"status":           models.StatusPending,
 	})
 
-	err := b.dynamoService.SaveBusinessCard(ctx, businessCard)
+	err := b.saveBusinessCard(ctx, businessCard)
 	if err != nil {
 		logger.LogError("ProcessBusinessCard", err, map[string]interface{}{
 			"step":             "save_initial_record",
@@ -75,14 +254,26 @@ func (b *BusinessCardService) ProcessBusinessCard(ctx context.Context, images []
 		return nil, fmt.Errorf("failed to save initial business card: %w", err)
 	}
 
-	// Try to process with Gemini
+	b.notifyListeners(ctx, businessCard, "")
+
+	return businessCard, nil
+}
+
+// processBusinessCard moves a stored card through PROCESSING to COMPLETED, NEEDS_REVIEW or FAILED.
+// A card that gets no extraction slot goes back to its previous status; without waitForever that
+// happens with ErrExtractionBusy after the configured wait.
+func (b *BusinessCardService) processBusinessCard(ctx context.Context, businessCard *models.BusinessCard, waitForever bool) (*models.BusinessCard, error) {
+	businessCardID := businessCard.ID
+	previousStatus := businessCard.Status
+
+	// Try to process with the extraction provider
 	businessCard.Status = models.StatusProcessing
 	logger.LogInfo("ProcessBusinessCard", "Updating status to processing", map[string]interface{}{
 		"business_card_id": businessCardID,
 		"status":           models.StatusProcessing,
 	})
 
-	err = b.dynamoService.SaveBusinessCard(ctx, businessCard)
+	err := b.saveBusinessCard(ctx, businessCard)
 	if err != nil {
 		logger.LogError("ProcessBusinessCard", err, map[string]interface{}{
 			"step":             "update_status_processing",
@@ -91,15 +282,24 @@ func (b *BusinessCardService) ProcessBusinessCard(ctx context.Context, images []
 		return nil, fmt.Errorf("failed to update business card status: %w", err)
 	}
 
-	// Extract data using Gemini
-	logger.LogInfo("ProcessBusinessCard", "Starting Gemini AI processing", map[string]interface{}{
+	b.notifyListeners(ctx, businessCard, previousStatus)
+
+	// Extract data using the configured provider
+	logger.LogInfo("ProcessBusinessCard", "Starting AI extraction", map[string]interface{}{
 		"business_card_id": businessCardID,
+		"provider":         b.extractor.Name(),
 	})
 
-	processedCard, err := b.geminiService.ExtractBusinessCardData(ctx, imageData)
+	processedCard, err := b.extractBusinessCardData(ctx, businessCardID, businessCard.Images, waitForever)
+	if errors.Is(err, errExtractionNotStarted) {
+		if restoreErr := b.restoreUnextractedCard(ctx, businessCard, previousStatus); restoreErr != nil {
+			return nil, restoreErr
+		}
+		return nil, err
+	}
 	if err != nil {
 		logger.LogError("ProcessBusinessCard", err, map[string]interface{}{
-			"step":             "gemini_processing",
+			"step":             "extraction",
 			"business_card_id": businessCardID,
 		})
 
@@ -117,7 +317,7 @@ func (b *BusinessCardService) ProcessBusinessCard(ctx context.Context, images []
 		})
 
 		// Save failed state
-		saveErr := b.dynamoService.SaveBusinessCard(ctx, businessCard)
+		saveErr := b.saveBusinessCard(ctx, businessCard)
 		if saveErr != nil {
 			logger.LogError("ProcessBusinessCard", saveErr, map[string]interface{}{
 				"step":             "save_failed_state",
@@ -126,25 +326,28 @@ func (b *BusinessCardService) ProcessBusinessCard(ctx context.Context, images []
 			return nil, fmt.Errorf("failed to save error state: %w", saveErr)
 		}
 
+		b.notifyListeners(ctx, businessCard, models.StatusProcessing)
+
 		return businessCard, fmt.Errorf("failed to process business card: %w", err)
 	}
 
-	// Update with processed data
-	businessCard.PersonalData = processedCard.PersonalData
-	businessCard.CompanyData = processedCard.CompanyData
+	// Update with processed data, keeping the fields verified by a person
+	applyExtractedData(businessCard, processedCard)
+	b.postProcess(businessCard)
 	businessCard.ExtractedText = processedCard.ExtractedText
+	businessCard.ExtractionProvider = processedCard.ExtractionProvider
 	businessCard.ProcessedAt = time.Now()
-	businessCard.Status = models.StatusCompleted
+	businessCard.Status = b.extractedStatus(businessCard)
 
 	logger.LogInfo("ProcessBusinessCard", "Business card processed successfully", map[string]interface{}{
 		"business_card_id": businessCardID,
-		"status":           models.StatusCompleted,
+		"status":           businessCard.Status,
 		"personal_name":    processedCard.PersonalData.FullName,
 		"company_name":     processedCard.CompanyData.Name,
 	})
 
 	// Save final state
-	err = b.dynamoService.SaveBusinessCard(ctx, businessCard)
+	err = b.saveBusinessCard(ctx, businessCard)
 	if err != nil {
 		logger.LogError("ProcessBusinessCard", err, map[string]interface{}{
 			"step":             "save_final_state",
@@ -153,6 +356,8 @@ func (b *BusinessCardService) ProcessBusinessCard(ctx context.Context, images []
 		return nil, fmt.Errorf("failed to save processed business card: %w", err)
 	}
 
+	b.notifyListeners(ctx, businessCard, models.StatusProcessing)
+
 	logger.LogInfo("ProcessBusinessCard", "Business card processing completed successfully", map[string]interface{}{
 		"business_card_id": businessCardID,
 	})
@@ -166,7 +371,7 @@ func (b *BusinessCardService) RetryFailedProcessing(ctx context.Context, id stri
 	})
 
 	// Get the failed business card
-	businessCard, err := b.dynamoService.GetBusinessCard(ctx, id)
+	businessCard, err := b.repository.GetBusinessCard(ctx, id)
 	if err != nil {
 		logger.LogError("RetryFailedProcessing", err, map[string]interface{}{
 			"step":             "get_business_card",
@@ -175,13 +380,16 @@ func (b *BusinessCardService) RetryFailedProcessing(ctx context.Context, id stri
 		return nil, fmt.Errorf("failed to get business card: %w", err)
 	}
 
-	if businessCard.Status != models.StatusFailed {
+	// Dead-lettered cards are no longer retried automatically, but can still be retried by hand
+	if businessCard.Status != models.StatusFailed && businessCard.Status != models.StatusDeadLetter {
 		logger.LogWarn("RetryFailedProcessing", "Business card is not in failed state", map[string]interface{}{
 			"business_card_id": id,
 			"current_status":   businessCard.Status,
 		})
 		return nil, fmt.Errorf("business card is not in failed state")
 	}
+	previousStatus := businessCard.Status
+	previousRetryAt := businessCard.LastRetryAt
 
 	// Update status to retrying
 	businessCard.Status = models.StatusRetrying
@@ -195,7 +403,7 @@ func (b *BusinessCardService) RetryFailedProcessing(ctx context.Context, id stri
 	})
 
 	// Save retry state
-	err = b.dynamoService.SaveBusinessCard(ctx, businessCard)
+	err = b.saveBusinessCard(ctx, businessCard)
 	if err != nil {
 		logger.LogError("RetryFailedProcessing", err, map[string]interface{}{
 			"step":             "save_retry_state",
@@ -204,16 +412,28 @@ func (b *BusinessCardService) RetryFailedProcessing(ctx context.Context, id stri
 		return nil, fmt.Errorf("failed to update retry state: %w", err)
 	}
 
-	// Try to process with Gemini again
-	logger.LogInfo("RetryFailedProcessing", "Starting Gemini AI retry processing", map[string]interface{}{
+	b.notifyListeners(ctx, businessCard, previousStatus)
+
+	// Try to process with the extraction provider again
+	logger.LogInfo("RetryFailedProcessing", "Starting AI extraction retry", map[string]interface{}{
 		"business_card_id": id,
 		"retry_count":      businessCard.RetryCount,
+		"provider":         b.extractor.Name(),
 	})
 
-	processedCard, err := b.geminiService.ExtractBusinessCardData(ctx, businessCard.Images)
+	processedCard, err := b.extractBusinessCardData(ctx, id, businessCard.Images, false)
+	if errors.Is(err, errExtractionNotStarted) {
+		// A busy provider leaves the card as it was without using up a retry
+		businessCard.RetryCount--
+		businessCard.LastRetryAt = previousRetryAt
+		if restoreErr := b.restoreUnextractedCard(ctx, businessCard, previousStatus); restoreErr != nil {
+			return nil, restoreErr
+		}
+		return nil, err
+	}
 	if err != nil {
 		logger.LogError("RetryFailedProcessing", err, map[string]interface{}{
-			"step":             "gemini_retry_processing",
+			"step":             "extraction_retry",
 			"business_card_id": id,
 			"retry_count":      businessCard.RetryCount,
 		})
@@ -229,7 +449,7 @@ func (b *BusinessCardService) RetryFailedProcessing(ctx context.Context, id stri
 		})
 
 		// Save failed state
-		saveErr := b.dynamoService.SaveBusinessCard(ctx, businessCard)
+		saveErr := b.saveBusinessCard(ctx, businessCard)
 		if saveErr != nil {
 			logger.LogError("RetryFailedProcessing", saveErr, map[string]interface{}{
 				"step":             "save_retry_failed_state",
@@ -238,26 +458,30 @@ func (b *BusinessCardService) RetryFailedProcessing(ctx context.Context, id stri
 			return nil, fmt.Errorf("failed to save error state: %w", saveErr)
 		}
 
+		b.notifyListeners(ctx, businessCard, models.StatusRetrying)
+
 		return businessCard, fmt.Errorf("failed to process business card on retry: %w", err)
 	}
 
-	// Update with processed data
-	businessCard.PersonalData = processedCard.PersonalData
-	businessCard.CompanyData = processedCard.CompanyData
+	// Update with processed data, keeping the fields verified by a person
+	applyExtractedData(businessCard, processedCard)
+	b.postProcess(businessCard)
 	businessCard.ExtractedText = processedCard.ExtractedText
+	businessCard.ExtractionProvider = processedCard.ExtractionProvider
 	businessCard.ProcessedAt = time.Now()
-	businessCard.Status = models.StatusCompleted
+	businessCard.Status = b.extractedStatus(businessCard)
 	businessCard.Error = "" // Clear any previous error
 
 	logger.LogInfo("RetryFailedProcessing", "Retry processing completed successfully", map[string]interface{}{
 		"business_card_id": id,
 		"retry_count":      businessCard.RetryCount,
+		"status":           businessCard.Status,
 		"personal_name":    processedCard.PersonalData.FullName,
 		"company_name":     processedCard.CompanyData.Name,
 	})
 
 	// Save final state
-	err = b.dynamoService.SaveBusinessCard(ctx, businessCard)
+	err = b.saveBusinessCard(ctx, businessCard)
 	if err != nil {
 		logger.LogError("RetryFailedProcessing", err, map[string]interface{}{
 			"step":             "save_retry_final_state",
@@ -266,17 +490,126 @@ func (b *BusinessCardService) RetryFailedProcessing(ctx context.Context, id stri
 		return nil, fmt.Errorf("failed to save processed business card: %w", err)
 	}
 
+	b.notifyListeners(ctx, businessCard, models.StatusRetrying)
+
 	return businessCard, nil
 }
 
+// extractBusinessCardData runs the primary extractor and fails over to the fallback one when it
+// errors. The extraction slot is only held while the extractors run.
+func (b *BusinessCardService) extractBusinessCardData(ctx context.Context, businessCardID string, images []models.ImageData, waitForever bool) (*models.BusinessCard, error) {
+	images, err := b.loadImages(ctx, images)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := b.acquireExtractionSlot(ctx, waitForever)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errExtractionNotStarted, err)
+	}
+	defer release()
+
+	processedCard, err := b.extractor.ExtractBusinessCardData(ctx, images)
+	if err == nil {
+		processedCard.ExtractionProvider = b.extractor.Name()
+		normalizeBusinessCardData(processedCard)
+		return processedCard, nil
+	}
+
+	if b.fallbackExtractor == nil {
+		return nil, err
+	}
+
+	logger.LogWarn("extractBusinessCardData", "Primary extraction failed, using fallback provider", map[string]interface{}{
+		"business_card_id":  businessCardID,
+		"provider":          b.extractor.Name(),
+		"fallback_provider": b.fallbackExtractor.Name(),
+		"error":             err.Error(),
+	})
+
+	processedCard, fallbackErr := b.fallbackExtractor.ExtractBusinessCardData(ctx, images)
+	if fallbackErr != nil {
+		return nil, fmt.Errorf("%w (fallback %s also failed: %v)", err, b.fallbackExtractor.Name(), fallbackErr)
+	}
+
+	processedCard.ExtractionProvider = b.fallbackExtractor.Name()
+	normalizeBusinessCardData(processedCard)
+	return processedCard, nil
+}
+
+// restoreUnextractedCard puts a card whose extraction never started back to the status it had
+// before the attempt. The save outlives a cancelled request, so the card isn't left PROCESSING.
+func (b *BusinessCardService) restoreUnextractedCard(ctx context.Context, businessCard *models.BusinessCard, status string) error {
+	attemptStatus := businessCard.Status
+	businessCard.Status = status
+
+	if err := b.saveBusinessCard(context.WithoutCancel(ctx), businessCard); err != nil {
+		logger.LogError("restoreUnextractedCard", err, map[string]interface{}{
+			"business_card_id": businessCard.ID,
+			"status":           status,
+		})
+		return fmt.Errorf("failed to restore business card status: %w", err)
+	}
+
+	b.notifyListeners(ctx, businessCard, attemptStatus)
+	return nil
+}
+
+// MoveToDeadLetter gives up on a FAILED business card. The last error is kept and the card is
+// no longer picked up by the retry scheduler.
+func (b *BusinessCardService) MoveToDeadLetter(ctx context.Context, id string) (*models.BusinessCard, error) {
+	businessCard, err := b.repository.GetBusinessCard(ctx, id)
+	if err != nil {
+		logger.LogError("MoveToDeadLetter", err, map[string]interface{}{
+			"step":             "get_business_card",
+			"business_card_id": id,
+		})
+		return nil, fmt.Errorf("failed to get business card: %w", err)
+	}
+
+	if businessCard.Status != models.StatusFailed {
+		return nil, fmt.Errorf("business card is not in failed state")
+	}
+
+	businessCard.Status = models.StatusDeadLetter
+
+	logger.LogWarn("MoveToDeadLetter", "Moving business card to dead letter", map[string]interface{}{
+		"business_card_id": id,
+		"retry_count":      businessCard.RetryCount,
+		"error":            businessCard.Error,
+	})
+
+	if err := b.saveBusinessCard(ctx, businessCard); err != nil {
+		logger.LogError("MoveToDeadLetter", err, map[string]interface{}{
+			"step":             "save_dead_letter_state",
+			"business_card_id": id,
+		})
+		return nil, fmt.Errorf("failed to save dead letter state: %w", err)
+	}
+
+	b.notifyListeners(ctx, businessCard, models.StatusFailed)
+
+	return businessCard, nil
+}
+
+// GetBusinessCard returns a business card including its image bytes
 func (b *BusinessCardService) GetBusinessCard(ctx context.Context, id string) (*models.BusinessCard, error) {
 	logger.LogDebug("GetBusinessCard", "Retrieving business card", map[string]interface{}{
 		"business_card_id": id,
 	})
 
-	businessCard, err := b.dynamoService.GetBusinessCard(ctx, id)
+	businessCard, err := b.repository.GetBusinessCard(ctx, id)
+	if err != nil {
+		logger.LogError("GetBusinessCard", err, map[string]interface{}{
+			"business_card_id": id,
+		})
+		return nil, err
+	}
+
+	businessCard.Images, err = b.loadImages(ctx, businessCard.Images)
 	if err != nil {
 		logger.LogError("GetBusinessCard", err, map[string]interface{}{
+			"step":             "load_images",
 			"business_card_id": id,
 		})
 		return nil, err
@@ -285,10 +618,24 @@ func (b *BusinessCardService) GetBusinessCard(ctx context.Context, id string) (*
 	return businessCard, nil
 }
 
+// GetBusinessCardRecord returns the stored card without fetching its images from the image store,
+// for callers that only need its status or fields. Inline image bytes are cleared as well.
+func (b *BusinessCardService) GetBusinessCardRecord(ctx context.Context, id string) (*models.BusinessCard, error) {
+	businessCard, err := b.repository.GetBusinessCard(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range businessCard.Images {
+		businessCard.Images[i].Data = nil
+	}
+	return businessCard, nil
+}
+
 func (b *BusinessCardService) GetAllBusinessCards(ctx context.Context) ([]models.BusinessCard, error) {
 	logger.LogDebug("GetAllBusinessCards", "Retrieving all business cards", map[string]interface{}{})
 
-	businessCards, err := b.dynamoService.GetAllBusinessCards(ctx)
+	businessCards, err := b.repository.GetAllBusinessCards(ctx)
 	if err != nil {
 		logger.LogError("GetAllBusinessCards", err, map[string]interface{}{})
 		return nil, err
@@ -301,10 +648,40 @@ func (b *BusinessCardService) GetAllBusinessCards(ctx context.Context) ([]models
 	return businessCards, nil
 }
 
+// ListBusinessCards returns one page of business cards. Image bytes are not included.
+func (b *BusinessCardService) ListBusinessCards(ctx context.Context, query models.BusinessCardQuery) (*models.BusinessCardPage, error) {
+	query, err := normalizeBusinessCardQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.LogDebug("ListBusinessCards", "Listing business cards", map[string]interface{}{
+		"status":       query.Status,
+		"company_name": query.CompanyName,
+		"sort_by":      query.SortBy,
+		"order":        query.SortOrder,
+		"limit":        query.Limit,
+	})
+
+	page, err := b.repository.ListBusinessCards(ctx, query)
+	if err != nil {
+		logger.LogError("ListBusinessCards", err, map[string]interface{}{})
+		return nil, err
+	}
+
+	for i := range page.BusinessCards {
+		for j := range page.BusinessCards[i].Images {
+			page.BusinessCards[i].Images[j].Data = nil
+		}
+	}
+
+	return page, nil
+}
+
 func (b *BusinessCardService) GetFailedBusinessCards(ctx context.Context) ([]models.BusinessCard, error) {
 	logger.LogDebug("GetFailedBusinessCards", "Retrieving failed business cards", map[string]interface{}{})
 
-	businessCards, err := b.dynamoService.GetBusinessCardsByStatus(ctx, models.StatusFailed)
+	businessCards, err := b.repository.GetBusinessCardsByStatus(ctx, models.StatusFailed)
 	if err != nil {
 		logger.LogError("GetFailedBusinessCards", err, map[string]interface{}{})
 		return nil, err
@@ -317,10 +694,26 @@ func (b *BusinessCardService) GetFailedBusinessCards(ctx context.Context) ([]mod
 	return businessCards, nil
 }
 
+func (b *BusinessCardService) GetDeadLetterBusinessCards(ctx context.Context) ([]models.BusinessCard, error) {
+	logger.LogDebug("GetDeadLetterBusinessCards", "Retrieving dead-lettered business cards", map[string]interface{}{})
+
+	businessCards, err := b.repository.GetBusinessCardsByStatus(ctx, models.StatusDeadLetter)
+	if err != nil {
+		logger.LogError("GetDeadLetterBusinessCards", err, map[string]interface{}{})
+		return nil, err
+	}
+
+	logger.LogDebug("GetDeadLetterBusinessCards", "Retrieved dead-lettered business cards", map[string]interface{}{
+		"count": len(businessCards),
+	})
+
+	return businessCards, nil
+}
+
 func (b *BusinessCardService) InitializeDatabase(ctx context.Context) error {
 	logger.LogInfo("InitializeDatabase", "Initializing database", map[string]interface{}{})
 
-	err := b.dynamoService.CreateTableIfNotExists(ctx)
+	err := b.repository.CreateTableIfNotExists(ctx)
 	if err != nil {
 		logger.LogError("InitializeDatabase", err, map[string]interface{}{})
 		return err
@@ -329,3 +722,66 @@ func (b *BusinessCardService) InitializeDatabase(ctx context.Context) error {
 	logger.LogInfo("InitializeDatabase", "Database initialized successfully", map[string]interface{}{})
 	return nil
 }
+
+// saveBusinessCard persists the card, in the context's tenant when it has none yet. With an image
+// store configured the image bytes are left out of the record, which only keeps their object keys.
+func (b *BusinessCardService) saveBusinessCard(ctx context.Context, businessCard *models.BusinessCard) error {
+	return b.repository.SaveBusinessCard(ctx, b.businessCardRecord(ctx, businessCard))
+}
+
+// saveBusinessCardIf saves the business card only while the stored one matches the condition
+func (b *BusinessCardService) saveBusinessCardIf(ctx context.Context, businessCard *models.BusinessCard, condition BusinessCardCondition) error {
+	return b.repository.SaveBusinessCardIf(ctx, b.businessCardRecord(ctx, businessCard), condition)
+}
+
+// businessCardRecord returns the business card as stored: owned by a tenant and without the bytes
+// of images kept in the image store
+func (b *BusinessCardService) businessCardRecord(ctx context.Context, businessCard *models.BusinessCard) *models.BusinessCard {
+	if businessCard.TenantID == "" {
+		businessCard.TenantID = TenantFromContext(ctx)
+	}
+
+	if b.imageStore == nil {
+		return businessCard
+	}
+
+	record := *businessCard
+	record.Images = make([]models.ImageData, len(businessCard.Images))
+	for i, image := range businessCard.Images {
+		if image.ObjectKey != "" {
+			image.Data = nil
+		}
+		record.Images[i] = image
+	}
+
+	return &record
+}
+
+// loadImages returns a copy of the images with the bytes of stored ones downloaded and verified
+func (b *BusinessCardService) loadImages(ctx context.Context, images []models.ImageData) ([]models.ImageData, error) {
+	loaded := make([]models.ImageData, len(images))
+	for i, image := range images {
+		if len(image.Data) == 0 && image.ObjectKey != "" {
+			if b.imageStore == nil {
+				return nil, fmt.Errorf("image %s is in an image store but none is configured", image.ObjectKey)
+			}
+
+			data, err := b.imageStore.GetImage(ctx, image.ObjectKey)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load image %s: %w", image.ObjectKey, err)
+			}
+
+			if image.Checksum != "" {
+				checksum := sha256.Sum256(data)
+				if hex.EncodeToString(checksum[:]) != image.Checksum {
+					return nil, fmt.Errorf("checksum mismatch for image %s", image.ObjectKey)
+				}
+			}
+
+			image.Data = data
+		}
+		loaded[i] = image
+	}
+
+	return loaded, nil
+}
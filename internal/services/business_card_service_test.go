@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"business-card-reader/internal/models"
+)
+
+// fakeImageStore keeps images in memory and counts downloads
+type fakeImageStore struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	gets    int
+}
+
+func newFakeImageStore() *fakeImageStore {
+	return &fakeImageStore{objects: make(map[string][]byte)}
+}
+
+func (s *fakeImageStore) Name() string { return "fake" }
+
+func (s *fakeImageStore) PutImage(ctx context.Context, key string, contentType string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[key] = append([]byte(nil), data...)
+	return nil
+}
+
+func (s *fakeImageStore) GetImage(ctx context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gets++
+	data, ok := s.objects[key]
+	if !ok {
+		return nil, ErrImageNotFound
+	}
+	return data, nil
+}
+
+func (s *fakeImageStore) DeleteImage(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.objects, key)
+	return nil
+}
+
+// stubExtractor returns a copy of its card, or its error
+type stubExtractor struct {
+	card models.BusinessCard
+	err  error
+}
+
+func (e *stubExtractor) Name() string { return "stub" }
+
+func (e *stubExtractor) ExtractBusinessCardData(ctx context.Context, images []models.ImageData) (*models.BusinessCard, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	card := e.card
+	return &card, nil
+}
+
+var testUpload = []models.ImageUpload{{FileName: "front.jpg", ContentType: "image/jpeg", Data: []byte("jpeg bytes")}}
+
+func TestGetBusinessCardRecordSkipsImageStore(t *testing.T) {
+	ctx := context.Background()
+	imageStore := newFakeImageStore()
+	service := NewBusinessCardService(NewMemoryRepository(), &stubExtractor{})
+	service.SetImageStore(imageStore)
+
+	businessCard, err := service.ProcessBusinessCard(ctx, testUpload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := service.GetBusinessCardRecord(ctx, businessCard.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imageStore.gets != 0 {
+		t.Errorf("GetBusinessCardRecord downloaded %d images, want 0", imageStore.gets)
+	}
+	if record.Status != businessCard.Status || len(record.Images) != 1 || record.Images[0].ObjectKey == "" {
+		t.Errorf("record = %+v", record)
+	}
+
+	full, err := service.GetBusinessCard(ctx, businessCard.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imageStore.gets != 1 || string(full.Images[0].Data) != "jpeg bytes" {
+		t.Errorf("GetBusinessCard gets = %d, data = %q", imageStore.gets, full.Images[0].Data)
+	}
+}
@@ -0,0 +1,295 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"business-card-reader/internal/logger"
+	"business-card-reader/internal/models"
+)
+
+// ErrInvalidBusinessCardUpdate is returned when a correction fails validation
+var ErrInvalidBusinessCardUpdate = errors.New("invalid business card update")
+
+// ErrBusinessCardBusy is returned when a business card can't be edited because it is being processed
+var ErrBusinessCardBusy = errors.New("business card is being processed")
+
+// maxFieldLength bounds every editable text field
+const maxFieldLength = 500
+
+var phoneNumberPattern = regexp.MustCompile(`^\+?[0-9()./ -]+(\s*(x|ext\.?)\s*[0-9]+)?$`)
+
+// editableField binds the path of a correctable field to its value in the business card and in
+// the update request
+type editableField struct {
+	path     string
+	value    func(businessCard *models.BusinessCard) *string
+	update   func(request *models.BusinessCardUpdateRequest) *string
+	validate func(value string) error
+}
+
+var editableFields = []editableField{
+	{path: "personal_data.full_name",
+		value:  func(c *models.BusinessCard) *string { return &c.PersonalData.FullName },
+		update: func(r *models.BusinessCardUpdateRequest) *string { return r.PersonalData.FullName }},
+	{path: "personal_data.first_name",
+		value:  func(c *models.BusinessCard) *string { return &c.PersonalData.FirstName },
+		update: func(r *models.BusinessCardUpdateRequest) *string { return r.PersonalData.FirstName }},
+	{path: "personal_data.last_name",
+		value:  func(c *models.BusinessCard) *string { return &c.PersonalData.LastName },
+		update: func(r *models.BusinessCardUpdateRequest) *string { return r.PersonalData.LastName }},
+	{path: "personal_data.job_title",
+		value:  func(c *models.BusinessCard) *string { return &c.PersonalData.JobTitle },
+		update: func(r *models.BusinessCardUpdateRequest) *string { return r.PersonalData.JobTitle }},
+	{path: "personal_data.department",
+		value:  func(c *models.BusinessCard) *string { return &c.PersonalData.Department },
+		update: func(r *models.BusinessCardUpdateRequest) *string { return r.PersonalData.Department }},
+	{path: "personal_data.email",
+		value:    func(c *models.BusinessCard) *string { return &c.PersonalData.Email },
+		update:   func(r *models.BusinessCardUpdateRequest) *string { return r.PersonalData.Email },
+		validate: validateEmail},
+	{path: "personal_data.phone",
+		value:    func(c *models.BusinessCard) *string { return &c.PersonalData.Phone },
+		update:   func(r *models.BusinessCardUpdateRequest) *string { return r.PersonalData.Phone },
+		validate: validatePhone},
+	{path: "personal_data.mobile",
+		value:    func(c *models.BusinessCard) *string { return &c.PersonalData.Mobile },
+		update:   func(r *models.BusinessCardUpdateRequest) *string { return r.PersonalData.Mobile },
+		validate: validatePhone},
+	{path: "personal_data.linkedin",
+		value:    func(c *models.BusinessCard) *string { return &c.PersonalData.LinkedIn },
+		update:   func(r *models.BusinessCardUpdateRequest) *string { return r.PersonalData.LinkedIn },
+		validate: validateWebAddress},
+	{path: "personal_data.website",
+		value:    func(c *models.BusinessCard) *string { return &c.PersonalData.Website },
+		update:   func(r *models.BusinessCardUpdateRequest) *string { return r.PersonalData.Website },
+		validate: validateWebAddress},
+	{path: "company_data.name",
+		value:  func(c *models.BusinessCard) *string { return &c.CompanyData.Name },
+		update: func(r *models.BusinessCardUpdateRequest) *string { return r.CompanyData.Name }},
+	{path: "company_data.industry",
+		value:  func(c *models.BusinessCard) *string { return &c.CompanyData.Industry },
+		update: func(r *models.BusinessCardUpdateRequest) *string { return r.CompanyData.Industry }},
+	{path: "company_data.website",
+		value:    func(c *models.BusinessCard) *string { return &c.CompanyData.Website },
+		update:   func(r *models.BusinessCardUpdateRequest) *string { return r.CompanyData.Website },
+		validate: validateWebAddress},
+	{path: "company_data.email",
+		value:    func(c *models.BusinessCard) *string { return &c.CompanyData.Email },
+		update:   func(r *models.BusinessCardUpdateRequest) *string { return r.CompanyData.Email },
+		validate: validateEmail},
+	{path: "company_data.phone",
+		value:    func(c *models.BusinessCard) *string { return &c.CompanyData.Phone },
+		update:   func(r *models.BusinessCardUpdateRequest) *string { return r.CompanyData.Phone },
+		validate: validatePhone},
+	{path: "company_data.address.street",
+		value:  func(c *models.BusinessCard) *string { return &c.CompanyData.Address.Street },
+		update: func(r *models.BusinessCardUpdateRequest) *string { return r.CompanyData.Address.Street }},
+	{path: "company_data.address.city",
+		value:  func(c *models.BusinessCard) *string { return &c.CompanyData.Address.City },
+		update: func(r *models.BusinessCardUpdateRequest) *string { return r.CompanyData.Address.City }},
+	{path: "company_data.address.state",
+		value:  func(c *models.BusinessCard) *string { return &c.CompanyData.Address.State },
+		update: func(r *models.BusinessCardUpdateRequest) *string { return r.CompanyData.Address.State }},
+	{path: "company_data.address.postal_code",
+		value:  func(c *models.BusinessCard) *string { return &c.CompanyData.Address.PostalCode },
+		update: func(r *models.BusinessCardUpdateRequest) *string { return r.CompanyData.Address.PostalCode }},
+	{path: "company_data.address.country",
+		value:  func(c *models.BusinessCard) *string { return &c.CompanyData.Address.Country },
+		update: func(r *models.BusinessCardUpdateRequest) *string { return r.CompanyData.Address.Country }},
+	{path: "company_data.address.full",
+		value:  func(c *models.BusinessCard) *string { return &c.CompanyData.Address.Full },
+		update: func(r *models.BusinessCardUpdateRequest) *string { return r.CompanyData.Address.Full }},
+	{path: "company_data.social_media.linkedin",
+		value:    func(c *models.BusinessCard) *string { return &c.CompanyData.SocialMedia.LinkedIn },
+		update:   func(r *models.BusinessCardUpdateRequest) *string { return r.CompanyData.SocialMedia.LinkedIn },
+		validate: validateWebAddress},
+	{path: "company_data.social_media.twitter",
+		value:  func(c *models.BusinessCard) *string { return &c.CompanyData.SocialMedia.Twitter },
+		update: func(r *models.BusinessCardUpdateRequest) *string { return r.CompanyData.SocialMedia.Twitter }},
+	{path: "company_data.social_media.facebook",
+		value:  func(c *models.BusinessCard) *string { return &c.CompanyData.SocialMedia.Facebook },
+		update: func(r *models.BusinessCardUpdateRequest) *string { return r.CompanyData.SocialMedia.Facebook }},
+	{path: "company_data.social_media.instagram",
+		value:  func(c *models.BusinessCard) *string { return &c.CompanyData.SocialMedia.Instagram },
+		update: func(r *models.BusinessCardUpdateRequest) *string { return r.CompanyData.SocialMedia.Instagram }},
+}
+
+// UpdateBusinessCard applies manual corrections to the extracted data. Every field present in the
+// request is marked as verified, so later reprocessing keeps it, and loses its confidence score.
+// Each changed value is recorded in the change log with the given actor.
+func (b *BusinessCardService) UpdateBusinessCard(ctx context.Context, id string, request models.BusinessCardUpdateRequest, actor string) (*models.BusinessCard, error) {
+	logger.LogInfo("UpdateBusinessCard", "Updating business card", map[string]interface{}{
+		"business_card_id": id,
+		"actor":            actor,
+	})
+
+	businessCard, err := b.repository.GetBusinessCard(ctx, id)
+	if err != nil {
+		logger.LogError("UpdateBusinessCard", err, map[string]interface{}{
+			"step":             "get_business_card",
+			"business_card_id": id,
+		})
+		return nil, fmt.Errorf("failed to get business card: %w", err)
+	}
+
+	// An extraction in flight would overwrite the corrections when it saves its result
+	if err := checkNotProcessing(businessCard); err != nil {
+		return nil, err
+	}
+
+	changes, err := applyBusinessCardUpdate(businessCard, &request, actor, time.Now())
+	if err != nil {
+		logger.LogWarn("UpdateBusinessCard", "Rejected business card update", map[string]interface{}{
+			"business_card_id": id,
+			"error":            err.Error(),
+		})
+		return nil, err
+	}
+	b.postProcess(businessCard)
+
+	if err := b.saveBusinessCard(ctx, businessCard); err != nil {
+		logger.LogError("UpdateBusinessCard", err, map[string]interface{}{
+			"step":             "save_business_card",
+			"business_card_id": id,
+		})
+		return nil, fmt.Errorf("failed to save business card: %w", err)
+	}
+
+	logger.LogInfo("UpdateBusinessCard", "Business card updated successfully", map[string]interface{}{
+		"business_card_id": id,
+		"actor":            actor,
+		"changed_fields":   len(changes),
+	})
+
+	return businessCard, nil
+}
+
+// applyBusinessCardUpdate validates the whole request before changing anything and returns the
+// recorded changes
+func applyBusinessCardUpdate(businessCard *models.BusinessCard, request *models.BusinessCardUpdateRequest, actor string, now time.Time) ([]models.FieldChange, error) {
+	var fields []editableField
+	for _, field := range editableFields {
+		value := field.update(request)
+		if value == nil {
+			continue
+		}
+
+		*value = strings.TrimSpace(*value)
+		if err := validateField(field, *value); err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%w: no fields to update", ErrInvalidBusinessCardUpdate)
+	}
+
+	var changes []models.FieldChange
+	for _, field := range fields {
+		current := field.value(businessCard)
+		value := *field.update(request)
+
+		if *current != value {
+			changes = append(changes, models.FieldChange{
+				Field:     field.path,
+				OldValue:  *current,
+				NewValue:  value,
+				ChangedBy: actor,
+				ChangedAt: now,
+			})
+			*current = value
+		}
+
+		if !slices.Contains(businessCard.VerifiedFields, field.path) {
+			businessCard.VerifiedFields = append(businessCard.VerifiedFields, field.path)
+		}
+		delete(businessCard.FieldConfidence, field.path)
+	}
+
+	businessCard.ChangeLog = append(businessCard.ChangeLog, changes...)
+
+	return changes, nil
+}
+
+// applyExtractedData replaces the personal and company data and their confidence scores with a
+// new extraction result while keeping the human-verified fields
+func applyExtractedData(businessCard *models.BusinessCard, processedCard *models.BusinessCard) {
+	verified := make(map[string]string, len(businessCard.VerifiedFields))
+	for _, field := range editableFields {
+		if slices.Contains(businessCard.VerifiedFields, field.path) {
+			verified[field.path] = *field.value(businessCard)
+		}
+	}
+
+	businessCard.PersonalData = processedCard.PersonalData
+	businessCard.CompanyData = processedCard.CompanyData
+	businessCard.FieldConfidence = processedCard.FieldConfidence
+
+	for _, field := range editableFields {
+		if value, ok := verified[field.path]; ok {
+			*field.value(businessCard) = value
+			delete(businessCard.FieldConfidence, field.path)
+		}
+	}
+}
+
+// checkNotProcessing returns ErrBusinessCardBusy while an extraction of the card may be running
+func checkNotProcessing(businessCard *models.BusinessCard) error {
+	switch businessCard.Status {
+	case models.StatusPending, models.StatusProcessing, models.StatusRetrying:
+		return fmt.Errorf("%w: status is %s", ErrBusinessCardBusy, businessCard.Status)
+	}
+	return nil
+}
+
+func validateField(field editableField, value string) error {
+	if len(value) > maxFieldLength {
+		return fmt.Errorf("%w: %s must be at most %d characters", ErrInvalidBusinessCardUpdate, field.path, maxFieldLength)
+	}
+
+	// Empty values clear a field that was extracted by mistake
+	if value == "" || field.validate == nil {
+		return nil
+	}
+
+	if err := field.validate(value); err != nil {
+		return fmt.Errorf("%w: %s %v", ErrInvalidBusinessCardUpdate, field.path, err)
+	}
+	return nil
+}
+
+func validateEmail(value string) error {
+	address, err := mail.ParseAddress(value)
+	if err != nil || address.Address != value {
+		return fmt.Errorf("must be a valid email address")
+	}
+	return nil
+}
+
+func validatePhone(value string) error {
+	digits := 0
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			digits++
+		}
+	}
+	if !phoneNumberPattern.MatchString(value) || digits < 5 {
+		return fmt.Errorf("must be a valid phone number")
+	}
+	return nil
+}
+
+func validateWebAddress(value string) error {
+	if strings.ContainsAny(value, " \t\n") || !strings.Contains(value, ".") {
+		return fmt.Errorf("must be a valid web address")
+	}
+	return nil
+}
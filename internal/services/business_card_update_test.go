@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"business-card-reader/internal/models"
+)
+
+func TestApplyBusinessCardUpdateValidates(t *testing.T) {
+	value := func(s string) *string { return &s }
+
+	tests := []struct {
+		name    string
+		request models.BusinessCardUpdateRequest
+		wantErr bool
+	}{
+		{"valid email", models.BusinessCardUpdateRequest{PersonalData: models.PersonalDataUpdate{Email: value("jane@acme.com")}}, false},
+		{"email with display name", models.BusinessCardUpdateRequest{PersonalData: models.PersonalDataUpdate{Email: value("Jane <jane@acme.com>")}}, true},
+		{"invalid email", models.BusinessCardUpdateRequest{PersonalData: models.PersonalDataUpdate{Email: value("jane-at-acme")}}, true},
+		{"valid phone", models.BusinessCardUpdateRequest{PersonalData: models.PersonalDataUpdate{Phone: value("+49 (30) 123-4567")}}, false},
+		{"phone with letters", models.BusinessCardUpdateRequest{PersonalData: models.PersonalDataUpdate{Phone: value("call me")}}, true},
+		{"phone too short", models.BusinessCardUpdateRequest{CompanyData: models.CompanyDataUpdate{Phone: value("123")}}, true},
+		{"website with spaces", models.BusinessCardUpdateRequest{CompanyData: models.CompanyDataUpdate{Website: value("acme dot com")}}, true},
+		{"empty value clears", models.BusinessCardUpdateRequest{PersonalData: models.PersonalDataUpdate{Email: value("")}}, false},
+		{"too long", models.BusinessCardUpdateRequest{PersonalData: models.PersonalDataUpdate{JobTitle: value(strings.Repeat("x", maxFieldLength+1))}}, true},
+		{"no fields", models.BusinessCardUpdateRequest{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			businessCard := &models.BusinessCard{}
+			businessCard.PersonalData.FullName = "Jane Doe"
+
+			_, err := applyBusinessCardUpdate(businessCard, &tt.request, "alice", time.Now())
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidBusinessCardUpdate) {
+					t.Errorf("got %v, want ErrInvalidBusinessCardUpdate", err)
+				}
+				if len(businessCard.VerifiedFields) != 0 || len(businessCard.ChangeLog) != 0 {
+					t.Errorf("rejected update changed the card: %+v", businessCard)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("got %v, want the update applied", err)
+			}
+		})
+	}
+}
+
+func TestUpdateBusinessCardKeepsVerifiedFieldsOnReprocessing(t *testing.T) {
+	ctx := context.Background()
+	repository := NewMemoryRepository()
+	failed := models.BusinessCard{
+		ID:              "card",
+		Status:          models.StatusFailed,
+		CreatedAt:       time.Now(),
+		Images:          []models.ImageData{{FileName: "front.jpg", ContentType: "image/jpeg", Data: []byte("jpeg bytes")}},
+		FieldConfidence: map[string]float64{"personal_data.email": 0.4, "personal_data.full_name": 0.9},
+	}
+	failed.PersonalData.FullName = "Jane Doe"
+	failed.PersonalData.Email = "jane@acme.cm"
+	if err := repository.SaveBusinessCard(ctx, &failed); err != nil {
+		t.Fatal(err)
+	}
+
+	extracted := models.BusinessCard{FieldConfidence: map[string]float64{"personal_data.email": 0.5, "personal_data.full_name": 0.8}}
+	extracted.PersonalData.FullName = "Jane M. Doe"
+	extracted.PersonalData.Email = "jane@acme.cm"
+	extracted.CompanyData.Name = "Acme Corp"
+	service := NewBusinessCardService(repository, &stubExtractor{card: extracted})
+
+	email := "  jane@acme.com "
+	updated, err := service.UpdateBusinessCard(ctx, "card", models.BusinessCardUpdateRequest{
+		PersonalData: models.PersonalDataUpdate{Email: &email},
+	}, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.PersonalData.Email != "jane@acme.com" {
+		t.Errorf("email = %q, want the trimmed correction", updated.PersonalData.Email)
+	}
+	if !slices.Equal(updated.VerifiedFields, []string{"personal_data.email"}) {
+		t.Errorf("verified fields = %v", updated.VerifiedFields)
+	}
+	if _, ok := updated.FieldConfidence["personal_data.email"]; ok {
+		t.Error("verified field kept its confidence score")
+	}
+	if len(updated.ChangeLog) != 1 {
+		t.Fatalf("change log = %+v, want one entry", updated.ChangeLog)
+	}
+	if change := updated.ChangeLog[0]; change.Field != "personal_data.email" || change.OldValue != "jane@acme.cm" || change.NewValue != "jane@acme.com" || change.ChangedBy != "alice" {
+		t.Errorf("change = %+v", change)
+	}
+
+	t.Run("unchanged value isn't logged", func(t *testing.T) {
+		name := "Jane Doe"
+		updated, err := service.UpdateBusinessCard(ctx, "card", models.BusinessCardUpdateRequest{
+			PersonalData: models.PersonalDataUpdate{FullName: &name},
+		}, "alice")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(updated.ChangeLog) != 1 || !slices.Contains(updated.VerifiedFields, "personal_data.full_name") {
+			t.Errorf("change log %+v with verified fields %v", updated.ChangeLog, updated.VerifiedFields)
+		}
+	})
+
+	reprocessed, err := service.RetryFailedProcessing(ctx, "card")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reprocessed.PersonalData.Email != "jane@acme.com" || reprocessed.PersonalData.FullName != "Jane Doe" {
+		t.Errorf("reprocessing replaced verified fields: %+v", reprocessed.PersonalData)
+	}
+	if reprocessed.CompanyData.Name != "Acme Corp" {
+		t.Errorf("company name = %q, want the new extraction", reprocessed.CompanyData.Name)
+	}
+	for _, field := range reprocessed.VerifiedFields {
+		if _, ok := reprocessed.FieldConfidence[field]; ok {
+			t.Errorf("verified field %s got a confidence score", field)
+		}
+	}
+}
+
+func TestUpdateBusinessCardRefusesCardsInProcessing(t *testing.T) {
+	ctx := context.Background()
+	repository := NewMemoryRepository()
+	for _, status := range []string{models.StatusPending, models.StatusProcessing, models.StatusRetrying} {
+		t.Run(status, func(t *testing.T) {
+			businessCard := models.BusinessCard{ID: status, Status: status, CreatedAt: time.Now()}
+			if err := repository.SaveBusinessCard(ctx, &businessCard); err != nil {
+				t.Fatal(err)
+			}
+			service := NewBusinessCardService(repository, &stubExtractor{})
+
+			name := "Jane Doe"
+			_, err := service.UpdateBusinessCard(ctx, status, models.BusinessCardUpdateRequest{
+				PersonalData: models.PersonalDataUpdate{FullName: &name},
+			}, "alice")
+			if !errors.Is(err, ErrBusinessCardBusy) {
+				t.Errorf("got %v, want ErrBusinessCardBusy", err)
+			}
+		})
+	}
+}
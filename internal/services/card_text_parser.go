@@ -0,0 +1,169 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+
+	"business-card-reader/internal/models"
+)
+
+var (
+	emailPattern        = regexp.MustCompile(`(?i)[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,}`)
+	linkedInURLPattern  = regexp.MustCompile(`(?i)(?:https?://)?(?:[a-z]{2,3}\.)?linkedin\.com/(in|company|pub)/([a-z0-9_\-%.]+)/?`)
+	linkedInHandleLabel = regexp.MustCompile(`(?i)\blinked\s?in\b\s*[:/|]?\s*@?([a-z0-9_\-.]{3,})`)
+	urlPattern          = regexp.MustCompile(`(?i)\b(?:https?://[^\s,;]+|www\.[^\s,;]+|[a-z0-9][a-z0-9\-]*(?:\.[a-z0-9\-]+)*\.(?:com|net|org|io|co|dev|biz|info|app|ai|tech|us|uk|de|fr|br|es|it|nl|ca|au|in)(?:\.[a-z]{2})?(?:/[^\s,;]*)?)`)
+	phonePattern        = regexp.MustCompile(`\+?\(?\d[\d\s().\-/]{5,}\d`)
+	postalCodePatterns  = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)\b(?:zip|postal code|cep|plz)\s*:?\s*(\d{4,6})\b`), // Labelled codes
+		regexp.MustCompile(`\b\d{5}-\d{3}\b`),                                      // Brazil CEP
+		regexp.MustCompile(`\b\d{5}(?:-\d{4})?\b`),                                 // US ZIP, DE, FR, ES, IT
+		regexp.MustCompile(`\b[A-Z]\d[A-Z] ?\d[A-Z]\d\b`),                          // Canada
+		regexp.MustCompile(`\b[A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2}\b`),                 // United Kingdom
+		regexp.MustCompile(`\b\d{4} ?[A-Z]{2}\b`),                                  // Netherlands
+		regexp.MustCompile(`\b\d{3}-\d{4}\b`),                                      // Japan
+	}
+
+	mobileLabels   = []string{"mob", "cell", "m:", "celular", "handy", "gsm"}
+	faxLabels      = []string{"fax", "f:"}
+	genericMailbox = []string{"info", "contact", "hello", "sales", "office", "support", "admin", "team", "contato"}
+)
+
+// minPhoneDigits filters out dates, postal codes and similar short digit runs
+const minPhoneDigits = 7
+
+// parseBusinessCardText pulls well-structured fields out of raw OCR text with
+// deterministic rules. It never guesses names or titles, only values that can
+// be recognized by their shape.
+func parseBusinessCardText(text string) (models.PersonalData, models.CompanyData) {
+	var personal models.PersonalData
+	var company models.CompanyData
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		lowerLine := strings.ToLower(line)
+		remaining := line
+
+		// Emails first, so their domains are not picked up as websites
+		for _, email := range emailPattern.FindAllString(remaining, -1) {
+			email = strings.ToLower(email)
+			if isGenericMailbox(email) {
+				setIfEmpty(&company.Email, email)
+			} else if personal.Email == "" {
+				personal.Email = email
+			} else {
+				setIfEmpty(&company.Email, email)
+			}
+		}
+		remaining = emailPattern.ReplaceAllString(remaining, " ")
+
+		// LinkedIn profile and company pages
+		for _, match := range linkedInURLPattern.FindAllStringSubmatch(remaining, -1) {
+			profile := "linkedin.com/" + strings.ToLower(match[1]) + "/" + match[2]
+			if strings.EqualFold(match[1], "company") {
+				setIfEmpty(&company.SocialMedia.LinkedIn, profile)
+			} else {
+				setIfEmpty(&personal.LinkedIn, profile)
+			}
+		}
+		remaining = linkedInURLPattern.ReplaceAllString(remaining, " ")
+
+		if match := linkedInHandleLabel.FindStringSubmatch(remaining); match != nil {
+			setIfEmpty(&personal.LinkedIn, "linkedin.com/in/"+match[1])
+			remaining = linkedInHandleLabel.ReplaceAllString(remaining, " ")
+		}
+
+		for _, website := range urlPattern.FindAllString(remaining, -1) {
+			website = strings.TrimRight(website, "./")
+			if company.Website == "" {
+				company.Website = website
+			} else {
+				setIfEmpty(&personal.Website, website)
+			}
+		}
+		remaining = urlPattern.ReplaceAllString(remaining, " ")
+
+		// Phone numbers, routed by the label printed next to them
+		phones := phonePattern.FindAllString(remaining, -1)
+		for _, phone := range phones {
+			phone = strings.TrimSpace(phone)
+			if countDigits(phone) < minPhoneDigits || findPostalCode(phone) == phone {
+				continue
+			}
+			switch {
+			case containsAny(lowerLine, faxLabels):
+				continue
+			case containsAny(lowerLine, mobileLabels):
+				setIfEmpty(&personal.Mobile, phone)
+			case personal.Phone == "":
+				personal.Phone = phone
+			case personal.Mobile == "":
+				personal.Mobile = phone
+			default:
+				setIfEmpty(&company.Phone, phone)
+			}
+			remaining = strings.Replace(remaining, phone, " ", 1)
+		}
+
+		// Postal codes, on whatever is left of the line
+		if company.Address.PostalCode == "" {
+			if postalCode := findPostalCode(remaining); postalCode != "" {
+				company.Address.PostalCode = postalCode
+				company.Address.Full = line
+			}
+		}
+	}
+
+	return personal, company
+}
+
+func findPostalCode(line string) string {
+	for _, pattern := range postalCodePatterns {
+		match := pattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		if len(match) > 1 {
+			return match[1]
+		}
+		return match[0]
+	}
+	return ""
+}
+
+func isGenericMailbox(email string) bool {
+	local := strings.SplitN(email, "@", 2)[0]
+	for _, mailbox := range genericMailbox {
+		if local == mailbox {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(value string, needles []string) bool {
+	for _, needle := range needles {
+		if strings.Contains(value, needle) {
+			return true
+		}
+	}
+	return false
+}
+
+func countDigits(value string) int {
+	count := 0
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			count++
+		}
+	}
+	return count
+}
+
+func setIfEmpty(field *string, value string) {
+	if *field == "" {
+		*field = value
+	}
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+
+	"business-card-reader/internal/models"
+)
+
+// DuplicateScoreThreshold is the lowest score at which a business card is reported as a duplicate
+const DuplicateScoreThreshold = 0.5
+
+// Scores of the individual matches. They are combined as independent evidence, so several weak
+// matches add up to a strong one.
+const (
+	emailMatchScore          = 0.95
+	mobileMatchScore         = 0.8
+	phoneMatchScore          = 0.4
+	nameAndCompanyMatchScore = 0.7
+	minNameSimilarity        = 0.85
+)
+
+// companyLegalForms are dropped from company names before comparing them
+var companyLegalForms = []string{
+	"ag", "bv", "co", "company", "corp", "corporation", "gmbh", "inc", "incorporated", "limited",
+	"llc", "ltd", "plc", "sa", "sarl", "srl",
+}
+
+// matchDuplicate compares two business cards and returns how likely they describe the same
+// contact, between 0 and 1, together with the reasons
+func matchDuplicate(a *models.BusinessCard, b *models.BusinessCard) (float64, []string) {
+	var scores []float64
+	var reasons []string
+
+	if email := emailKey(a.PersonalData.Email); email != "" && email == emailKey(b.PersonalData.Email) {
+		scores = append(scores, emailMatchScore)
+		reasons = append(reasons, models.DuplicateReasonEmail)
+	}
+
+	if score := phoneMatch(a, b); score > 0 {
+		scores = append(scores, score)
+		reasons = append(reasons, models.DuplicateReasonPhone)
+	}
+
+	nameA, nameB := personNameKey(a.PersonalData.FullName), personNameKey(b.PersonalData.FullName)
+	companyA, companyB := companyKey(a.CompanyData.Name), companyKey(b.CompanyData.Name)
+	if nameA != "" && nameB != "" && companyA != "" && companyB != "" {
+		nameSimilarity := similarity(nameA, nameB)
+		companySimilarity := similarity(companyA, companyB)
+		if nameSimilarity >= minNameSimilarity && companySimilarity >= minNameSimilarity {
+			scores = append(scores, nameAndCompanyMatchScore*nameSimilarity*companySimilarity)
+			reasons = append(reasons, models.DuplicateReasonNameAndCompany)
+		}
+	}
+
+	unlikely := 1.0
+	for _, score := range scores {
+		unlikely *= 1 - score
+	}
+	return math.Round((1-unlikely)*100) / 100, reasons
+}
+
+// phoneMatch scores the best match between the phone numbers of two cards. Mobile numbers are
+// personal; office numbers are often shared by colleagues and count less.
+func phoneMatch(a *models.BusinessCard, b *models.BusinessCard) float64 {
+	mobileA := comparablePhoneOf(a, "personal_data.mobile", a.PersonalData.Mobile)
+	mobileB := comparablePhoneOf(b, "personal_data.mobile", b.PersonalData.Mobile)
+	if samePhoneNumber(mobileA, mobileB) {
+		return mobileMatchScore
+	}
+
+	phoneA := comparablePhoneOf(a, "personal_data.phone", a.PersonalData.Phone)
+	phoneB := comparablePhoneOf(b, "personal_data.phone", b.PersonalData.Phone)
+	for _, numberA := range []comparablePhone{mobileA, phoneA} {
+		for _, numberB := range []comparablePhone{mobileB, phoneB} {
+			if samePhoneNumber(numberA, numberB) {
+				return phoneMatchScore
+			}
+		}
+	}
+	return 0
+}
+
+// comparablePhone is a phone field as written, with its E.164 form when the phone
+// post-processor could normalize it
+type comparablePhone struct {
+	value string
+	e164  string
+}
+
+func comparablePhoneOf(businessCard *models.BusinessCard, path string, value string) comparablePhone {
+	phone := comparablePhone{value: value}
+	// The E.164 form only applies while the field still holds the number it was parsed from
+	for _, phoneNumber := range businessCard.PhoneNumbers {
+		if phoneNumber.Field == path && phoneNumber.Original == value {
+			phone.e164 = phoneNumber.E164
+		}
+	}
+	return phone
+}
+
+// samePhoneNumber compares the E.164 forms of two phone numbers when both have one, which keeps
+// numbers of different countries sharing their last digits apart. Otherwise it falls back to the
+// digits without leading zeros, so that a number written with country code matches the national
+// form: +49 30 555000 and 030 555000
+func samePhoneNumber(a comparablePhone, b comparablePhone) bool {
+	if a.e164 != "" && b.e164 != "" {
+		return a.e164 == b.e164
+	}
+
+	digitsA, digitsB := phoneDigits(a.value), phoneDigits(b.value)
+	if len(digitsA) < 7 || len(digitsB) < 7 {
+		return false
+	}
+	return strings.HasSuffix(digitsA, digitsB) || strings.HasSuffix(digitsB, digitsA)
+}
+
+func phoneDigits(phone string) string {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+	return strings.TrimLeft(digits, "0")
+}
+
+// emailKey lower-cases an email address and drops "+tag" sub-addressing
+func emailKey(email string) string {
+	local, domain, found := strings.Cut(strings.ToLower(strings.TrimSpace(email)), "@")
+	if !found || local == "" || domain == "" {
+		return ""
+	}
+	local, _, _ = strings.Cut(local, "+")
+	return local + "@" + domain
+}
+
+// personNameKey lower-cases a name, drops punctuation and sorts its words, so "Doe, John" and
+// "John Doe" match
+func personNameKey(name string) string {
+	words := nameWords(name)
+	slices.Sort(words)
+	return strings.Join(words, " ")
+}
+
+// companyKey lower-cases a company name and drops punctuation and legal forms such as Inc or GmbH
+func companyKey(name string) string {
+	words := slices.DeleteFunc(nameWords(name), func(word string) bool {
+		return slices.Contains(companyLegalForms, word)
+	})
+	return strings.Join(words, " ")
+}
+
+func nameWords(name string) []string {
+	return strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// similarity is 1 minus the Levenshtein distance of two strings relative to the longer one
+func similarity(a string, b string) float64 {
+	if a == b {
+		return 1
+	}
+
+	runesA, runesB := []rune(a), []rune(b)
+	previous := make([]int, len(runesB)+1)
+	current := make([]int, len(runesB)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(runesA); i++ {
+		current[0] = i
+		for j := 1; j <= len(runesB); j++ {
+			cost := 1
+			if runesA[i-1] == runesB[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return 1 - float64(previous[len(runesB)])/float64(max(len(runesA), len(runesB)))
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"business-card-reader/internal/models"
+)
+
+func TestSamePhoneNumber(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b comparablePhone
+		want bool
+	}{
+		{
+			name: "same E.164 written differently",
+			a:    comparablePhone{value: "+49 (30) 555-000", e164: "+4930555000"},
+			b:    comparablePhone{value: "030 555000", e164: "+4930555000"},
+			want: true,
+		},
+		{
+			name: "national numbers of different countries",
+			a:    comparablePhone{value: "030 555000", e164: "+4930555000"},
+			b:    comparablePhone{value: "030 555000", e164: "+4330555000"},
+			want: false,
+		},
+		{
+			name: "suffix fallback without E.164",
+			a:    comparablePhone{value: "+49 30 555000", e164: "+4930555000"},
+			b:    comparablePhone{value: "030 555000"},
+			want: true,
+		},
+		{
+			name: "different numbers",
+			a:    comparablePhone{value: "030 555000"},
+			b:    comparablePhone{value: "030 555001"},
+			want: false,
+		},
+		{
+			name: "too short for the suffix match",
+			a:    comparablePhone{value: "555000"},
+			b:    comparablePhone{value: "555000"},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := samePhoneNumber(tt.a, tt.b); got != tt.want {
+				t.Errorf("samePhoneNumber(%+v, %+v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComparablePhoneIgnoresStaleE164(t *testing.T) {
+	businessCard := &models.BusinessCard{
+		PhoneNumbers: []models.PhoneNumber{{Field: "personal_data.mobile", Original: "0171 1234567", E164: "+491711234567"}},
+	}
+
+	if phone := comparablePhoneOf(businessCard, "personal_data.mobile", "0171 1234567"); phone.e164 != "+491711234567" {
+		t.Errorf("current number has E.164 %q", phone.e164)
+	}
+	if phone := comparablePhoneOf(businessCard, "personal_data.mobile", "0172 7654321"); phone.e164 != "" {
+		t.Errorf("edited number keeps stale E.164 %q", phone.e164)
+	}
+}
+
+// scanlessRepository fails GetAllBusinessCards, so tests notice when duplicate search loads every
+// card at once instead of walking the listing pages
+type scanlessRepository struct {
+	BusinessCardRepository
+	t *testing.T
+}
+
+func (r scanlessRepository) GetAllBusinessCards(ctx context.Context) ([]models.BusinessCard, error) {
+	r.t.Helper()
+	r.t.Error("duplicate search scanned every business card")
+	return r.BusinessCardRepository.GetAllBusinessCards(ctx)
+}
+
+func TestFindDuplicates(t *testing.T) {
+	for backend, newRepository := range repositoryBackends {
+		t.Run(backend, func(t *testing.T) {
+			ctx := context.Background()
+			repository := newRepository(t)
+			if err := repository.CreateTableIfNotExists(ctx); err != nil {
+				t.Fatal(err)
+			}
+
+			created := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+			newCard := func(id string, name string, email string, company string, mobile string) models.BusinessCard {
+				businessCard := models.BusinessCard{ID: id, Status: models.StatusCompleted, CreatedAt: created}
+				businessCard.PersonalData.FullName = name
+				businessCard.PersonalData.Email = email
+				businessCard.PersonalData.Mobile = mobile
+				businessCard.CompanyData.Name = company
+				created = created.Add(time.Minute)
+				return businessCard
+			}
+
+			businessCards := []models.BusinessCard{
+				newCard("jane", "Jane Doe", "jane@acme.com", "Acme", "0171 1234567"),
+				newCard("same-email", "J. Doe", "Jane+events@ACME.com", "Acme Europe", ""),
+				newCard("same-company", "Jane Do", "", "ACME", ""),
+				newCard("colleague", "John Roe", "john@acme.com", "Acme", ""),
+				// Shares only the name and the mobile number with jane
+				newCard("moved-on", "Jane Doe", "jane@globex.com", "Globex", "0171 1234567"),
+				newCard("phone-only", "", "", "", "0171 1234567"),
+			}
+			for i := range businessCards {
+				if err := repository.SaveBusinessCard(ctx, &businessCards[i]); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			t.Run("across the tenant's cards", func(t *testing.T) {
+				service := NewBusinessCardService(scanlessRepository{BusinessCardRepository: repository, t: t}, &stubExtractor{})
+
+				candidates, err := service.FindDuplicates(ctx, "jane")
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var ids []string
+				for _, candidate := range candidates {
+					ids = append(ids, candidate.BusinessCard.ID)
+				}
+				if want := []string{"same-email", "moved-on", "phone-only", "same-company"}; !slices.Equal(ids, want) {
+					t.Errorf("duplicates of jane = %v, want %v", ids, want)
+				}
+			})
+
+			t.Run("by phone without email and company", func(t *testing.T) {
+				service := NewBusinessCardService(repository, &stubExtractor{})
+
+				candidates, err := service.FindDuplicates(ctx, "phone-only")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(candidates) != 2 {
+					t.Errorf("got %d duplicates of phone-only, want jane and moved-on", len(candidates))
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	"business-card-reader/internal/models"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func (d *DynamoService) apiKeysTable() string {
+	return d.tableName + "-api-keys"
+}
+
+func (d *DynamoService) SaveAPIKey(ctx context.Context, apiKey *models.APIKey) error {
+	item, err := attributevalue.MarshalMap(apiKey)
+	if err != nil {
+		return fmt.Errorf("failed to marshal API key: %w", err)
+	}
+
+	_, err = d.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(d.apiKeysTable()),
+		Item:      item,
+	})
+	if err != nil {
+		log.Printf("[DynamoService] Failed to save API key to DynamoDB: %v", err)
+		return fmt.Errorf("failed to save API key: %w", err)
+	}
+
+	return nil
+}
+
+func (d *DynamoService) GetAPIKey(ctx context.Context, id string) (*models.APIKey, error) {
+	result, err := d.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(d.apiKeysTable()),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: id},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	if result.Item == nil {
+		return nil, ErrAPIKeyNotFound
+	}
+
+	var apiKey models.APIKey
+	if err := attributevalue.UnmarshalMap(result.Item, &apiKey); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal API key: %w", err)
+	}
+
+	return &apiKey, nil
+}
+
+func (d *DynamoService) GetAllAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	items, err := d.scanAll(ctx, &dynamodb.ScanInput{
+		TableName: aws.String(d.apiKeysTable()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan API keys: %w", err)
+	}
+
+	var apiKeys []models.APIKey
+	for _, item := range items {
+		var apiKey models.APIKey
+		if err := attributevalue.UnmarshalMap(item, &apiKey); err != nil {
+			continue // Skip items that can't be unmarshaled
+		}
+		apiKeys = append(apiKeys, apiKey)
+	}
+
+	sort.Slice(apiKeys, func(i, j int) bool {
+		return apiKeys[i].CreatedAt.Before(apiKeys[j].CreatedAt)
+	})
+
+	return apiKeys, nil
+}
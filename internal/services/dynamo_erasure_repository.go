@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	"business-card-reader/internal/models"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func (d *DynamoService) erasureReceiptsTable() string {
+	return d.tableName + "-erasure-receipts"
+}
+
+// SaveErasureReceipt stores the receipt without its email, which the dynamodbav tag leaves out
+func (d *DynamoService) SaveErasureReceipt(ctx context.Context, receipt *models.ErasureReceipt) error {
+	item, err := attributevalue.MarshalMap(receipt)
+	if err != nil {
+		return fmt.Errorf("failed to marshal erasure receipt: %w", err)
+	}
+
+	_, err = d.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(d.erasureReceiptsTable()),
+		Item:      item,
+	})
+	if err != nil {
+		log.Printf("[DynamoService] Failed to save erasure receipt to DynamoDB: %v", err)
+		return fmt.Errorf("failed to save erasure receipt: %w", err)
+	}
+
+	return nil
+}
+
+func (d *DynamoService) GetErasureReceipt(ctx context.Context, id string) (*models.ErasureReceipt, error) {
+	result, err := d.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(d.erasureReceiptsTable()),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: id},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get erasure receipt: %w", err)
+	}
+
+	if result.Item == nil {
+		return nil, ErrErasureReceiptNotFound
+	}
+
+	var receipt models.ErasureReceipt
+	if err := attributevalue.UnmarshalMap(result.Item, &receipt); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal erasure receipt: %w", err)
+	}
+
+	return &receipt, nil
+}
+
+func (d *DynamoService) GetAllErasureReceipts(ctx context.Context) ([]models.ErasureReceipt, error) {
+	items, err := d.scanAll(ctx, &dynamodb.ScanInput{
+		TableName: aws.String(d.erasureReceiptsTable()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan erasure receipts: %w", err)
+	}
+
+	var receipts []models.ErasureReceipt
+	for _, item := range items {
+		var receipt models.ErasureReceipt
+		if err := attributevalue.UnmarshalMap(item, &receipt); err != nil {
+			continue // Skip items that can't be unmarshaled
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	sort.Slice(receipts, func(i, j int) bool {
+		return receipts[i].RequestedAt.Before(receipts[j].RequestedAt)
+	})
+
+	return receipts, nil
+}
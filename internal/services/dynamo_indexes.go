@@ -0,0 +1,508 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"business-card-reader/internal/models"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Global secondary indexes of the business card table
+const (
+	dynamoStatusIndex      = "status_key-created_at-index"
+	dynamoCompanyNameIndex = "company_name-created_at-index"
+	dynamoEmailIndex       = "email_key-created_at-index"
+	dynamoListIndex        = "list-created_at-index"
+	// dynamoProcessedListIndex orders each tenant's business cards by processed_at
+	dynamoProcessedListIndex = "list-processed_at-index"
+	// dynamoAllTenantsStatusIndex is keyed on the plain status, for the background jobs that poll
+	// the PENDING and FAILED cards of every tenant
+	dynamoAllTenantsStatusIndex = "status-created_at-index"
+)
+
+// dynamoListPartition is the partition key value of the list index, prefixed with the tenant,
+// which orders each tenant's business cards by created_at
+const dynamoListPartition = "BUSINESS_CARD"
+
+// dynamoIndexPollInterval is how often a migration checks whether a new index is active
+const dynamoIndexPollInterval = 10 * time.Second
+
+// Migrator is implemented by repositories whose existing storage needs an explicit,
+// potentially slow upgrade step
+type Migrator interface {
+	Migrate(ctx context.Context) error
+}
+
+type dynamoIndex struct {
+	name         string
+	partitionKey string
+	// sortKey is created_at_key unless set
+	sortKey string
+}
+
+var businessCardIndexes = []dynamoIndex{
+	{name: dynamoStatusIndex, partitionKey: "status_key"},
+	{name: dynamoAllTenantsStatusIndex, partitionKey: "status"},
+	{name: dynamoCompanyNameIndex, partitionKey: "company_name_key"},
+	{name: dynamoEmailIndex, partitionKey: "email_key"},
+	{name: dynamoListIndex, partitionKey: "list_pk"},
+	{name: dynamoProcessedListIndex, partitionKey: "list_pk", sortKey: "processed_at_key"},
+}
+
+func (i dynamoIndex) sortKeyName() string {
+	if i.sortKey == "" {
+		return "created_at_key"
+	}
+	return i.sortKey
+}
+
+func (i dynamoIndex) definition() types.GlobalSecondaryIndex {
+	return types.GlobalSecondaryIndex{
+		IndexName: aws.String(i.name),
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String(i.partitionKey), KeyType: types.KeyTypeHash},
+			{AttributeName: aws.String(i.sortKeyName()), KeyType: types.KeyTypeRange},
+		},
+		Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+	}
+}
+
+// indexAttributeDefinitions declares the table key and every index key
+func indexAttributeDefinitions(indexes []dynamoIndex) []types.AttributeDefinition {
+	names := []string{"id"}
+	for _, index := range indexes {
+		for _, name := range []string{index.partitionKey, index.sortKeyName()} {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+
+	definitions := make([]types.AttributeDefinition, len(names))
+	for i, name := range names {
+		definitions[i] = types.AttributeDefinition{
+			AttributeName: aws.String(name),
+			AttributeType: types.ScalarAttributeTypeS,
+		}
+	}
+	return definitions
+}
+
+func indexDefinitions(indexes []dynamoIndex) []types.GlobalSecondaryIndex {
+	definitions := make([]types.GlobalSecondaryIndex, len(indexes))
+	for i, index := range indexes {
+		definitions[i] = index.definition()
+	}
+	return definitions
+}
+
+// indexesActive reports whether every index of the table exists and is active, so that queries
+// can fall back to scans on tables that haven't been migrated yet
+func (d *DynamoService) indexesActive(ctx context.Context, tableName string, indexes []dynamoIndex) (bool, error) {
+	active, err := d.activeIndexes(ctx, tableName)
+	if err != nil {
+		return false, err
+	}
+
+	for _, index := range indexes {
+		if !active[index.name] {
+			log.Printf("[DynamoService] Table %s is missing secondary indexes, falling back to scans. Run with -migrate to create them", tableName)
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// ErrMigrationRequired is returned at startup when the DynamoDB table holds items the running
+// version can't read before it is migrated
+var ErrMigrationRequired = errors.New("storage must be migrated, run with -migrate")
+
+// checkLegacyItems fails with ErrMigrationRequired when the table holds business cards stored
+// before tenants existed. Their keys have no tenant prefix, so lookups and listings would skip
+// them until Migrate moves them to the default tenant.
+func (d *DynamoService) checkLegacyItems(ctx context.Context) error {
+	active, err := d.activeIndexes(ctx, d.tableName)
+	if err != nil {
+		return err
+	}
+
+	var legacyItems int32
+	if active[dynamoListIndex] {
+		// Before tenants the list index partition had no tenant prefix
+		result, err := d.client.Query(ctx, &dynamodb.QueryInput{
+			TableName:              aws.String(d.tableName),
+			IndexName:              aws.String(dynamoListIndex),
+			KeyConditionExpression: aws.String("list_pk = :partition"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":partition": &types.AttributeValueMemberS{Value: dynamoListPartition},
+			},
+			Limit: aws.Int32(1),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to check for items stored before tenants: %w", err)
+		}
+		legacyItems = result.Count
+	} else {
+		// A table without the list index was never migrated, so any item in it may predate tenants
+		result, err := d.client.Scan(ctx, &dynamodb.ScanInput{
+			TableName: aws.String(d.tableName),
+			Limit:     aws.Int32(1),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to check for items stored before tenants: %w", err)
+		}
+		legacyItems = result.Count
+	}
+
+	if legacyItems > 0 {
+		return fmt.Errorf("%w: table %s holds business cards stored before tenants existed, which stay invisible until they are moved to the default tenant", ErrMigrationRequired, d.tableName)
+	}
+	return nil
+}
+
+// activeIndexes returns the status of the table's global secondary indexes, keyed by name
+func (d *DynamoService) activeIndexes(ctx context.Context, tableName string) (map[string]bool, error) {
+	result, err := d.client.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+		TableName: aws.String(tableName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe table: %w", err)
+	}
+
+	active := make(map[string]bool)
+	for _, index := range result.Table.GlobalSecondaryIndexes {
+		active[aws.ToString(index.IndexName)] = index.IndexStatus == types.IndexStatusActive
+	}
+	return active, nil
+}
+
+// Migrate upgrades existing tables: it backfills the index attributes on items written by
+// older versions and creates the missing secondary indexes one at a time, waiting for each to
+// become active
+func (d *DynamoService) Migrate(ctx context.Context) error {
+	if _, err := d.createTables(ctx); err != nil {
+		return err
+	}
+
+	if err := d.backfillQueryAttributes(ctx); err != nil {
+		return err
+	}
+	if err := d.createIndexes(ctx, d.tableName, businessCardIndexes); err != nil {
+		return err
+	}
+	d.indexesReady = true
+
+	if err := d.migrateWebhookTables(ctx); err != nil {
+		return err
+	}
+
+	log.Printf("[DynamoService] Migration of table %s completed", d.tableName)
+
+	return nil
+}
+
+// createIndexes creates the table's missing indexes one at a time and waits for each to become active
+func (d *DynamoService) createIndexes(ctx context.Context, tableName string, indexes []dynamoIndex) error {
+	for _, index := range indexes {
+		active, err := d.activeIndexes(ctx, tableName)
+		if err != nil {
+			return err
+		}
+
+		if _, exists := active[index.name]; !exists {
+			log.Printf("[DynamoService] Creating index %s on table %s", index.name, tableName)
+
+			definition := index.definition()
+			_, err := d.client.UpdateTable(ctx, &dynamodb.UpdateTableInput{
+				TableName:            aws.String(tableName),
+				AttributeDefinitions: indexAttributeDefinitions(indexes),
+				GlobalSecondaryIndexUpdates: []types.GlobalSecondaryIndexUpdate{
+					{
+						Create: &types.CreateGlobalSecondaryIndexAction{
+							IndexName:  definition.IndexName,
+							KeySchema:  definition.KeySchema,
+							Projection: definition.Projection,
+						},
+					},
+				},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create index %s: %w", index.name, err)
+			}
+		}
+
+		if err := d.waitForIndex(ctx, tableName, index.name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *DynamoService) waitForIndex(ctx context.Context, tableName string, name string) error {
+	for {
+		active, err := d.activeIndexes(ctx, tableName)
+		if err != nil {
+			return err
+		}
+		if active[name] {
+			return nil
+		}
+
+		log.Printf("[DynamoService] Waiting for index %s to become active", name)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(dynamoIndexPollInterval):
+		}
+	}
+}
+
+// backfillQueryAttributes rewrites items that lack the attributes the indexes are keyed on.
+// Items stored before tenants existed are moved to the default tenant, under a new key.
+func (d *DynamoService) backfillQueryAttributes(ctx context.Context) error {
+	items, err := d.scanAll(ctx, &dynamodb.ScanInput{
+		TableName: aws.String(d.tableName),
+		FilterExpression: aws.String("attribute_not_exists(list_pk) OR attribute_not_exists(created_at_key) OR attribute_not_exists(tenant_id) OR attribute_not_exists(status_key) OR attribute_not_exists(processed_at_key) OR " +
+			"(attribute_not_exists(email_key) AND contains(personal_data.email, :at))"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":at": &types.AttributeValueMemberS{Value: "@"},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scan items to backfill: %w", err)
+	}
+
+	log.Printf("[DynamoService] Backfilling index attributes on %d items", len(items))
+
+	for _, item := range items {
+		id, ok := item["id"].(*types.AttributeValueMemberS)
+		if !ok {
+			continue
+		}
+
+		businessCard, err := unmarshalBusinessCard(item)
+		if err != nil {
+			return fmt.Errorf("failed to backfill business card %s: %w", id.Value, err)
+		}
+		businessCard.TenantID = recordTenant(businessCard.TenantID)
+
+		// Saving again writes the query attributes alongside the card
+		if err := d.SaveBusinessCard(ctx, businessCard); err != nil {
+			return fmt.Errorf("failed to backfill business card %s: %w", id.Value, err)
+		}
+
+		if id.Value != tenantKey(businessCard.TenantID, businessCard.ID) {
+			_, err := d.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+				TableName: aws.String(d.tableName),
+				Key: map[string]types.AttributeValue{
+					"id": id,
+				},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to remove unscoped business card %s: %w", id.Value, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// queryBusinessCards lists business cards through the index that best matches the query's
+// filters. Only the list index is also sorted by processed_at, so with that sort every filter
+// applies to the items of the tenant's list partition.
+func (d *DynamoService) queryBusinessCards(ctx context.Context, query models.BusinessCardQuery, cursor *pageCursor) (*models.BusinessCardPage, error) {
+	tenantID := TenantFromContext(ctx)
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(d.tableName),
+		ScanIndexForward:          aws.Bool(query.SortOrder == models.SortOrderAsc),
+		ExpressionAttributeNames:  map[string]string{},
+		ExpressionAttributeValues: map[string]types.AttributeValue{},
+	}
+
+	var filters []string
+	switch {
+	case query.SortBy == models.SortByProcessedAt:
+		input.IndexName = aws.String(dynamoProcessedListIndex)
+		input.ExpressionAttributeNames["#partition"] = "list_pk"
+		input.ExpressionAttributeValues[":partition"] = &types.AttributeValueMemberS{Value: tenantKey(tenantID, dynamoListPartition)}
+		if query.Status != "" {
+			filters = append(filters, "#status = :status")
+			input.ExpressionAttributeNames["#status"] = "status"
+			input.ExpressionAttributeValues[":status"] = &types.AttributeValueMemberS{Value: query.Status}
+		}
+		if query.CompanyName != "" {
+			filters = append(filters, "company_name_key = :company_name")
+			input.ExpressionAttributeValues[":company_name"] = &types.AttributeValueMemberS{Value: tenantKey(tenantID, normalizeCompanyName(query.CompanyName))}
+		}
+		if query.Email != "" {
+			filters = append(filters, "email_key = :email")
+			input.ExpressionAttributeValues[":email"] = &types.AttributeValueMemberS{Value: tenantKey(tenantID, query.Email)}
+		}
+	case query.Email != "":
+		// The email index is the most selective, the other filters apply to its items
+		input.IndexName = aws.String(dynamoEmailIndex)
+		input.ExpressionAttributeNames["#partition"] = "email_key"
+		input.ExpressionAttributeValues[":partition"] = &types.AttributeValueMemberS{Value: tenantKey(tenantID, query.Email)}
+		if query.Status != "" {
+			filters = append(filters, "#status = :status")
+			input.ExpressionAttributeNames["#status"] = "status"
+			input.ExpressionAttributeValues[":status"] = &types.AttributeValueMemberS{Value: query.Status}
+		}
+		if query.CompanyName != "" {
+			filters = append(filters, "company_name_key = :company_name")
+			input.ExpressionAttributeValues[":company_name"] = &types.AttributeValueMemberS{Value: tenantKey(tenantID, normalizeCompanyName(query.CompanyName))}
+		}
+	case query.Status != "":
+		input.IndexName = aws.String(dynamoStatusIndex)
+		input.ExpressionAttributeNames["#partition"] = "status_key"
+		input.ExpressionAttributeValues[":partition"] = &types.AttributeValueMemberS{Value: tenantKey(tenantID, query.Status)}
+		if query.CompanyName != "" {
+			filters = append(filters, "company_name_key = :company_name")
+			input.ExpressionAttributeValues[":company_name"] = &types.AttributeValueMemberS{Value: tenantKey(tenantID, normalizeCompanyName(query.CompanyName))}
+		}
+	case query.CompanyName != "":
+		input.IndexName = aws.String(dynamoCompanyNameIndex)
+		input.ExpressionAttributeNames["#partition"] = "company_name_key"
+		input.ExpressionAttributeValues[":partition"] = &types.AttributeValueMemberS{Value: tenantKey(tenantID, normalizeCompanyName(query.CompanyName))}
+	default:
+		input.IndexName = aws.String(dynamoListIndex)
+		input.ExpressionAttributeNames["#partition"] = "list_pk"
+		input.ExpressionAttributeValues[":partition"] = &types.AttributeValueMemberS{Value: tenantKey(tenantID, dynamoListPartition)}
+	}
+
+	// The created_at range is a key condition on the indexes sorted by created_at
+	var createdRange string
+	switch {
+	case query.CreatedAfter != nil && query.CreatedBefore != nil:
+		createdRange = "created_at_key BETWEEN :created_after AND :created_before"
+	case query.CreatedAfter != nil:
+		createdRange = "created_at_key >= :created_after"
+	case query.CreatedBefore != nil:
+		createdRange = "created_at_key <= :created_before"
+	}
+	keyCondition := "#partition = :partition"
+	if createdRange != "" && query.SortBy == models.SortByProcessedAt {
+		filters = append(filters, createdRange)
+	} else if createdRange != "" {
+		keyCondition += " AND " + createdRange
+	}
+	if query.CreatedAfter != nil {
+		input.ExpressionAttributeValues[":created_after"] = &types.AttributeValueMemberS{Value: sortableTime(*query.CreatedAfter)}
+	}
+	if query.CreatedBefore != nil {
+		input.ExpressionAttributeValues[":created_before"] = &types.AttributeValueMemberS{Value: sortableTime(*query.CreatedBefore)}
+	}
+	input.KeyConditionExpression = aws.String(keyCondition)
+	if len(filters) > 0 {
+		input.FilterExpression = aws.String(strings.Join(filters, " AND "))
+	}
+
+	if cursor != nil {
+		input.ExclusiveStartKey = decodeDynamoKey(cursor.Key)
+	}
+
+	page := &models.BusinessCardPage{}
+	for {
+		// As with scans, Limit counts items before filtering and keeps LastEvaluatedKey exact
+		input.Limit = aws.Int32(int32(query.Limit - len(page.BusinessCards)))
+
+		items, lastEvaluatedKey, err := d.queryPage(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		page.BusinessCards = append(page.BusinessCards, items...)
+
+		if len(lastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = lastEvaluatedKey
+		if len(page.BusinessCards) >= query.Limit {
+			page.NextCursor = encodeCursor(pageCursor{Key: encodeDynamoKey(lastEvaluatedKey)})
+			break
+		}
+	}
+
+	return page, nil
+}
+
+// queryStatusIndex returns every business card with the given status, oldest first, from the
+// context's tenant or from every tenant for a context created by WithAllTenants
+func (d *DynamoService) queryStatusIndex(ctx context.Context, status string) ([]models.BusinessCard, error) {
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(d.tableName),
+		IndexName:              aws.String(dynamoAllTenantsStatusIndex),
+		KeyConditionExpression: aws.String("#status = :status"),
+		ExpressionAttributeNames: map[string]string{
+			"#status": "status",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":status": &types.AttributeValueMemberS{Value: status},
+		},
+	}
+	if tenantID, scoped := listingTenant(ctx); scoped {
+		// The tenant's own partition of the status index holds none of the other tenants' cards
+		input.IndexName = aws.String(dynamoStatusIndex)
+		input.ExpressionAttributeNames["#status"] = "status_key"
+		input.ExpressionAttributeValues[":status"] = &types.AttributeValueMemberS{Value: tenantKey(tenantID, status)}
+	}
+
+	var businessCards []models.BusinessCard
+	for {
+		items, lastEvaluatedKey, err := d.queryPage(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		businessCards = append(businessCards, items...)
+
+		if len(lastEvaluatedKey) == 0 {
+			return businessCards, nil
+		}
+		input.ExclusiveStartKey = lastEvaluatedKey
+	}
+}
+
+// queryListIndex returns every business card of the tenant, oldest first
+func (d *DynamoService) queryListIndex(ctx context.Context, tenantID string) ([]models.BusinessCard, error) {
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(d.tableName),
+		IndexName:              aws.String(dynamoListIndex),
+		KeyConditionExpression: aws.String("list_pk = :partition"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":partition": &types.AttributeValueMemberS{Value: tenantKey(tenantID, dynamoListPartition)},
+		},
+	}
+
+	var businessCards []models.BusinessCard
+	for {
+		items, lastEvaluatedKey, err := d.queryPage(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		businessCards = append(businessCards, items...)
+
+		if len(lastEvaluatedKey) == 0 {
+			return businessCards, nil
+		}
+		input.ExclusiveStartKey = lastEvaluatedKey
+	}
+}
+
+func (d *DynamoService) queryPage(ctx context.Context, input *dynamodb.QueryInput) ([]models.BusinessCard, map[string]types.AttributeValue, error) {
+	result, err := d.client.Query(ctx, input)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query business cards: %w", err)
+	}
+
+	return unmarshalBusinessCards(result.Items), result.LastEvaluatedKey, nil
+}
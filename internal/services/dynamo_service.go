@@ -2,8 +2,11 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"business-card-reader/internal/models"
 
@@ -14,9 +17,31 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
 
+// DynamoAPI is the part of the DynamoDB client used by DynamoService, implemented by
+// *dynamodb.Client and by the dynamotest stand-in
+type DynamoAPI interface {
+	CreateTable(ctx context.Context, input *dynamodb.CreateTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.CreateTableOutput, error)
+	DescribeTable(ctx context.Context, input *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error)
+	UpdateTable(ctx context.Context, input *dynamodb.UpdateTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateTableOutput, error)
+	PutItem(ctx context.Context, input *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)
+	GetItem(ctx context.Context, input *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
+	DeleteItem(ctx context.Context, input *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)
+	Query(ctx context.Context, input *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
+	Scan(ctx context.Context, input *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error)
+	DescribeTimeToLive(ctx context.Context, input *dynamodb.DescribeTimeToLiveInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTimeToLiveOutput, error)
+	UpdateTimeToLive(ctx context.Context, input *dynamodb.UpdateTimeToLiveInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateTimeToLiveOutput, error)
+}
+
 type DynamoService struct {
-	client    *dynamodb.Client
+	client    DynamoAPI
 	tableName string
+	// indexesReady is set once the secondary indexes are known to be active; until then
+	// listing and status lookups scan the table
+	indexesReady bool
+	// webhookIndexesReady does the same for the indexes of the webhook tables
+	webhookIndexesReady bool
+	// webhookDeliveryRetention is how long DynamoDB keeps a delivery after its last scheduled attempt
+	webhookDeliveryRetention time.Duration
 }
 
 func NewDynamoService(region string) (*DynamoService, error) {
@@ -31,18 +56,40 @@ func NewDynamoService(region string) (*DynamoService, error) {
 
 	log.Printf("[DynamoService] Initialized with table: %s, region: %s", tableName, region)
 
+	return NewDynamoServiceWithClient(client, tableName), nil
+}
+
+// NewDynamoServiceWithClient creates a repository on the given client and main table name
+func NewDynamoServiceWithClient(client DynamoAPI, tableName string) *DynamoService {
 	return &DynamoService{
-		client:    client,
-		tableName: tableName,
-	}, nil
+		client:                   client,
+		tableName:                tableName,
+		webhookDeliveryRetention: DefaultWebhookDeliveryRetention,
+	}
+}
+
+// businessCardItem marshals a business card together with its index attributes
+func businessCardItem(businessCard *models.BusinessCard) (map[string]types.AttributeValue, error) {
+	item, err := attributevalue.MarshalMap(businessCard)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal business card: %w", err)
+	}
+	for name, value := range businessCardQueryAttributes(businessCard) {
+		item[name] = value
+	}
+	// The tenant prefixes the partition key, so tenants never share an item
+	tenantID := recordTenant(businessCard.TenantID)
+	item["id"] = &types.AttributeValueMemberS{Value: tenantKey(tenantID, businessCard.ID)}
+	item["tenant_id"] = &types.AttributeValueMemberS{Value: tenantID}
+	return item, nil
 }
 
 func (d *DynamoService) SaveBusinessCard(ctx context.Context, businessCard *models.BusinessCard) error {
 	log.Printf("[DynamoService] Saving business card with ID: %s", businessCard.ID)
-	item, err := attributevalue.MarshalMap(businessCard)
+	item, err := businessCardItem(businessCard)
 	if err != nil {
 		log.Printf("[DynamoService] Failed to marshal business card: %v", err)
-		return fmt.Errorf("failed to marshal business card: %w", err)
+		return err
 	}
 
 	_, err = d.client.PutItem(ctx, &dynamodb.PutItemInput{
@@ -58,11 +105,51 @@ func (d *DynamoService) SaveBusinessCard(ctx context.Context, businessCard *mode
 	return nil
 }
 
+// SaveBusinessCardIf puts the business card with a condition on the stored status and reviewer
+func (d *DynamoService) SaveBusinessCardIf(ctx context.Context, businessCard *models.BusinessCard, condition BusinessCardCondition) error {
+	log.Printf("[DynamoService] Saving business card with ID %s if its status is %s", businessCard.ID, condition.Status)
+	item, err := businessCardItem(businessCard)
+	if err != nil {
+		log.Printf("[DynamoService] Failed to marshal business card: %v", err)
+		return err
+	}
+
+	conditionExpression := "#status = :expected_status"
+	names := map[string]string{"#status": "status"}
+	values := map[string]types.AttributeValue{
+		":expected_status": &types.AttributeValueMemberS{Value: condition.Status},
+	}
+	if condition.Reviewer != "" {
+		conditionExpression += " AND #review.#reviewer = :expected_reviewer"
+		names["#review"] = "review"
+		names["#reviewer"] = "reviewer"
+		values[":expected_reviewer"] = &types.AttributeValueMemberS{Value: condition.Reviewer}
+	}
+
+	_, err = d.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                 aws.String(d.tableName),
+		Item:                      item,
+		ConditionExpression:       aws.String(conditionExpression),
+		ExpressionAttributeNames:  names,
+		ExpressionAttributeValues: values,
+	})
+	var conditionFailed *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionFailed) {
+		return ErrBusinessCardChanged
+	}
+	if err != nil {
+		log.Printf("[DynamoService] Failed to save business card to DynamoDB: %v", err)
+		return fmt.Errorf("failed to save business card: %w", err)
+	}
+
+	return nil
+}
+
 func (d *DynamoService) GetBusinessCard(ctx context.Context, id string) (*models.BusinessCard, error) {
 	result, err := d.client.GetItem(ctx, &dynamodb.GetItemInput{
 		TableName: aws.String(d.tableName),
 		Key: map[string]types.AttributeValue{
-			"id": &types.AttributeValueMemberS{Value: id},
+			"id": &types.AttributeValueMemberS{Value: tenantKey(TenantFromContext(ctx), id)},
 		},
 	})
 	if err != nil {
@@ -70,76 +157,132 @@ func (d *DynamoService) GetBusinessCard(ctx context.Context, id string) (*models
 	}
 
 	if result.Item == nil {
-		return nil, fmt.Errorf("business card not found")
+		return nil, ErrBusinessCardNotFound
 	}
 
-	var businessCard models.BusinessCard
-	err = attributevalue.UnmarshalMap(result.Item, &businessCard)
+	return unmarshalBusinessCard(result.Item)
+}
+
+// GetAllBusinessCards reads the tenant's partition of the list index. Only a context created by
+// WithAllTenants, or a table without indexes, scans the table.
+func (d *DynamoService) GetAllBusinessCards(ctx context.Context) ([]models.BusinessCard, error) {
+	tenantID, scoped := listingTenant(ctx)
+	if scoped && d.indexesReady {
+		return d.queryListIndex(ctx, tenantID)
+	}
+
+	input := &dynamodb.ScanInput{
+		TableName: aws.String(d.tableName),
+	}
+	if scoped {
+		input.FilterExpression = aws.String("tenant_id = :tenant")
+		input.ExpressionAttributeValues = map[string]types.AttributeValue{
+			":tenant": &types.AttributeValueMemberS{Value: tenantID},
+		}
+	}
+
+	items, err := d.scanAll(ctx, input)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal business card: %w", err)
+		return nil, fmt.Errorf("failed to scan business cards: %w", err)
 	}
 
-	return &businessCard, nil
+	return unmarshalBusinessCards(items), nil
 }
 
-func (d *DynamoService) GetAllBusinessCards(ctx context.Context) ([]models.BusinessCard, error) {
-	result, err := d.client.Scan(ctx, &dynamodb.ScanInput{
-		TableName: aws.String(d.tableName),
+// CreateTableIfNotExists creates the missing tables. It fails on an existing table that still holds
+// business cards stored before tenants existed, which no lookup would find until Migrate has moved
+// them to the default tenant.
+func (d *DynamoService) CreateTableIfNotExists(ctx context.Context) error {
+	created, err := d.createTables(ctx)
+	if err != nil {
+		return err
+	}
+	if created {
+		return nil
+	}
+
+	return d.checkLegacyItems(ctx)
+}
+
+// createTables creates the missing tables and reports whether the business card table is new
+func (d *DynamoService) createTables(ctx context.Context) (bool, error) {
+	// The business card table is created with its secondary indexes, existing tables only get
+	// them through Migrate
+	created, err := d.createTableIfNotExists(ctx, &dynamodb.CreateTableInput{
+		TableName:              aws.String(d.tableName),
+		AttributeDefinitions:   indexAttributeDefinitions(businessCardIndexes),
+		GlobalSecondaryIndexes: indexDefinitions(businessCardIndexes),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to scan business cards: %w", err)
+		return false, err
+	}
+	if created {
+		d.indexesReady = true
+	} else if d.indexesReady, err = d.indexesActive(ctx, d.tableName, businessCardIndexes); err != nil {
+		return false, err
 	}
 
-	var businessCards []models.BusinessCard
-	for _, item := range result.Items {
-		var businessCard models.BusinessCard
-		err = attributevalue.UnmarshalMap(item, &businessCard)
+	if err := d.createWebhookTables(ctx); err != nil {
+		return false, err
+	}
+
+	for _, tableName := range []string{d.apiKeysTable(), d.erasureReceiptsTable()} {
+		_, err := d.createTableIfNotExists(ctx, &dynamodb.CreateTableInput{
+			TableName: aws.String(tableName),
+			AttributeDefinitions: []types.AttributeDefinition{
+				{
+					AttributeName: aws.String("id"),
+					AttributeType: types.ScalarAttributeTypeS, // String type for GUID
+				},
+			},
+		})
 		if err != nil {
-			continue // Skip items that can't be unmarshaled
+			return false, err
 		}
-		businessCards = append(businessCards, businessCard)
 	}
 
-	return businessCards, nil
+	return created, nil
 }
 
-func (d *DynamoService) CreateTableIfNotExists(ctx context.Context) error {
+// createTableIfNotExists creates a pay-per-request table keyed by id and reports whether it
+// had to be created
+func (d *DynamoService) createTableIfNotExists(ctx context.Context, input *dynamodb.CreateTableInput) (bool, error) {
+	tableName := aws.ToString(input.TableName)
+
 	// Check if table exists
 	_, err := d.client.DescribeTable(ctx, &dynamodb.DescribeTableInput{
-		TableName: aws.String(d.tableName),
+		TableName: input.TableName,
 	})
 	if err == nil {
 		// Table already exists
-		return nil
+		return false, nil
 	}
 
-	// Create table
-	_, err = d.client.CreateTable(ctx, &dynamodb.CreateTableInput{
-		TableName: aws.String(d.tableName),
-		KeySchema: []types.KeySchemaElement{
-			{
-				AttributeName: aws.String("id"),
-				KeyType:       types.KeyTypeHash,
-			},
-		},
-		AttributeDefinitions: []types.AttributeDefinition{
-			{
-				AttributeName: aws.String("id"),
-				AttributeType: types.ScalarAttributeTypeS, // String type for GUID
-			},
+	log.Printf("[DynamoService] Creating table: %s", tableName)
+
+	input.KeySchema = []types.KeySchemaElement{
+		{
+			AttributeName: aws.String("id"),
+			KeyType:       types.KeyTypeHash,
 		},
-		BillingMode: types.BillingModePayPerRequest,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to create table: %w", err)
 	}
+	input.BillingMode = types.BillingModePayPerRequest
 
-	return nil
+	// Create table
+	if _, err := d.client.CreateTable(ctx, input); err != nil {
+		return false, fmt.Errorf("failed to create table %s: %w", tableName, err)
+	}
+
+	return true, nil
 }
 
 func (d *DynamoService) GetBusinessCardsByStatus(ctx context.Context, status string) ([]models.BusinessCard, error) {
-	// Use a filter expression to get cards by status
-	result, err := d.client.Scan(ctx, &dynamodb.ScanInput{
+	if d.indexesReady {
+		return d.queryStatusIndex(ctx, status)
+	}
+
+	// Without the status index, fall back to a filtered scan
+	input := &dynamodb.ScanInput{
 		TableName:        aws.String(d.tableName),
 		FilterExpression: aws.String("#status = :status"),
 		ExpressionAttributeNames: map[string]string{
@@ -148,20 +291,193 @@ func (d *DynamoService) GetBusinessCardsByStatus(ctx context.Context, status str
 		ExpressionAttributeValues: map[string]types.AttributeValue{
 			":status": &types.AttributeValueMemberS{Value: status},
 		},
-	})
+	}
+	if tenantID, scoped := listingTenant(ctx); scoped {
+		input.FilterExpression = aws.String("#status = :status AND tenant_id = :tenant")
+		input.ExpressionAttributeValues[":tenant"] = &types.AttributeValueMemberS{Value: tenantID}
+	}
+
+	items, err := d.scanAll(ctx, input)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan business cards by status: %w", err)
 	}
 
-	var businessCards []models.BusinessCard
-	for _, item := range result.Items {
-		var businessCard models.BusinessCard
-		err = attributevalue.UnmarshalMap(item, &businessCard)
+	return unmarshalBusinessCards(items), nil
+}
+
+func (d *DynamoService) DeleteBusinessCard(ctx context.Context, id string) error {
+	log.Printf("[DynamoService] Deleting business card with ID: %s", id)
+	result, err := d.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(d.tableName),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: tenantKey(TenantFromContext(ctx), id)},
+		},
+		ReturnValues: types.ReturnValueAllOld,
+	})
+	if err != nil {
+		log.Printf("[DynamoService] Failed to delete business card from DynamoDB: %v", err)
+		return fmt.Errorf("failed to delete business card: %w", err)
+	}
+
+	if len(result.Attributes) == 0 {
+		return ErrBusinessCardNotFound
+	}
+
+	return nil
+}
+
+// ListBusinessCards queries the secondary index matching the filters, resuming from the
+// LastEvaluatedKey stored in the cursor. A table without indexes falls back to scanning the
+// table page by page; sorting then only applies within the returned page, so sorting by
+// processed_at is refused there until the table is migrated.
+func (d *DynamoService) ListBusinessCards(ctx context.Context, query models.BusinessCardQuery) (*models.BusinessCardPage, error) {
+	cursor, err := decodeCursor(query.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.indexesReady {
+		return d.queryBusinessCards(ctx, query, cursor)
+	}
+	if query.SortBy == models.SortByProcessedAt {
+		return nil, fmt.Errorf("%w: sort_by=processed_at needs the DynamoDB indexes, run with -migrate", ErrInvalidQuery)
+	}
+
+	input := &dynamodb.ScanInput{
+		TableName: aws.String(d.tableName),
+	}
+	applyBusinessCardFilters(input, TenantFromContext(ctx), query)
+	if cursor != nil {
+		input.ExclusiveStartKey = decodeDynamoKey(cursor.Key)
+	}
+
+	page := &models.BusinessCardPage{}
+	for {
+		// Limit counts items read before filtering, so a page can never overshoot the
+		// remaining capacity and LastEvaluatedKey always points right after the last item returned
+		input.Limit = aws.Int32(int32(query.Limit - len(page.BusinessCards)))
+
+		result, err := d.client.Scan(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan business cards: %w", err)
+		}
+
+		page.BusinessCards = append(page.BusinessCards, unmarshalBusinessCards(result.Items)...)
+
+		if len(result.LastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+		if len(page.BusinessCards) >= query.Limit {
+			page.NextCursor = encodeCursor(pageCursor{Key: encodeDynamoKey(result.LastEvaluatedKey)})
+			break
+		}
+	}
+
+	sortBusinessCards(page.BusinessCards, query)
+
+	return page, nil
+}
+
+// businessCardQueryAttributes returns the extra attributes listing queries filter on: sortable
+// timestamps, the status, the normalized company name and email, and the list index partition.
+// The partition keys of the status, list, company name and email indexes are prefixed with the tenant.
+func businessCardQueryAttributes(businessCard *models.BusinessCard) map[string]types.AttributeValue {
+	tenantID := recordTenant(businessCard.TenantID)
+	attributes := map[string]types.AttributeValue{
+		"list_pk":          &types.AttributeValueMemberS{Value: tenantKey(tenantID, dynamoListPartition)},
+		"status_key":       &types.AttributeValueMemberS{Value: tenantKey(tenantID, businessCard.Status)},
+		"created_at_key":   &types.AttributeValueMemberS{Value: sortableTime(businessCard.CreatedAt)},
+		"processed_at_key": &types.AttributeValueMemberS{Value: sortableTime(businessCard.ProcessedAt)},
+	}
+	if companyName := normalizeCompanyName(businessCard.CompanyData.Name); companyName != "" {
+		attributes["company_name_key"] = &types.AttributeValueMemberS{Value: tenantKey(tenantID, companyName)}
+	}
+	if email := emailKey(businessCard.PersonalData.Email); email != "" {
+		attributes["email_key"] = &types.AttributeValueMemberS{Value: tenantKey(tenantID, email)}
+	}
+	return attributes
+}
+
+// unmarshalBusinessCard reads a business card item, removing the tenant prefix from its ID
+func unmarshalBusinessCard(item map[string]types.AttributeValue) (*models.BusinessCard, error) {
+	var businessCard models.BusinessCard
+	if err := attributevalue.UnmarshalMap(item, &businessCard); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal business card: %w", err)
+	}
+
+	businessCard.ID = strings.TrimPrefix(businessCard.ID, tenantKey(recordTenant(businessCard.TenantID), ""))
+	return &businessCard, nil
+}
+
+func unmarshalBusinessCards(items []map[string]types.AttributeValue) []models.BusinessCard {
+	businessCards := make([]models.BusinessCard, 0, len(items))
+	for _, item := range items {
+		businessCard, err := unmarshalBusinessCard(item)
 		if err != nil {
 			continue // Skip items that can't be unmarshaled
 		}
-		businessCards = append(businessCards, businessCard)
+		businessCards = append(businessCards, *businessCard)
 	}
+	return businessCards
+}
 
-	return businessCards, nil
+func applyBusinessCardFilters(input *dynamodb.ScanInput, tenantID string, query models.BusinessCardQuery) {
+	conditions := []string{"tenant_id = :tenant"}
+	names := map[string]string{}
+	values := map[string]types.AttributeValue{
+		":tenant": &types.AttributeValueMemberS{Value: tenantID},
+	}
+
+	if query.Status != "" {
+		conditions = append(conditions, "#status = :status")
+		names["#status"] = "status"
+		values[":status"] = &types.AttributeValueMemberS{Value: query.Status}
+	}
+	if query.CompanyName != "" {
+		conditions = append(conditions, "company_name_key = :company_name")
+		values[":company_name"] = &types.AttributeValueMemberS{Value: tenantKey(tenantID, normalizeCompanyName(query.CompanyName))}
+	}
+	if query.Email != "" {
+		conditions = append(conditions, "email_key = :email")
+		values[":email"] = &types.AttributeValueMemberS{Value: tenantKey(tenantID, query.Email)}
+	}
+	if query.CreatedAfter != nil {
+		conditions = append(conditions, "created_at_key >= :created_after")
+		values[":created_after"] = &types.AttributeValueMemberS{Value: sortableTime(*query.CreatedAfter)}
+	}
+	if query.CreatedBefore != nil {
+		conditions = append(conditions, "created_at_key <= :created_before")
+		values[":created_before"] = &types.AttributeValueMemberS{Value: sortableTime(*query.CreatedBefore)}
+	}
+
+	input.FilterExpression = aws.String(strings.Join(conditions, " AND "))
+	if len(names) > 0 {
+		input.ExpressionAttributeNames = names
+	}
+	input.ExpressionAttributeValues = values
+}
+
+// encodeDynamoKey converts a LastEvaluatedKey into cursor form. Every key attribute of the
+// table and its indexes is a string.
+func encodeDynamoKey(key map[string]types.AttributeValue) map[string]string {
+	encoded := make(map[string]string, len(key))
+	for name, value := range key {
+		if s, ok := value.(*types.AttributeValueMemberS); ok {
+			encoded[name] = s.Value
+		}
+	}
+	return encoded
+}
+
+func decodeDynamoKey(encoded map[string]string) map[string]types.AttributeValue {
+	if len(encoded) == 0 {
+		return nil
+	}
+
+	key := make(map[string]types.AttributeValue, len(encoded))
+	for name, value := range encoded {
+		key[name] = &types.AttributeValueMemberS{Value: value}
+	}
+	return key
 }
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"business-card-reader/internal/models"
+	"business-card-reader/internal/services/dynamotest"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// newTestDynamoService returns a DynamoDB repository with its tables created on an in-memory client
+func newTestDynamoService(t *testing.T) (*DynamoService, *dynamotest.Client) {
+	t.Helper()
+	client := dynamotest.NewClient()
+	repository := NewDynamoServiceWithClient(client, "business-card-reader")
+	if err := repository.CreateTableIfNotExists(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return repository, client
+}
+
+func TestDynamoServiceRequiresMigrationOfLegacyItems(t *testing.T) {
+	tests := []struct {
+		name string
+		// olderTable turns the table into one of a version from before the indexes
+		olderTable bool
+	}{
+		{name: "table with indexes"},
+		{name: "table without indexes", olderTable: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			_, client := newTestDynamoService(t)
+
+			// Stored by a version from before tenants: no tenant prefix on the key or list partition
+			legacy := models.BusinessCard{ID: "legacy", Status: models.StatusCompleted, CreatedAt: time.Now()}
+			item, err := attributevalue.MarshalMap(&legacy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.olderTable {
+				for _, index := range businessCardIndexes {
+					client.DropIndex("business-card-reader", index.name)
+				}
+			} else {
+				item["list_pk"] = &types.AttributeValueMemberS{Value: dynamoListPartition}
+				item["created_at_key"] = &types.AttributeValueMemberS{Value: sortableTime(legacy.CreatedAt)}
+			}
+			if _, err := client.PutItem(ctx, &dynamodb.PutItemInput{TableName: aws.String("business-card-reader"), Item: item}); err != nil {
+				t.Fatal(err)
+			}
+
+			repository := NewDynamoServiceWithClient(client, "business-card-reader")
+			if err := repository.CreateTableIfNotExists(ctx); !errors.Is(err, ErrMigrationRequired) {
+				t.Fatalf("startup on legacy items: got %v, want ErrMigrationRequired", err)
+			}
+
+			if err := repository.Migrate(ctx); err != nil {
+				t.Fatal(err)
+			}
+			if err := repository.CreateTableIfNotExists(ctx); err != nil {
+				t.Fatalf("startup after migration: %v", err)
+			}
+			businessCard, err := repository.GetBusinessCard(WithTenant(ctx, DefaultTenant), "legacy")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if businessCard.TenantID != DefaultTenant {
+				t.Errorf("migrated card belongs to %q, want the default tenant", businessCard.TenantID)
+			}
+		})
+	}
+}
+
+func TestDynamoServiceStatusQueriesStayInTenant(t *testing.T) {
+	ctx := context.Background()
+	acme := WithTenant(ctx, "acme")
+	repository, client := newTestDynamoService(t)
+
+	// A table from before the status index was keyed on the tenant
+	client.DropIndex("business-card-reader", dynamoStatusIndex)
+	repository = NewDynamoServiceWithClient(client, "business-card-reader")
+	if err := repository.CreateTableIfNotExists(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	createdAt := time.Now()
+	for i, tenantID := range []string{"globex", "globex", "globex", "acme"} {
+		businessCard := models.BusinessCard{ID: fmt.Sprintf("card-%d", i), TenantID: tenantID, Status: models.StatusFailed, CreatedAt: createdAt.Add(time.Duration(i) * time.Second)}
+		if err := repository.SaveBusinessCard(ctx, &businessCard); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Written by the older version, without the tenant-keyed status
+	item, _ := client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String("business-card-reader"),
+		Key:       map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "acme#card-3"}},
+	})
+	delete(item.Item, "status_key")
+	if _, err := client.PutItem(ctx, &dynamodb.PutItemInput{TableName: aws.String("business-card-reader"), Item: item.Item}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repository.Migrate(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// On a status index shared by all tenants, acme's card comes after three of globex
+	itemsRead := client.ItemsRead()
+	page, err := repository.ListBusinessCards(acme, models.BusinessCardQuery{Status: models.StatusFailed, SortBy: models.SortByCreatedAt, SortOrder: models.SortOrderAsc, Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.BusinessCards) != 1 || page.BusinessCards[0].ID != "card-3" || page.NextCursor != "" {
+		t.Errorf("got %d business cards and next cursor %q, want card-3 alone", len(page.BusinessCards), page.NextCursor)
+	}
+	if read := client.ItemsRead() - itemsRead; read != 1 {
+		t.Errorf("listing read %d items, want only acme's", read)
+	}
+
+	itemsRead = client.ItemsRead()
+	if businessCards, err := repository.GetBusinessCardsByStatus(acme, models.StatusFailed); err != nil || len(businessCards) != 1 {
+		t.Errorf("acme has %d failed business cards (%v), want 1", len(businessCards), err)
+	}
+	if read := client.ItemsRead() - itemsRead; read != 1 {
+		t.Errorf("status lookup read %d items, want only acme's", read)
+	}
+	if businessCards, err := repository.GetBusinessCardsByStatus(WithAllTenants(ctx), models.StatusFailed); err != nil || len(businessCards) != 4 {
+		t.Errorf("all tenants have %d failed business cards (%v), want 4", len(businessCards), err)
+	}
+}
+
+func TestDynamoServiceQueriesWithoutScanning(t *testing.T) {
+	acme := WithTenant(context.Background(), "acme")
+	repository, client := newTestDynamoService(t)
+	businessCards := seedListingCards(t, repository)
+
+	createdAfter := businessCards[1].CreatedAt
+	filters := map[string]models.BusinessCardQuery{
+		"all":           {},
+		"by status":     {Status: models.StatusFailed},
+		"by company":    {CompanyName: "Acme Corp"},
+		"by email":      {Email: "jane@acme.com"},
+		"created after": {CreatedAfter: &createdAfter},
+	}
+	for name, query := range filters {
+		for _, sortBy := range []string{models.SortByCreatedAt, models.SortByProcessedAt} {
+			for _, order := range []string{models.SortOrderAsc, models.SortOrderDesc} {
+				query.SortBy, query.SortOrder, query.Limit = sortBy, order, 2
+				if _, err := repository.ListBusinessCards(acme, query); err != nil {
+					t.Fatalf("%s by %s %s: %v", name, sortBy, order, err)
+				}
+				if scans := client.Scans(); scans != 0 {
+					t.Fatalf("listing %s by %s %s scanned the table", name, sortBy, order)
+				}
+			}
+		}
+	}
+
+	if _, err := repository.GetBusinessCardsByStatus(acme, models.StatusFailed); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repository.GetBusinessCardsByStatus(WithAllTenants(acme), models.StatusFailed); err != nil {
+		t.Fatal(err)
+	}
+	if all, err := repository.GetAllBusinessCards(acme); err != nil || len(all) != len(businessCards) {
+		t.Fatalf("got %d business cards of acme (%v), want %d", len(all), err, len(businessCards))
+	}
+	if scans := client.Scans(); scans != 0 {
+		t.Errorf("status lookups or the tenant's full listing scanned the table %d times", scans)
+	}
+}
@@ -0,0 +1,458 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"business-card-reader/internal/models"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Global secondary indexes of the webhook tables
+const (
+	dynamoSubscriptionTenantIndex   = "tenant_id-created_at-index"
+	dynamoDeliveryDueIndex          = "status-next_attempt_at-index"
+	dynamoDeliverySubscriptionIndex = "subscription_id-created_at-index"
+)
+
+var webhookSubscriptionIndexes = []dynamoIndex{
+	{name: dynamoSubscriptionTenantIndex, partitionKey: "tenant_id"},
+}
+
+var webhookDeliveryIndexes = []dynamoIndex{
+	{name: dynamoDeliveryDueIndex, partitionKey: "status", sortKey: "next_attempt_key"},
+	{name: dynamoDeliverySubscriptionIndex, partitionKey: "subscription_id"},
+}
+
+// dynamoDeliveryExpiryAttribute is the TTL attribute of the deliveries table, the epoch second
+// after which DynamoDB deletes a delivery
+const dynamoDeliveryExpiryAttribute = "expires_at"
+
+// DefaultWebhookDeliveryRetention is how long the delivery log is kept on DynamoDB unless
+// SetWebhookDeliveryRetention says otherwise
+const DefaultWebhookDeliveryRetention = 30 * 24 * time.Hour
+
+// dynamoTableActiveTimeout bounds the wait for a new table before its time to live is turned on
+const dynamoTableActiveTimeout = 5 * time.Minute
+
+func (d *DynamoService) webhookSubscriptionsTable() string {
+	return d.tableName + "-webhooks"
+}
+
+func (d *DynamoService) webhookDeliveriesTable() string {
+	return d.tableName + "-webhook-deliveries"
+}
+
+// SetWebhookDeliveryRetention sets how long deliveries are kept after their last scheduled attempt
+func (d *DynamoService) SetWebhookDeliveryRetention(retention time.Duration) {
+	d.webhookDeliveryRetention = retention
+}
+
+// createWebhookTables creates the missing webhook tables with their indexes and records whether
+// the indexes of existing tables are active
+func (d *DynamoService) createWebhookTables(ctx context.Context) error {
+	subscriptionsCreated, err := d.createTableIfNotExists(ctx, &dynamodb.CreateTableInput{
+		TableName:              aws.String(d.webhookSubscriptionsTable()),
+		AttributeDefinitions:   indexAttributeDefinitions(webhookSubscriptionIndexes),
+		GlobalSecondaryIndexes: indexDefinitions(webhookSubscriptionIndexes),
+	})
+	if err != nil {
+		return err
+	}
+	deliveriesCreated, err := d.createTableIfNotExists(ctx, &dynamodb.CreateTableInput{
+		TableName:              aws.String(d.webhookDeliveriesTable()),
+		AttributeDefinitions:   indexAttributeDefinitions(webhookDeliveryIndexes),
+		GlobalSecondaryIndexes: indexDefinitions(webhookDeliveryIndexes),
+	})
+	if err != nil {
+		return err
+	}
+
+	if deliveriesCreated {
+		// The time to live can only be turned on once the table is active
+		waiter := dynamodb.NewTableExistsWaiter(d.client)
+		if err := waiter.Wait(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(d.webhookDeliveriesTable())}, dynamoTableActiveTimeout); err != nil {
+			return fmt.Errorf("failed to wait for table %s: %w", d.webhookDeliveriesTable(), err)
+		}
+		if err := d.enableDeliveryExpiry(ctx); err != nil {
+			return err
+		}
+	}
+
+	d.webhookIndexesReady = true
+	if !subscriptionsCreated {
+		ready, err := d.indexesActive(ctx, d.webhookSubscriptionsTable(), webhookSubscriptionIndexes)
+		if err != nil {
+			return err
+		}
+		d.webhookIndexesReady = ready
+	}
+	if !deliveriesCreated {
+		ready, err := d.indexesActive(ctx, d.webhookDeliveriesTable(), webhookDeliveryIndexes)
+		if err != nil {
+			return err
+		}
+		d.webhookIndexesReady = d.webhookIndexesReady && ready
+	}
+
+	return nil
+}
+
+// enableDeliveryExpiry turns on the time to live of the deliveries table unless it is on already
+func (d *DynamoService) enableDeliveryExpiry(ctx context.Context) error {
+	result, err := d.client.DescribeTimeToLive(ctx, &dynamodb.DescribeTimeToLiveInput{
+		TableName: aws.String(d.webhookDeliveriesTable()),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to describe time to live of %s: %w", d.webhookDeliveriesTable(), err)
+	}
+	if description := result.TimeToLiveDescription; description != nil {
+		switch description.TimeToLiveStatus {
+		case types.TimeToLiveStatusEnabled, types.TimeToLiveStatusEnabling:
+			return nil
+		}
+	}
+
+	log.Printf("[DynamoService] Enabling time to live on table %s", d.webhookDeliveriesTable())
+
+	_, err = d.client.UpdateTimeToLive(ctx, &dynamodb.UpdateTimeToLiveInput{
+		TableName: aws.String(d.webhookDeliveriesTable()),
+		TimeToLiveSpecification: &types.TimeToLiveSpecification{
+			AttributeName: aws.String(dynamoDeliveryExpiryAttribute),
+			Enabled:       aws.Bool(true),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to enable time to live on %s: %w", d.webhookDeliveriesTable(), err)
+	}
+	return nil
+}
+
+// migrateWebhookTables backfills the index and expiry attributes on subscriptions and deliveries
+// written by older versions, creates the missing indexes and turns on the expiry of deliveries
+func (d *DynamoService) migrateWebhookTables(ctx context.Context) error {
+	items, err := d.scanAll(ctx, &dynamodb.ScanInput{
+		TableName:        aws.String(d.webhookSubscriptionsTable()),
+		FilterExpression: aws.String("attribute_not_exists(tenant_id) OR attribute_not_exists(created_at_key)"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scan webhook subscriptions to backfill: %w", err)
+	}
+	for _, subscription := range unmarshalWebhookSubscriptions(items) {
+		if err := d.SaveWebhookSubscription(ctx, &subscription); err != nil {
+			return fmt.Errorf("failed to backfill webhook subscription %s: %w", subscription.ID, err)
+		}
+	}
+
+	items, err = d.scanAll(ctx, &dynamodb.ScanInput{
+		TableName:        aws.String(d.webhookDeliveriesTable()),
+		FilterExpression: aws.String("attribute_not_exists(created_at_key) OR attribute_not_exists(next_attempt_key) OR attribute_not_exists(" + dynamoDeliveryExpiryAttribute + ")"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scan webhook deliveries to backfill: %w", err)
+	}
+	for _, delivery := range unmarshalWebhookDeliveries(items) {
+		if err := d.SaveWebhookDelivery(ctx, &delivery); err != nil {
+			return fmt.Errorf("failed to backfill webhook delivery %s: %w", delivery.ID, err)
+		}
+	}
+
+	if err := d.createIndexes(ctx, d.webhookSubscriptionsTable(), webhookSubscriptionIndexes); err != nil {
+		return err
+	}
+	if err := d.createIndexes(ctx, d.webhookDeliveriesTable(), webhookDeliveryIndexes); err != nil {
+		return err
+	}
+	if err := d.enableDeliveryExpiry(ctx); err != nil {
+		return err
+	}
+	d.webhookIndexesReady = true
+
+	return nil
+}
+
+// SaveWebhookSubscription stores the subscription together with the keys of the tenant index
+func (d *DynamoService) SaveWebhookSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	item, err := attributevalue.MarshalMap(subscription)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook subscription: %w", err)
+	}
+	item["tenant_id"] = &types.AttributeValueMemberS{Value: recordTenant(subscription.TenantID)}
+	item["created_at_key"] = &types.AttributeValueMemberS{Value: sortableTime(subscription.CreatedAt)}
+
+	_, err = d.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(d.webhookSubscriptionsTable()),
+		Item:      item,
+	})
+	if err != nil {
+		log.Printf("[DynamoService] Failed to save webhook subscription to DynamoDB: %v", err)
+		return fmt.Errorf("failed to save webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (d *DynamoService) GetWebhookSubscription(ctx context.Context, id string) (*models.WebhookSubscription, error) {
+	result, err := d.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(d.webhookSubscriptionsTable()),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: id},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+
+	if result.Item == nil {
+		return nil, ErrWebhookSubscriptionNotFound
+	}
+
+	var subscription models.WebhookSubscription
+	if err := attributevalue.UnmarshalMap(result.Item, &subscription); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal webhook subscription: %w", err)
+	}
+
+	return &subscription, nil
+}
+
+// GetAllWebhookSubscriptions queries the context's tenant's subscriptions from the tenant index.
+// A context created by WithAllTenants, or a table without the index, scans the table.
+func (d *DynamoService) GetAllWebhookSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	if tenantID, scoped := listingTenant(ctx); scoped && d.webhookIndexesReady {
+		items, err := d.queryAll(ctx, &dynamodb.QueryInput{
+			TableName:              aws.String(d.webhookSubscriptionsTable()),
+			IndexName:              aws.String(dynamoSubscriptionTenantIndex),
+			KeyConditionExpression: aws.String("tenant_id = :tenant"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":tenant": &types.AttributeValueMemberS{Value: tenantID},
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query webhook subscriptions: %w", err)
+		}
+		return unmarshalWebhookSubscriptions(items), nil
+	}
+
+	items, err := d.scanAll(ctx, &dynamodb.ScanInput{
+		TableName: aws.String(d.webhookSubscriptionsTable()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan webhook subscriptions: %w", err)
+	}
+
+	subscriptions := unmarshalWebhookSubscriptions(items)
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].CreatedAt.Before(subscriptions[j].CreatedAt)
+	})
+
+	return subscriptions, nil
+}
+
+func (d *DynamoService) DeleteWebhookSubscription(ctx context.Context, id string) error {
+	result, err := d.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(d.webhookSubscriptionsTable()),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: id},
+		},
+		ReturnValues: types.ReturnValueAllOld,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+
+	if len(result.Attributes) == 0 {
+		return ErrWebhookSubscriptionNotFound
+	}
+
+	deliveries, err := d.GetWebhookDeliveriesBySubscription(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	for _, delivery := range deliveries {
+		_, err := d.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+			TableName: aws.String(d.webhookDeliveriesTable()),
+			Key: map[string]types.AttributeValue{
+				"id": &types.AttributeValueMemberS{Value: delivery.ID},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete webhook delivery: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// SaveWebhookDelivery stores the delivery together with the keys of its indexes and its expiry,
+// the retention after its creation or next attempt, whichever is later
+func (d *DynamoService) SaveWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	item, err := attributevalue.MarshalMap(delivery)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook delivery: %w", err)
+	}
+	item["created_at_key"] = &types.AttributeValueMemberS{Value: sortableTime(delivery.CreatedAt)}
+	item["next_attempt_key"] = &types.AttributeValueMemberS{Value: sortableTime(delivery.NextAttemptAt)}
+	lastActivity := delivery.CreatedAt
+	if delivery.NextAttemptAt.After(lastActivity) {
+		lastActivity = delivery.NextAttemptAt
+	}
+	item[dynamoDeliveryExpiryAttribute] = &types.AttributeValueMemberN{Value: strconv.FormatInt(lastActivity.Add(d.webhookDeliveryRetention).Unix(), 10)}
+
+	_, err = d.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(d.webhookDeliveriesTable()),
+		Item:      item,
+	})
+	if err != nil {
+		log.Printf("[DynamoService] Failed to save webhook delivery to DynamoDB: %v", err)
+		return fmt.Errorf("failed to save webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+// GetWebhookDeliveriesBySubscription queries the subscription index, oldest delivery first
+func (d *DynamoService) GetWebhookDeliveriesBySubscription(ctx context.Context, subscriptionID string) ([]models.WebhookDelivery, error) {
+	if !d.webhookIndexesReady {
+		return d.scanWebhookDeliveries(ctx, "subscription_id", subscriptionID)
+	}
+
+	items, err := d.queryAll(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(d.webhookDeliveriesTable()),
+		IndexName:              aws.String(dynamoDeliverySubscriptionIndex),
+		KeyConditionExpression: aws.String("subscription_id = :subscription"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":subscription": &types.AttributeValueMemberS{Value: subscriptionID},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook deliveries: %w", err)
+	}
+	return unmarshalWebhookDeliveries(items), nil
+}
+
+// GetDueWebhookDeliveries queries the pending partition of the due index up to now, so the
+// dispatcher only reads the deliveries it is about to send
+func (d *DynamoService) GetDueWebhookDeliveries(ctx context.Context, now time.Time) ([]models.WebhookDelivery, error) {
+	if d.webhookIndexesReady {
+		items, err := d.queryAll(ctx, &dynamodb.QueryInput{
+			TableName:              aws.String(d.webhookDeliveriesTable()),
+			IndexName:              aws.String(dynamoDeliveryDueIndex),
+			KeyConditionExpression: aws.String("#status = :pending AND next_attempt_key <= :now"),
+			ExpressionAttributeNames: map[string]string{
+				"#status": "status",
+			},
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":pending": &types.AttributeValueMemberS{Value: models.DeliveryStatusPending},
+				":now":     &types.AttributeValueMemberS{Value: sortableTime(now)},
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query due webhook deliveries: %w", err)
+		}
+		return unmarshalWebhookDeliveries(items), nil
+	}
+
+	pendingDeliveries, err := d.scanWebhookDeliveries(ctx, "status", models.DeliveryStatusPending)
+	if err != nil {
+		return nil, err
+	}
+
+	// Timestamps are stored as RFC 3339 strings of varying width, so compare them here
+	// rather than in the filter expression
+	var dueDeliveries []models.WebhookDelivery
+	for _, delivery := range pendingDeliveries {
+		if !delivery.NextAttemptAt.After(now) {
+			dueDeliveries = append(dueDeliveries, delivery)
+		}
+	}
+
+	return dueDeliveries, nil
+}
+
+func (d *DynamoService) scanWebhookDeliveries(ctx context.Context, attribute string, value string) ([]models.WebhookDelivery, error) {
+	items, err := d.scanAll(ctx, &dynamodb.ScanInput{
+		TableName:        aws.String(d.webhookDeliveriesTable()),
+		FilterExpression: aws.String("#attribute = :value"),
+		ExpressionAttributeNames: map[string]string{
+			"#attribute": attribute,
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":value": &types.AttributeValueMemberS{Value: value},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan webhook deliveries: %w", err)
+	}
+
+	deliveries := unmarshalWebhookDeliveries(items)
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedAt.Before(deliveries[j].CreatedAt)
+	})
+
+	return deliveries, nil
+}
+
+func unmarshalWebhookSubscriptions(items []map[string]types.AttributeValue) []models.WebhookSubscription {
+	var subscriptions []models.WebhookSubscription
+	for _, item := range items {
+		var subscription models.WebhookSubscription
+		if err := attributevalue.UnmarshalMap(item, &subscription); err != nil {
+			continue // Skip items that can't be unmarshaled
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions
+}
+
+func unmarshalWebhookDeliveries(items []map[string]types.AttributeValue) []models.WebhookDelivery {
+	var deliveries []models.WebhookDelivery
+	for _, item := range items {
+		var delivery models.WebhookDelivery
+		if err := attributevalue.UnmarshalMap(item, &delivery); err != nil {
+			continue // Skip items that can't be unmarshaled
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries
+}
+
+// queryAll follows LastEvaluatedKey until every item matching the query has been read
+func (d *DynamoService) queryAll(ctx context.Context, input *dynamodb.QueryInput) ([]map[string]types.AttributeValue, error) {
+	var items []map[string]types.AttributeValue
+
+	paginator := dynamodb.NewQueryPaginator(d.client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page.Items...)
+	}
+
+	return items, nil
+}
+
+// scanAll follows LastEvaluatedKey until the whole table has been read
+func (d *DynamoService) scanAll(ctx context.Context, input *dynamodb.ScanInput) ([]map[string]types.AttributeValue, error) {
+	var items []map[string]types.AttributeValue
+
+	paginator := dynamodb.NewScanPaginator(d.client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page.Items...)
+	}
+
+	return items, nil
+}
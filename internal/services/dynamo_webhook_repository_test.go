@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"business-card-reader/internal/models"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func deliveryIDs(deliveries []models.WebhookDelivery) []string {
+	ids := make([]string, len(deliveries))
+	for i, delivery := range deliveries {
+		ids[i] = delivery.ID
+	}
+	return ids
+}
+
+// seedWebhookDeliveries stores two subscriptions and their deliveries, of which due-1 and due-2 are due at now
+func seedWebhookDeliveries(t *testing.T, repository WebhookRepository, now time.Time) {
+	t.Helper()
+	ctx := context.Background()
+
+	for _, subscription := range []models.WebhookSubscription{
+		{ID: "acme-hook", TenantID: "acme", URL: "https://hooks.acme.com", Active: true, CreatedAt: now.Add(-time.Hour)},
+		{ID: "globex-hook", TenantID: "globex", URL: "https://hooks.globex.com", Active: true, CreatedAt: now.Add(-time.Hour)},
+	} {
+		if err := repository.SaveWebhookSubscription(ctx, &subscription); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deliveredAt := now.Add(-time.Minute)
+	for _, delivery := range []models.WebhookDelivery{
+		{ID: "due-1", SubscriptionID: "acme-hook", Status: models.DeliveryStatusPending, NextAttemptAt: now.Add(-time.Minute), CreatedAt: now.Add(-3 * time.Minute)},
+		{ID: "later", SubscriptionID: "acme-hook", Status: models.DeliveryStatusPending, NextAttemptAt: now.Add(time.Hour), CreatedAt: now.Add(-2 * time.Minute)},
+		{ID: "delivered", SubscriptionID: "acme-hook", Status: models.DeliveryStatusDelivered, NextAttemptAt: now.Add(-time.Minute), CreatedAt: now.Add(-time.Minute), DeliveredAt: &deliveredAt},
+		{ID: "due-2", SubscriptionID: "globex-hook", Status: models.DeliveryStatusPending, NextAttemptAt: now, CreatedAt: now.Add(-time.Minute)},
+	} {
+		if err := repository.SaveWebhookDelivery(ctx, &delivery); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDynamoWebhookRepositoryQueriesIndexes(t *testing.T) {
+	repository, client := newTestDynamoService(t)
+	repository.SetWebhookDeliveryRetention(7 * 24 * time.Hour)
+	now := time.Now()
+	seedWebhookDeliveries(t, repository, now)
+
+	itemsRead := client.ItemsRead()
+	due, err := repository.GetDueWebhookDeliveries(context.Background(), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := deliveryIDs(due); !slices.Equal(got, []string{"due-1", "due-2"}) {
+		t.Errorf("due deliveries = %v, want due-1 and due-2", got)
+	}
+	if read := client.ItemsRead() - itemsRead; read != 2 {
+		t.Errorf("dispatcher read %d deliveries, want only the 2 due ones", read)
+	}
+
+	deliveries, err := repository.GetWebhookDeliveriesBySubscription(context.Background(), "acme-hook")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := deliveryIDs(deliveries); !slices.Equal(got, []string{"due-1", "later", "delivered"}) {
+		t.Errorf("delivery log = %v, want it oldest first", got)
+	}
+
+	subscriptions, err := repository.GetAllWebhookSubscriptions(WithTenant(context.Background(), "acme"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subscriptions) != 1 || subscriptions[0].ID != "acme-hook" {
+		t.Errorf("acme's subscriptions = %+v", subscriptions)
+	}
+
+	if scans := client.Scans(); scans != 0 {
+		t.Errorf("webhook lookups scanned a table %d times", scans)
+	}
+
+	t.Run("deliveries expire", func(t *testing.T) {
+		ttl, err := client.DescribeTimeToLive(context.Background(), &dynamodb.DescribeTimeToLiveInput{TableName: aws.String(repository.webhookDeliveriesTable())})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if description := ttl.TimeToLiveDescription; description.TimeToLiveStatus != types.TimeToLiveStatusEnabled || aws.ToString(description.AttributeName) != "expires_at" {
+			t.Errorf("time to live = %+v, want enabled on expires_at", description)
+		}
+
+		item, err := client.GetItem(context.Background(), &dynamodb.GetItemInput{
+			TableName: aws.String(repository.webhookDeliveriesTable()),
+			Key:       map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "later"}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := strconv.FormatInt(now.Add(time.Hour+7*24*time.Hour).Unix(), 10)
+		if expiresAt, _ := item.Item["expires_at"].(*types.AttributeValueMemberN); expiresAt == nil || expiresAt.Value != want {
+			t.Errorf("expires_at = %v, want the retention after the next attempt (%s)", item.Item["expires_at"], want)
+		}
+	})
+}
+
+func TestDynamoWebhookTablesMigration(t *testing.T) {
+	ctx := context.Background()
+	repository, client := newTestDynamoService(t)
+
+	// Tables of an older version: no indexes, no time to live, items without the index keys
+	for _, index := range webhookSubscriptionIndexes {
+		client.DropIndex(repository.webhookSubscriptionsTable(), index.name)
+	}
+	for _, index := range webhookDeliveryIndexes {
+		client.DropIndex(repository.webhookDeliveriesTable(), index.name)
+	}
+	if _, err := client.UpdateTimeToLive(ctx, &dynamodb.UpdateTimeToLiveInput{
+		TableName:               aws.String(repository.webhookDeliveriesTable()),
+		TimeToLiveSpecification: &types.TimeToLiveSpecification{AttributeName: aws.String("expires_at"), Enabled: aws.Bool(false)},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	legacySubscription := models.WebhookSubscription{ID: "legacy-hook", URL: "https://hooks.example.com", Active: true, CreatedAt: now}
+	legacyDelivery := models.WebhookDelivery{ID: "legacy", SubscriptionID: "legacy-hook", Status: models.DeliveryStatusPending, NextAttemptAt: now, CreatedAt: now}
+	for table, record := range map[string]interface{}{
+		repository.webhookSubscriptionsTable(): legacySubscription,
+		repository.webhookDeliveriesTable():    legacyDelivery,
+	} {
+		item, err := attributevalue.MarshalMap(record)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.PutItem(ctx, &dynamodb.PutItemInput{TableName: aws.String(table), Item: item}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	older := NewDynamoServiceWithClient(client, "business-card-reader")
+	if err := older.CreateTableIfNotExists(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if due, err := older.GetDueWebhookDeliveries(ctx, now); err != nil || len(due) != 1 {
+		t.Fatalf("before migration: %d due deliveries (%v), want the legacy one", len(due), err)
+	}
+
+	if err := older.Migrate(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	scans := client.Scans()
+	if due, err := older.GetDueWebhookDeliveries(ctx, now); err != nil || len(due) != 1 {
+		t.Errorf("after migration: %d due deliveries (%v), want the legacy one", len(due), err)
+	}
+	if subscriptions, err := older.GetAllWebhookSubscriptions(WithTenant(ctx, DefaultTenant)); err != nil || len(subscriptions) != 1 {
+		t.Errorf("after migration: %d subscriptions of the default tenant (%v), want the legacy one", len(subscriptions), err)
+	}
+	if client.Scans() != scans {
+		t.Error("webhook lookups still scan after the migration")
+	}
+
+	ttl, err := client.DescribeTimeToLive(ctx, &dynamodb.DescribeTimeToLiveInput{TableName: aws.String(repository.webhookDeliveriesTable())})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ttl.TimeToLiveDescription.TimeToLiveStatus != types.TimeToLiveStatusEnabled {
+		t.Error("migration didn't turn on the expiry of deliveries")
+	}
+}
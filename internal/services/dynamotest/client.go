@@ -0,0 +1,477 @@
+// Package dynamotest provides an in-memory stand-in for the DynamoDB API used by
+// services.DynamoService, so tests can run the DynamoDB repository without AWS. It covers the
+// operations and expressions the repository uses, not DynamoDB as a whole.
+package dynamotest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Client keeps tables in memory. Secondary indexes are active as soon as they are created.
+type Client struct {
+	mu     sync.Mutex
+	tables map[string]*table
+	// itemsRead counts the items scans and queries evaluated before filtering, which DynamoDB bills
+	itemsRead int
+	scans     int
+}
+
+type table struct {
+	hashKey string
+	indexes map[string]index
+	items   map[string]map[string]types.AttributeValue
+	// timeToLive names the expiry attribute once the time to live is turned on. Expired items
+	// are kept, as DynamoDB may keep them for days.
+	timeToLive string
+}
+
+type index struct {
+	hashKey  string
+	rangeKey string
+}
+
+func NewClient() *Client {
+	return &Client{tables: make(map[string]*table)}
+}
+
+func (c *Client) CreateTable(ctx context.Context, input *dynamodb.CreateTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.CreateTableOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	tableName := aws.ToString(input.TableName)
+	if _, exists := c.tables[tableName]; exists {
+		return nil, &types.ResourceInUseException{Message: aws.String("table already exists: " + tableName)}
+	}
+
+	t := &table{
+		indexes: make(map[string]index),
+		items:   make(map[string]map[string]types.AttributeValue),
+	}
+	t.hashKey, _ = keySchema(input.KeySchema)
+	if t.hashKey == "" {
+		return nil, fmt.Errorf("table %s has no hash key", tableName)
+	}
+	for _, definition := range input.GlobalSecondaryIndexes {
+		hashKey, rangeKey := keySchema(definition.KeySchema)
+		t.indexes[aws.ToString(definition.IndexName)] = index{hashKey: hashKey, rangeKey: rangeKey}
+	}
+	c.tables[tableName] = t
+
+	return &dynamodb.CreateTableOutput{}, nil
+}
+
+func (c *Client) DescribeTable(ctx context.Context, input *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t, err := c.table(input.TableName)
+	if err != nil {
+		return nil, err
+	}
+
+	description := &types.TableDescription{
+		TableName:   input.TableName,
+		TableStatus: types.TableStatusActive,
+		ItemCount:   aws.Int64(int64(len(t.items))),
+	}
+	names := make([]string, 0, len(t.indexes))
+	for name := range t.indexes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		description.GlobalSecondaryIndexes = append(description.GlobalSecondaryIndexes, types.GlobalSecondaryIndexDescription{
+			IndexName:   aws.String(name),
+			IndexStatus: types.IndexStatusActive,
+		})
+	}
+
+	return &dynamodb.DescribeTableOutput{Table: description}, nil
+}
+
+func (c *Client) UpdateTable(ctx context.Context, input *dynamodb.UpdateTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateTableOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t, err := c.table(input.TableName)
+	if err != nil {
+		return nil, err
+	}
+	for _, update := range input.GlobalSecondaryIndexUpdates {
+		switch {
+		case update.Create != nil:
+			hashKey, rangeKey := keySchema(update.Create.KeySchema)
+			t.indexes[aws.ToString(update.Create.IndexName)] = index{hashKey: hashKey, rangeKey: rangeKey}
+		case update.Delete != nil:
+			delete(t.indexes, aws.ToString(update.Delete.IndexName))
+		}
+	}
+
+	return &dynamodb.UpdateTableOutput{}, nil
+}
+
+func (c *Client) DescribeTimeToLive(ctx context.Context, input *dynamodb.DescribeTimeToLiveInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTimeToLiveOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t, err := c.table(input.TableName)
+	if err != nil {
+		return nil, err
+	}
+
+	description := &types.TimeToLiveDescription{TimeToLiveStatus: types.TimeToLiveStatusDisabled}
+	if t.timeToLive != "" {
+		description = &types.TimeToLiveDescription{
+			AttributeName:    aws.String(t.timeToLive),
+			TimeToLiveStatus: types.TimeToLiveStatusEnabled,
+		}
+	}
+	return &dynamodb.DescribeTimeToLiveOutput{TimeToLiveDescription: description}, nil
+}
+
+func (c *Client) UpdateTimeToLive(ctx context.Context, input *dynamodb.UpdateTimeToLiveInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateTimeToLiveOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t, err := c.table(input.TableName)
+	if err != nil {
+		return nil, err
+	}
+
+	specification := input.TimeToLiveSpecification
+	enable := aws.ToBool(specification.Enabled)
+	if enable == (t.timeToLive != "") {
+		return nil, fmt.Errorf("time to live is already %s on table %s", map[bool]string{true: "enabled", false: "disabled"}[enable], aws.ToString(input.TableName))
+	}
+	t.timeToLive = ""
+	if enable {
+		t.timeToLive = aws.ToString(specification.AttributeName)
+	}
+
+	return &dynamodb.UpdateTimeToLiveOutput{TimeToLiveSpecification: specification}, nil
+}
+
+// ItemsRead returns how many items scans and queries have evaluated so far, filtered out or not
+func (c *Client) ItemsRead() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.itemsRead
+}
+
+// Scans returns how many Scan calls were made so far
+func (c *Client) Scans() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.scans
+}
+
+// DropIndex removes a secondary index, as on a table created by an older version
+func (c *Client) DropIndex(tableName string, name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if t, ok := c.tables[tableName]; ok {
+		delete(t.indexes, name)
+	}
+}
+
+func (c *Client) PutItem(ctx context.Context, input *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t, err := c.table(input.TableName)
+	if err != nil {
+		return nil, err
+	}
+	key, err := t.key(input.Item)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.ConditionExpression != nil {
+		matches, err := parseCondition(aws.ToString(input.ConditionExpression), input.ExpressionAttributeNames, input.ExpressionAttributeValues)
+		if err != nil {
+			return nil, err
+		}
+		existing := t.items[key]
+		if existing == nil {
+			existing = map[string]types.AttributeValue{}
+		}
+		if !matches(existing) {
+			return nil, &types.ConditionalCheckFailedException{Message: aws.String("the conditional request failed")}
+		}
+	}
+
+	output := &dynamodb.PutItemOutput{}
+	if input.ReturnValues == types.ReturnValueAllOld {
+		output.Attributes = copyItem(t.items[key])
+	}
+	t.items[key] = copyItem(input.Item)
+
+	return output, nil
+}
+
+func (c *Client) GetItem(ctx context.Context, input *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t, err := c.table(input.TableName)
+	if err != nil {
+		return nil, err
+	}
+	key, err := t.key(input.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dynamodb.GetItemOutput{Item: copyItem(t.items[key])}, nil
+}
+
+func (c *Client) DeleteItem(ctx context.Context, input *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t, err := c.table(input.TableName)
+	if err != nil {
+		return nil, err
+	}
+	key, err := t.key(input.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &dynamodb.DeleteItemOutput{}
+	if input.ReturnValues == types.ReturnValueAllOld {
+		output.Attributes = copyItem(t.items[key])
+	}
+	delete(t.items, key)
+
+	return output, nil
+}
+
+func (c *Client) Scan(ctx context.Context, input *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t, err := c.table(input.TableName)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := parseCondition(aws.ToString(input.FilterExpression), input.ExpressionAttributeNames, input.ExpressionAttributeValues)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]map[string]types.AttributeValue, 0, len(t.items))
+	for _, key := range t.sortedKeys() {
+		candidates = append(candidates, t.items[key])
+	}
+
+	page, lastEvaluated, err := t.page(candidates, input.ExclusiveStartKey, input.Limit, index{})
+	if err != nil {
+		return nil, err
+	}
+	c.scans++
+	c.itemsRead += len(page)
+	output := &dynamodb.ScanOutput{ScannedCount: int32(len(page)), LastEvaluatedKey: lastEvaluated}
+	for _, item := range page {
+		if filter(item) {
+			output.Items = append(output.Items, copyItem(item))
+		}
+	}
+	output.Count = int32(len(output.Items))
+
+	return output, nil
+}
+
+func (c *Client) Query(ctx context.Context, input *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t, err := c.table(input.TableName)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := index{hashKey: t.hashKey}
+	if input.IndexName != nil {
+		var ok bool
+		if keys, ok = t.indexes[aws.ToString(input.IndexName)]; !ok {
+			return nil, fmt.Errorf("table %s has no index %s", aws.ToString(input.TableName), aws.ToString(input.IndexName))
+		}
+	}
+
+	keyCondition, err := parseCondition(aws.ToString(input.KeyConditionExpression), input.ExpressionAttributeNames, input.ExpressionAttributeValues)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := parseCondition(aws.ToString(input.FilterExpression), input.ExpressionAttributeNames, input.ExpressionAttributeValues)
+	if err != nil {
+		return nil, err
+	}
+
+	// An index holds only the items carrying its key attributes
+	var candidates []map[string]types.AttributeValue
+	for _, key := range t.sortedKeys() {
+		item := t.items[key]
+		if _, ok := item[keys.hashKey]; !ok {
+			continue
+		}
+		if _, ok := item[keys.rangeKey]; keys.rangeKey != "" && !ok {
+			continue
+		}
+		if keyCondition(item) {
+			candidates = append(candidates, item)
+		}
+	}
+	if keys.rangeKey != "" {
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return compare(candidates[i][keys.rangeKey], candidates[j][keys.rangeKey]) < 0
+		})
+	}
+	if input.ScanIndexForward != nil && !*input.ScanIndexForward {
+		for i, j := 0, len(candidates)-1; i < j; i, j = i+1, j-1 {
+			candidates[i], candidates[j] = candidates[j], candidates[i]
+		}
+	}
+
+	page, lastEvaluated, err := t.page(candidates, input.ExclusiveStartKey, input.Limit, keys)
+	if err != nil {
+		return nil, err
+	}
+	c.itemsRead += len(page)
+	output := &dynamodb.QueryOutput{ScannedCount: int32(len(page)), LastEvaluatedKey: lastEvaluated}
+	for _, item := range page {
+		if filter(item) {
+			output.Items = append(output.Items, copyItem(item))
+		}
+	}
+	output.Count = int32(len(output.Items))
+
+	return output, nil
+}
+
+func (c *Client) table(name *string) (*table, error) {
+	t, ok := c.tables[aws.ToString(name)]
+	if !ok {
+		return nil, &types.ResourceNotFoundException{Message: aws.String("table not found: " + aws.ToString(name))}
+	}
+	return t, nil
+}
+
+func (t *table) key(item map[string]types.AttributeValue) (string, error) {
+	value, ok := item[t.hashKey].(*types.AttributeValueMemberS)
+	if !ok {
+		return "", fmt.Errorf("item has no string key %s", t.hashKey)
+	}
+	return value.Value, nil
+}
+
+func (t *table) sortedKeys() []string {
+	keys := make([]string, 0, len(t.items))
+	for key := range t.items {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// page evaluates up to limit items after the start key, like DynamoDB before applying the filter,
+// and returns the key to continue from when items are left
+func (t *table) page(items []map[string]types.AttributeValue, startKey map[string]types.AttributeValue, limit *int32, keys index) ([]map[string]types.AttributeValue, map[string]types.AttributeValue, error) {
+	if startKey != nil {
+		start, err := t.key(startKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		position := -1
+		for i, item := range items {
+			if key, _ := t.key(item); key == start {
+				position = i
+				break
+			}
+		}
+		if position < 0 {
+			return nil, nil, fmt.Errorf("exclusive start key %s not found", start)
+		}
+		items = items[position+1:]
+	}
+
+	if limit == nil || int(*limit) >= len(items) {
+		return items, nil, nil
+	}
+
+	items = items[:*limit]
+	last := items[len(items)-1]
+	lastEvaluated := map[string]types.AttributeValue{t.hashKey: last[t.hashKey]}
+	for _, name := range []string{keys.hashKey, keys.rangeKey} {
+		if name != "" {
+			lastEvaluated[name] = last[name]
+		}
+	}
+	return items, lastEvaluated, nil
+}
+
+func keySchema(schema []types.KeySchemaElement) (hashKey string, rangeKey string) {
+	for _, element := range schema {
+		switch element.KeyType {
+		case types.KeyTypeHash:
+			hashKey = aws.ToString(element.AttributeName)
+		case types.KeyTypeRange:
+			rangeKey = aws.ToString(element.AttributeName)
+		}
+	}
+	return hashKey, rangeKey
+}
+
+// copyItem copies the top level of an item; stored attribute values are never modified in place
+func copyItem(item map[string]types.AttributeValue) map[string]types.AttributeValue {
+	if item == nil {
+		return nil
+	}
+	copied := make(map[string]types.AttributeValue, len(item))
+	for name, value := range item {
+		copied[name] = value
+	}
+	return copied
+}
+
+// attributeAt resolves a document path such as personal_data.email
+func attributeAt(item map[string]types.AttributeValue, path []string) (types.AttributeValue, bool) {
+	value, ok := item[path[0]]
+	for _, name := range path[1:] {
+		if !ok {
+			return nil, false
+		}
+		member, isMap := value.(*types.AttributeValueMemberM)
+		if !isMap {
+			return nil, false
+		}
+		value, ok = member.Value[name]
+	}
+	return value, ok
+}
+
+func splitPath(path string, names map[string]string) ([]string, error) {
+	parts := strings.Split(path, ".")
+	for i, part := range parts {
+		if strings.HasPrefix(part, "#") {
+			name, ok := names[part]
+			if !ok {
+				return nil, fmt.Errorf("expression attribute name %s is not defined", part)
+			}
+			parts[i] = name
+		}
+	}
+	return parts, nil
+}
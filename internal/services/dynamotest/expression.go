@@ -0,0 +1,358 @@
+package dynamotest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// condition evaluates a key condition or filter expression against an item
+type condition func(item map[string]types.AttributeValue) bool
+
+// operand resolves an attribute path or expression value against an item
+type operand func(item map[string]types.AttributeValue) (types.AttributeValue, bool)
+
+// parseCondition compiles a condition expression with comparisons, BETWEEN, AND, OR, NOT,
+// parentheses and the attribute_exists, attribute_not_exists, begins_with and contains functions.
+// An empty expression matches every item.
+func parseCondition(expression string, names map[string]string, values map[string]types.AttributeValue) (condition, error) {
+	if strings.TrimSpace(expression) == "" {
+		return func(map[string]types.AttributeValue) bool { return true }, nil
+	}
+
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, names: names, values: values}
+	parsed, err := p.or()
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %q: %w", expression, err)
+	}
+	if p.position < len(p.tokens) {
+		return nil, fmt.Errorf("invalid expression %q: unexpected %q", expression, p.tokens[p.position])
+	}
+	return parsed, nil
+}
+
+func tokenize(expression string) ([]string, error) {
+	var tokens []string
+	runes := []rune(expression)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')' || r == ',' || r == '=':
+			tokens = append(tokens, string(r))
+			i++
+		case r == '<' || r == '>':
+			if i+1 < len(runes) && (runes[i+1] == '=' || (r == '<' && runes[i+1] == '>')) {
+				tokens = append(tokens, string(runes[i:i+2]))
+				i += 2
+			} else {
+				tokens = append(tokens, string(r))
+				i++
+			}
+		case r == ':' || r == '#' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			start := i
+			for i++; i < len(runes) && (runes[i] == '.' || runes[i] == '#' || runes[i] == '_' || unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])); i++ {
+			}
+			tokens = append(tokens, string(runes[start:i]))
+		default:
+			return nil, fmt.Errorf("unexpected character %q", r)
+		}
+	}
+	return tokens, nil
+}
+
+type parser struct {
+	tokens   []string
+	position int
+	names    map[string]string
+	values   map[string]types.AttributeValue
+}
+
+func (p *parser) peek() string {
+	if p.position < len(p.tokens) {
+		return p.tokens[p.position]
+	}
+	return ""
+}
+
+func (p *parser) keyword(word string) bool {
+	if strings.EqualFold(p.peek(), word) {
+		p.position++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(token string) error {
+	if p.peek() != token {
+		return fmt.Errorf("expected %q, got %q", token, p.peek())
+	}
+	p.position++
+	return nil
+}
+
+func (p *parser) or() (condition, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("OR") {
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(item map[string]types.AttributeValue) bool { return l(item) || right(item) }
+	}
+	return left, nil
+}
+
+func (p *parser) and() (condition, error) {
+	left, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("AND") {
+		right, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(item map[string]types.AttributeValue) bool { return l(item) && right(item) }
+	}
+	return left, nil
+}
+
+func (p *parser) not() (condition, error) {
+	if p.keyword("NOT") {
+		negated, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		return func(item map[string]types.AttributeValue) bool { return !negated(item) }, nil
+	}
+	return p.primary()
+}
+
+func (p *parser) primary() (condition, error) {
+	if p.peek() == "(" {
+		p.position++
+		inner, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		return inner, p.expect(")")
+	}
+
+	if p.position+1 < len(p.tokens) && p.tokens[p.position+1] == "(" {
+		return p.function()
+	}
+
+	left, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.keyword("BETWEEN") {
+		low, err := p.operand()
+		if err != nil {
+			return nil, err
+		}
+		if !p.keyword("AND") {
+			return nil, fmt.Errorf("BETWEEN without AND")
+		}
+		high, err := p.operand()
+		if err != nil {
+			return nil, err
+		}
+		return func(item map[string]types.AttributeValue) bool {
+			value, ok := left(item)
+			lowValue, lowOK := low(item)
+			highValue, highOK := high(item)
+			return ok && lowOK && highOK && sameType(value, lowValue) && sameType(value, highValue) &&
+				compare(value, lowValue) >= 0 && compare(value, highValue) <= 0
+		}, nil
+	}
+
+	comparator := p.peek()
+	var matches func(int) bool
+	switch comparator {
+	case "=":
+		matches = func(c int) bool { return c == 0 }
+	case "<>":
+		matches = func(c int) bool { return c != 0 }
+	case "<":
+		matches = func(c int) bool { return c < 0 }
+	case "<=":
+		matches = func(c int) bool { return c <= 0 }
+	case ">":
+		matches = func(c int) bool { return c > 0 }
+	case ">=":
+		matches = func(c int) bool { return c >= 0 }
+	default:
+		return nil, fmt.Errorf("expected a comparator, got %q", comparator)
+	}
+	p.position++
+
+	right, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	return func(item map[string]types.AttributeValue) bool {
+		leftValue, leftOK := left(item)
+		rightValue, rightOK := right(item)
+		if !leftOK || !rightOK || !sameType(leftValue, rightValue) {
+			return false
+		}
+		return matches(compare(leftValue, rightValue))
+	}, nil
+}
+
+func (p *parser) function() (condition, error) {
+	name := strings.ToLower(p.peek())
+	p.position += 2
+
+	var arguments []operand
+	for {
+		argument, err := p.operand()
+		if err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, argument)
+		if p.peek() != "," {
+			break
+		}
+		p.position++
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case name == "attribute_exists" && len(arguments) == 1:
+		return func(item map[string]types.AttributeValue) bool {
+			_, ok := arguments[0](item)
+			return ok
+		}, nil
+	case name == "attribute_not_exists" && len(arguments) == 1:
+		return func(item map[string]types.AttributeValue) bool {
+			_, ok := arguments[0](item)
+			return !ok
+		}, nil
+	case name == "begins_with" && len(arguments) == 2:
+		return func(item map[string]types.AttributeValue) bool {
+			value, ok := arguments[0](item)
+			prefix, prefixOK := arguments[1](item)
+			s, isString := value.(*types.AttributeValueMemberS)
+			sPrefix, prefixIsString := prefix.(*types.AttributeValueMemberS)
+			return ok && prefixOK && isString && prefixIsString && strings.HasPrefix(s.Value, sPrefix.Value)
+		}, nil
+	case name == "contains" && len(arguments) == 2:
+		return func(item map[string]types.AttributeValue) bool {
+			value, ok := arguments[0](item)
+			needle, needleOK := arguments[1](item)
+			return ok && needleOK && contains(value, needle)
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported function %s with %d arguments", name, len(arguments))
+}
+
+func (p *parser) operand() (operand, error) {
+	token := p.peek()
+	if token == "" || strings.ContainsAny(token[:1], "(),=<>") {
+		return nil, fmt.Errorf("expected an operand, got %q", token)
+	}
+	p.position++
+
+	if strings.HasPrefix(token, ":") {
+		value, ok := p.values[token]
+		if !ok {
+			return nil, fmt.Errorf("expression attribute value %s is not defined", token)
+		}
+		return func(map[string]types.AttributeValue) (types.AttributeValue, bool) { return value, true }, nil
+	}
+
+	path, err := splitPath(token, p.names)
+	if err != nil {
+		return nil, err
+	}
+	return func(item map[string]types.AttributeValue) (types.AttributeValue, bool) {
+		return attributeAt(item, path)
+	}, nil
+}
+
+// sameType reports whether two values are scalars of the same type, the only ones compare orders
+func sameType(a, b types.AttributeValue) bool {
+	switch a.(type) {
+	case *types.AttributeValueMemberS:
+		_, ok := b.(*types.AttributeValueMemberS)
+		return ok
+	case *types.AttributeValueMemberN:
+		_, ok := b.(*types.AttributeValueMemberN)
+		return ok
+	case *types.AttributeValueMemberBOOL:
+		_, ok := b.(*types.AttributeValueMemberBOOL)
+		return ok
+	}
+	return false
+}
+
+// compare orders two scalar values of the same type
+func compare(a, b types.AttributeValue) int {
+	switch a := a.(type) {
+	case *types.AttributeValueMemberS:
+		if b, ok := b.(*types.AttributeValueMemberS); ok {
+			return strings.Compare(a.Value, b.Value)
+		}
+	case *types.AttributeValueMemberN:
+		if b, ok := b.(*types.AttributeValueMemberN); ok {
+			x, _ := strconv.ParseFloat(a.Value, 64)
+			y, _ := strconv.ParseFloat(b.Value, 64)
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	case *types.AttributeValueMemberBOOL:
+		if b, ok := b.(*types.AttributeValueMemberBOOL); ok && a.Value == b.Value {
+			return 0
+		}
+		return 1
+	}
+	return 1
+}
+
+func contains(value, needle types.AttributeValue) bool {
+	switch value := value.(type) {
+	case *types.AttributeValueMemberS:
+		if needle, ok := needle.(*types.AttributeValueMemberS); ok {
+			return strings.Contains(value.Value, needle.Value)
+		}
+	case *types.AttributeValueMemberSS:
+		if needle, ok := needle.(*types.AttributeValueMemberS); ok {
+			for _, member := range value.Value {
+				if member == needle.Value {
+					return true
+				}
+			}
+		}
+	case *types.AttributeValueMemberL:
+		for _, member := range value.Value {
+			if sameType(member, needle) && compare(member, needle) == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
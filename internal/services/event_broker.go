@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	"business-card-reader/internal/logger"
+	"business-card-reader/internal/models"
+)
+
+// eventSubscriberBuffer is how many events a slow subscriber may lag behind before events are dropped
+const eventSubscriberBuffer = 16
+
+// EventBroker fans business card status changes out to live subscribers such as SSE streams.
+// Publishing never blocks the processing pipeline: events are dropped for subscribers whose
+// buffer is full.
+type EventBroker struct {
+	mu          sync.RWMutex
+	subscribers map[*eventSubscriber]struct{}
+	closed      bool
+}
+
+type eventSubscriber struct {
+	tenantID       string
+	businessCardID string
+	events         chan models.BusinessCardEvent
+}
+
+func NewEventBroker() *EventBroker {
+	return &EventBroker{
+		subscribers: make(map[*eventSubscriber]struct{}),
+	}
+}
+
+// Subscribe returns a channel of events for one business card of the tenant, or for all of its
+// business cards when businessCardID is empty. The returned function must be called to
+// unsubscribe. The channel is closed on unsubscribe and when the broker is closed.
+func (e *EventBroker) Subscribe(tenantID string, businessCardID string) (<-chan models.BusinessCardEvent, func()) {
+	subscriber := &eventSubscriber{
+		tenantID:       tenantID,
+		businessCardID: businessCardID,
+		events:         make(chan models.BusinessCardEvent, eventSubscriberBuffer),
+	}
+
+	e.mu.Lock()
+	if e.closed {
+		close(subscriber.events)
+	} else {
+		e.subscribers[subscriber] = struct{}{}
+	}
+	e.mu.Unlock()
+
+	unsubscribe := func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+
+		if _, ok := e.subscribers[subscriber]; ok {
+			delete(e.subscribers, subscriber)
+			close(subscriber.events)
+		}
+	}
+
+	return subscriber.events, unsubscribe
+}
+
+// OnBusinessCardEvent publishes the event to every matching subscriber
+func (e *EventBroker) OnBusinessCardEvent(ctx context.Context, event models.BusinessCardEvent) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	for subscriber := range e.subscribers {
+		if subscriber.tenantID != recordTenant(event.BusinessCard.TenantID) {
+			continue
+		}
+		if subscriber.businessCardID != "" && subscriber.businessCardID != event.BusinessCardID {
+			continue
+		}
+
+		select {
+		case subscriber.events <- event:
+		default:
+			logger.LogWarn("EventBroker", "Subscriber is too slow, dropping event", map[string]interface{}{
+				"business_card_id": event.BusinessCardID,
+				"status":           event.Status,
+			})
+		}
+	}
+}
+
+// Close ends every open subscription, letting long-lived streams finish during shutdown
+func (e *EventBroker) Close() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.closed = true
+	for subscriber := range e.subscribers {
+		delete(e.subscribers, subscriber)
+		close(subscriber.events)
+	}
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"business-card-reader/internal/models"
+)
+
+// BusinessCardListener is notified after a business card status change has been saved.
+// Listeners are called synchronously and should hand slow work off to their own goroutines.
+type BusinessCardListener interface {
+	OnBusinessCardEvent(ctx context.Context, event models.BusinessCardEvent)
+}
+
+// AddListener registers a listener for business card status changes
+func (b *BusinessCardService) AddListener(listener BusinessCardListener) {
+	b.listeners = append(b.listeners, listener)
+}
+
+// notifyListeners publishes a status change to every registered listener
+func (b *BusinessCardService) notifyListeners(ctx context.Context, businessCard *models.BusinessCard, previousStatus string) {
+	if len(b.listeners) == 0 {
+		return
+	}
+
+	// Image bytes can be large, listeners only receive their metadata
+	eventCard := *businessCard
+	eventCard.Images = make([]models.ImageData, len(businessCard.Images))
+	for i, image := range businessCard.Images {
+		image.Data = nil
+		eventCard.Images[i] = image
+	}
+
+	event := models.BusinessCardEvent{
+		BusinessCardID: businessCard.ID,
+		Status:         businessCard.Status,
+		PreviousStatus: previousStatus,
+		Timestamp:      time.Now(),
+		BusinessCard:   eventCard,
+	}
+
+	// The request that caused the change may be cancelled before listeners finish
+	listenerCtx := context.WithoutCancel(ctx)
+	for _, listener := range b.listeners {
+		listener.OnBusinessCardEvent(listenerCtx, event)
+	}
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"business-card-reader/internal/logger"
+)
+
+// ErrExtractionBusy is returned when no extraction slot freed up within the configured wait
+var ErrExtractionBusy = errors.New("too many business cards are being extracted")
+
+// errExtractionNotStarted wraps the errors of extractions that never got a slot
+var errExtractionNotStarted = errors.New("extraction not started")
+
+// SetExtractionConcurrency caps the extractions running at once, protecting the extraction
+// provider's quota. A slot is taken right before the extractor runs and given back as soon as it
+// returns. Uploads and retries wait up to wait for a slot and fail with ErrExtractionBusy after
+// that; async workers wait as long as it takes.
+func (b *BusinessCardService) SetExtractionConcurrency(maxConcurrent int, wait time.Duration) {
+	logger.LogInfo("SetExtractionConcurrency", "Extraction concurrency capped", map[string]interface{}{
+		"max_concurrent": maxConcurrent,
+		"wait":           wait.String(),
+	})
+	b.extractionSlots = make(chan struct{}, maxConcurrent)
+	b.extractionWait = wait
+}
+
+// ExtractionRetryAfter is how long clients turned away with ErrExtractionBusy should wait
+func (b *BusinessCardService) ExtractionRetryAfter() time.Duration {
+	return max(b.extractionWait, time.Second)
+}
+
+// acquireExtractionSlot blocks until an extraction slot is free and returns the function releasing
+// it. Without waitForever it gives up with ErrExtractionBusy after the configured wait.
+func (b *BusinessCardService) acquireExtractionSlot(ctx context.Context, waitForever bool) (func(), error) {
+	if b.extractionSlots == nil {
+		return func() {}, nil
+	}
+	release := func() { <-b.extractionSlots }
+
+	select {
+	case b.extractionSlots <- struct{}{}:
+		return release, nil
+	default:
+	}
+
+	var timeout <-chan time.Time
+	if !waitForever {
+		timer := time.NewTimer(b.extractionWait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case b.extractionSlots <- struct{}{}:
+		return release, nil
+	case <-timeout:
+		logger.LogWarn("acquireExtractionSlot", "No extraction slot available", map[string]interface{}{
+			"max_concurrent": cap(b.extractionSlots),
+			"wait":           b.extractionWait.String(),
+		})
+		return nil, ErrExtractionBusy
+	case <-ctx.Done():
+		return nil, fmt.Errorf("stopped waiting for an extraction slot: %w", ctx.Err())
+	}
+}
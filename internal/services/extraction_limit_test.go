@@ -0,0 +1,169 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"business-card-reader/internal/models"
+)
+
+// slowImageStore blocks uploads of the slow image until released
+type slowImageStore struct {
+	*fakeImageStore
+	uploading chan struct{}
+	release   chan struct{}
+}
+
+var slowUpload = []models.ImageUpload{{FileName: "slow.jpg", ContentType: "image/jpeg", Data: []byte("slow jpeg bytes")}}
+
+func (s *slowImageStore) PutImage(ctx context.Context, key string, contentType string, data []byte) error {
+	if bytes.Equal(data, slowUpload[0].Data) {
+		s.uploading <- struct{}{}
+		<-s.release
+	}
+	return s.fakeImageStore.PutImage(ctx, key, contentType, data)
+}
+
+// blockingExtractor holds its extraction until released
+type blockingExtractor struct {
+	extracting chan struct{}
+	release    chan struct{}
+}
+
+func (e *blockingExtractor) Name() string { return "blocking" }
+
+func (e *blockingExtractor) ExtractBusinessCardData(ctx context.Context, images []models.ImageData) (*models.BusinessCard, error) {
+	e.extracting <- struct{}{}
+	<-e.release
+	return &models.BusinessCard{}, nil
+}
+
+func TestImageUploadHoldsNoExtractionSlot(t *testing.T) {
+	ctx := context.Background()
+	imageStore := &slowImageStore{fakeImageStore: newFakeImageStore(), uploading: make(chan struct{}), release: make(chan struct{})}
+	service := NewBusinessCardService(NewMemoryRepository(), &stubExtractor{})
+	service.SetImageStore(imageStore)
+	service.SetExtractionConcurrency(1, 10*time.Millisecond)
+
+	slowDone := make(chan error)
+	go func() {
+		_, err := service.ProcessBusinessCard(ctx, slowUpload)
+		slowDone <- err
+	}()
+	<-imageStore.uploading
+
+	if _, err := service.ProcessBusinessCard(ctx, testUpload); err != nil {
+		t.Errorf("upload next to a slow image upload: %v", err)
+	}
+
+	close(imageStore.release)
+	if err := <-slowDone; err != nil {
+		t.Errorf("slow upload: %v", err)
+	}
+}
+
+func TestBusyExtractionLeavesNoTrace(t *testing.T) {
+	ctx := context.Background()
+	repository := NewMemoryRepository()
+	extractor := &blockingExtractor{extracting: make(chan struct{}), release: make(chan struct{})}
+	service := NewBusinessCardService(repository, extractor)
+	service.SetExtractionConcurrency(1, 10*time.Millisecond)
+
+	failed := models.BusinessCard{ID: "failed", Status: models.StatusFailed, RetryCount: 2, Error: "provider down", CreatedAt: time.Now()}
+	if err := repository.SaveBusinessCard(ctx, &failed); err != nil {
+		t.Fatal(err)
+	}
+
+	// Hold the only slot
+	holderDone := make(chan error)
+	go func() {
+		_, err := service.ProcessBusinessCard(ctx, testUpload)
+		holderDone <- err
+	}()
+	<-extractor.extracting
+	t.Cleanup(func() {
+		close(extractor.release)
+		if err := <-holderDone; err != nil {
+			t.Errorf("upload holding the slot: %v", err)
+		}
+	})
+
+	t.Run("upload is discarded", func(t *testing.T) {
+		if _, err := service.ProcessBusinessCard(ctx, testUpload); !errors.Is(err, ErrExtractionBusy) {
+			t.Fatalf("got %v, want ErrExtractionBusy", err)
+		}
+
+		businessCards, err := repository.GetAllBusinessCards(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, businessCard := range businessCards {
+			if businessCard.ID != "failed" && businessCard.Status != models.StatusProcessing {
+				t.Errorf("busy upload left a %s card behind", businessCard.Status)
+			}
+		}
+		if len(businessCards) != 2 {
+			t.Errorf("got %d business cards, want the failed one and the one being extracted", len(businessCards))
+		}
+	})
+
+	t.Run("retry uses up no attempt", func(t *testing.T) {
+		if _, err := service.RetryFailedProcessing(ctx, "failed"); !errors.Is(err, ErrExtractionBusy) {
+			t.Fatalf("got %v, want ErrExtractionBusy", err)
+		}
+
+		stored, err := repository.GetBusinessCard(ctx, "failed")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stored.Status != models.StatusFailed || stored.RetryCount != 2 || stored.LastRetryAt != nil {
+			t.Errorf("busy retry left status %s, retry count %d, last retry %v", stored.Status, stored.RetryCount, stored.LastRetryAt)
+		}
+	})
+}
+
+func TestFullQueueLeavesNoTrace(t *testing.T) {
+	ctx := context.Background()
+	repository := NewMemoryRepository()
+	imageStore := newFakeImageStore()
+	extractor := &blockingExtractor{extracting: make(chan struct{}, 2), release: make(chan struct{})}
+	service := NewBusinessCardService(repository, extractor)
+	service.SetImageStore(imageStore)
+	if err := service.EnableAsyncProcessing(ctx, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		close(extractor.release)
+		service.Shutdown()
+	})
+
+	// One card is being extracted and one waits in the queue
+	if _, err := service.SubmitBusinessCard(ctx, testUpload); err != nil {
+		t.Fatal(err)
+	}
+	<-extractor.extracting
+	if _, err := service.SubmitBusinessCard(ctx, testUpload); err != nil {
+		t.Fatal(err)
+	}
+
+	businessCard, err := service.SubmitBusinessCard(ctx, testUpload)
+	if !errors.Is(err, ErrProcessingQueueFull) || businessCard != nil {
+		t.Fatalf("got %v and card %v, want ErrProcessingQueueFull and no card", err, businessCard)
+	}
+
+	businessCards, err := repository.GetAllBusinessCards(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(businessCards) != 2 {
+		t.Errorf("got %d business cards, want only the two accepted ones", len(businessCards))
+	}
+	imageStore.mu.Lock()
+	defer imageStore.mu.Unlock()
+	if len(imageStore.objects) != 2 {
+		t.Errorf("image store keeps %d images, want only those of the accepted cards", len(imageStore.objects))
+	}
+}
@@ -0,0 +1,268 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"business-card-reader/internal/config"
+	"business-card-reader/internal/logger"
+	"business-card-reader/internal/models"
+)
+
+// extractorHTTPTimeout bounds a single call to an HTTP based vision model
+const extractorHTTPTimeout = 2 * time.Minute
+
+// Extractor turns business card images into structured contact data
+type Extractor interface {
+	// Name identifies the provider in logs and in the stored business card
+	Name() string
+	ExtractBusinessCardData(ctx context.Context, images []models.ImageData) (*models.BusinessCard, error)
+}
+
+// ExtractorFactory builds an Extractor from the application configuration
+type ExtractorFactory func(cfg *config.Config) (Extractor, error)
+
+var (
+	extractorRegistryMu sync.RWMutex
+	extractorRegistry   = map[string]ExtractorFactory{}
+)
+
+// RegisterExtractor makes an extraction provider available under the given name.
+// Providers register themselves from an init function in their own file.
+func RegisterExtractor(name string, factory ExtractorFactory) {
+	extractorRegistryMu.Lock()
+	defer extractorRegistryMu.Unlock()
+
+	name = strings.ToLower(name)
+	if _, exists := extractorRegistry[name]; exists {
+		panic(fmt.Sprintf("extractor %q registered twice", name))
+	}
+	extractorRegistry[name] = factory
+}
+
+// RegisteredExtractors returns the names of all available extraction providers
+func RegisteredExtractors() []string {
+	extractorRegistryMu.RLock()
+	defer extractorRegistryMu.RUnlock()
+
+	names := make([]string, 0, len(extractorRegistry))
+	for name := range extractorRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewExtractor creates the provider selected by cfg.Extraction.Provider
+func NewExtractor(cfg *config.Config) (Extractor, error) {
+	return newExtractorByName(cfg, cfg.Extraction.Provider)
+}
+
+func newExtractorByName(cfg *config.Config, name string) (Extractor, error) {
+	name = strings.ToLower(name)
+
+	extractorRegistryMu.RLock()
+	factory, ok := extractorRegistry[name]
+	extractorRegistryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unsupported extraction provider %q, available providers: %s", name, strings.Join(RegisteredExtractors(), ", "))
+	}
+
+	logger.LogInfo("NewExtractor", "Initializing extraction provider", map[string]interface{}{
+		"provider": name,
+	})
+
+	return factory(cfg)
+}
+
+// postExtractionRequest sends a JSON request to an HTTP based provider and decodes the JSON reply
+func postExtractionRequest(ctx context.Context, client *http.Client, url string, headers map[string]string, request interface{}, response interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		httpRequest.Header.Set(key, value)
+	}
+
+	httpResponse, err := client.Do(httpRequest)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", url, err)
+	}
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode >= 300 {
+		errorBody, _ := io.ReadAll(io.LimitReader(httpResponse.Body, 4096))
+		return fmt.Errorf("%s returned status %d: %s", url, httpResponse.StatusCode, strings.TrimSpace(string(errorBody)))
+	}
+
+	if err := json.NewDecoder(httpResponse.Body).Decode(response); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// parseExtractionResponse turns a raw model response into a business card
+func parseExtractionResponse(responseText string, images []models.ImageData) (*models.BusinessCard, error) {
+	// Clean the response to extract JSON
+	jsonStr := extractJSONFromResponse(responseText)
+
+	logger.LogDebug("ExtractBusinessCardData", "Extracted JSON from response", map[string]interface{}{
+		"json_length": len(jsonStr),
+	})
+
+	// Parse the extracted data
+	var extractedData struct {
+		PersonalData models.PersonalData        `json:"personal_data"`
+		CompanyData  models.CompanyData         `json:"company_data"`
+		Confidence   map[string]json.RawMessage `json:"confidence"`
+	}
+
+	if err := json.Unmarshal([]byte(jsonStr), &extractedData); err != nil {
+		logger.LogError("ExtractBusinessCardData", err, map[string]interface{}{
+			"step":        "parse_json",
+			"json_string": jsonStr,
+		})
+		return nil, fmt.Errorf("failed to parse extracted data: %w", err)
+	}
+
+	logger.LogInfo("ExtractBusinessCardData", "Business card data extracted successfully", map[string]interface{}{
+		"personal_name": extractedData.PersonalData.FullName,
+		"company_name":  extractedData.CompanyData.Name,
+		"has_email":     extractedData.PersonalData.Email != "",
+		"has_phone":     extractedData.PersonalData.Phone != "",
+	})
+
+	businessCard := &models.BusinessCard{
+		PersonalData:  extractedData.PersonalData,
+		CompanyData:   extractedData.CompanyData,
+		ExtractedText: responseText,
+		Images:        images,
+	}
+	businessCard.FieldConfidence = parseFieldConfidence(businessCard, extractedData.Confidence)
+
+	return businessCard, nil
+}
+
+// buildExtractionPrompt returns the instructions shared by every vision model provider
+func buildExtractionPrompt() string {
+	return `
+You are an expert at extracting information from business cards. Analyze the provided business card image(s) and extract all relevant information.
+
+Please extract the information and return it in the following JSON format:
+
+{
+  "personal_data": {
+    "full_name": "",
+    "first_name": "",
+    "last_name": "",
+    "job_title": "",
+    "department": "",
+    "email": "",
+    "phone": "",
+    "mobile": "",
+    "linkedin": "",
+    "website": ""
+  },
+  "company_data": {
+    "name": "",
+    "industry": "",
+    "website": "",
+    "email": "",
+    "phone": "",
+    "address": {
+      "street": "",
+      "city": "",
+      "state": "",
+      "postal_code": "",
+      "country": "",
+      "full": ""
+    },
+    "social_media": {
+      "linkedin": "",
+      "twitter": "",
+      "facebook": "",
+      "instagram": ""
+    }
+  },
+  "confidence": {}
+}
+
+Rules:
+1. Extract all visible text accurately
+2. If multiple images are provided, combine information from both
+3. Leave fields empty ("") if information is not available
+4. For phone numbers, distinguish between main phone and mobile if possible
+5. For websites, include the full URL if visible
+6. For social media, extract usernames or full URLs
+7. For addresses, provide both individual components and full address
+8. In "confidence", rate every non-empty field from 0 to 1 by how sure you are that it was read correctly, keyed by
+   its path, for example {"personal_data.full_name": 0.98, "company_data.address.postal_code": 0.4}. Use low values
+   for blurry, cut-off or ambiguous text and for values you had to guess
+9. Return ONLY the JSON object, no additional text or formatting
+
+Analyze the business card(s) and extract the information:
+`
+}
+
+// extractJSONFromResponse cuts the first top-level JSON object out of a model response,
+// dropping markdown fences or commentary the model may have added around it
+func extractJSONFromResponse(response string) string {
+	// Find the JSON object in the response
+	start := -1
+	end := -1
+	braceCount := 0
+	// Braces inside string values, such as in a company name, don't count
+	inString := false
+	escaped := false
+
+	for i, char := range response {
+		if inString {
+			if escaped {
+				escaped = false
+			} else if char == '\\' {
+				escaped = true
+			} else if char == '"' {
+				inString = false
+			}
+			continue
+		}
+
+		if char == '"' && start != -1 {
+			inString = true
+		} else if char == '{' {
+			if start == -1 {
+				start = i
+			}
+			braceCount++
+		} else if char == '}' {
+			braceCount--
+			if braceCount == 0 && start != -1 {
+				end = i + 1
+				break
+			}
+		}
+	}
+
+	if start != -1 && end != -1 {
+		return response[start:end]
+	}
+
+	return response
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"business-card-reader/internal/models"
+)
+
+const cannedExtraction = `{
+  "personal_data": {"full_name": "Ada Lovelace", "email": "ada@example.com"},
+  "company_data": {"name": "Analytical Engines Ltd"},
+  "confidence": {"personal_data.full_name": 0.97, "personal_data.email": 0.4}
+}`
+
+var testImages = []models.ImageData{{FileName: "front.jpg", ContentType: "image/jpeg", Data: []byte("jpeg bytes")}}
+
+// extractorStandIn serves every request with the status and body, recording the decoded request
+func extractorStandIn(t *testing.T, path string, status int, body string, request interface{}) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			t.Errorf("request to %s, want %s", r.URL.Path, path)
+		}
+		if request != nil {
+			if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+				t.Errorf("failed to decode request: %v", err)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func openAICompletion(content string) string {
+	body, _ := json.Marshal(map[string]interface{}{
+		"choices": []map[string]interface{}{{"message": map[string]string{"role": "assistant", "content": content}}},
+	})
+	return string(body)
+}
+
+func ollamaCompletion(content string) string {
+	body, _ := json.Marshal(map[string]interface{}{
+		"message": map[string]string{"role": "assistant", "content": content},
+		"done":    true,
+	})
+	return string(body)
+}
+
+func assertExtractedCard(t *testing.T, businessCard *models.BusinessCard, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("ExtractBusinessCardData() error = %v", err)
+	}
+	if businessCard.PersonalData.FullName != "Ada Lovelace" || businessCard.PersonalData.Email != "ada@example.com" {
+		t.Errorf("personal data = %+v", businessCard.PersonalData)
+	}
+	if businessCard.CompanyData.Name != "Analytical Engines Ltd" {
+		t.Errorf("company name = %q", businessCard.CompanyData.Name)
+	}
+	if businessCard.FieldConfidence["personal_data.email"] != 0.4 {
+		t.Errorf("field confidence = %v", businessCard.FieldConfidence)
+	}
+	if len(businessCard.Images) != 1 {
+		t.Errorf("images = %d, want 1", len(businessCard.Images))
+	}
+}
+
+func TestOpenAIExtractor(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var request openAIChatRequest
+		server := extractorStandIn(t, "/v1/chat/completions", http.StatusOK, openAICompletion(cannedExtraction), &request)
+		extractor, err := NewOpenAIExtractor(server.URL+"/v1/", "sk-test", "gpt-4o-mini")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		businessCard, err := extractor.ExtractBusinessCardData(context.Background(), testImages)
+		assertExtractedCard(t, businessCard, err)
+
+		if request.Model != "gpt-4o-mini" || len(request.Messages) != 1 || len(request.Messages[0].Content) != 2 {
+			t.Fatalf("request = %+v", request)
+		}
+		if url := request.Messages[0].Content[1].ImageURL.URL; !strings.HasPrefix(url, "data:image/jpeg;base64,") {
+			t.Errorf("image URL = %q", url)
+		}
+	})
+
+	t.Run("fenced code block", func(t *testing.T) {
+		content := "Here is the card:\n```json\n" + cannedExtraction + "\n```\nLet me know if you need more."
+		server := extractorStandIn(t, "/v1/chat/completions", http.StatusOK, openAICompletion(content), nil)
+		extractor, _ := NewOpenAIExtractor(server.URL+"/v1", "", "local")
+
+		businessCard, err := extractor.ExtractBusinessCardData(context.Background(), testImages)
+		assertExtractedCard(t, businessCard, err)
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		server := extractorStandIn(t, "/v1/chat/completions", http.StatusTooManyRequests, `{"error": {"message": "quota exceeded"}}`, nil)
+		extractor, _ := NewOpenAIExtractor(server.URL+"/v1", "", "local")
+
+		_, err := extractor.ExtractBusinessCardData(context.Background(), testImages)
+		if err == nil || !strings.Contains(err.Error(), "status 429") || !strings.Contains(err.Error(), "quota exceeded") {
+			t.Fatalf("error = %v, want status 429 with the body", err)
+		}
+	})
+
+	t.Run("malformed response", func(t *testing.T) {
+		server := extractorStandIn(t, "/v1/chat/completions", http.StatusOK, `{"choices": [`, nil)
+		extractor, _ := NewOpenAIExtractor(server.URL+"/v1", "", "local")
+
+		if _, err := extractor.ExtractBusinessCardData(context.Background(), testImages); err == nil {
+			t.Fatal("expected an error for a malformed response")
+		}
+	})
+
+	t.Run("malformed extraction", func(t *testing.T) {
+		server := extractorStandIn(t, "/v1/chat/completions", http.StatusOK, openAICompletion(`{"personal_data": "nope"}`), nil)
+		extractor, _ := NewOpenAIExtractor(server.URL+"/v1", "", "local")
+
+		_, err := extractor.ExtractBusinessCardData(context.Background(), testImages)
+		if err == nil || !strings.Contains(err.Error(), "failed to parse extracted data") {
+			t.Fatalf("error = %v, want a parse error", err)
+		}
+	})
+
+	t.Run("no choices", func(t *testing.T) {
+		server := extractorStandIn(t, "/v1/chat/completions", http.StatusOK, `{"choices": []}`, nil)
+		extractor, _ := NewOpenAIExtractor(server.URL+"/v1", "", "local")
+
+		if _, err := extractor.ExtractBusinessCardData(context.Background(), testImages); err == nil {
+			t.Fatal("expected an error without choices")
+		}
+	})
+}
+
+func TestOllamaExtractor(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var request ollamaChatRequest
+		server := extractorStandIn(t, "/api/chat", http.StatusOK, ollamaCompletion(cannedExtraction), &request)
+		extractor, err := NewOllamaExtractor(server.URL, "llava")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		businessCard, err := extractor.ExtractBusinessCardData(context.Background(), testImages)
+		assertExtractedCard(t, businessCard, err)
+
+		if request.Model != "llava" || request.Stream || request.Format != "json" || len(request.Messages[0].Images) != 1 {
+			t.Errorf("request = %+v", request)
+		}
+	})
+
+	t.Run("fenced code block", func(t *testing.T) {
+		server := extractorStandIn(t, "/api/chat", http.StatusOK, ollamaCompletion("```\n"+cannedExtraction+"\n```"), nil)
+		extractor, _ := NewOllamaExtractor(server.URL, "llava")
+
+		businessCard, err := extractor.ExtractBusinessCardData(context.Background(), testImages)
+		assertExtractedCard(t, businessCard, err)
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		server := extractorStandIn(t, "/api/chat", http.StatusNotFound, `{"error": "model \"llava\" not found"}`, nil)
+		extractor, _ := NewOllamaExtractor(server.URL, "llava")
+
+		_, err := extractor.ExtractBusinessCardData(context.Background(), testImages)
+		if err == nil || !strings.Contains(err.Error(), "status 404") {
+			t.Fatalf("error = %v, want status 404", err)
+		}
+	})
+
+	t.Run("error in body", func(t *testing.T) {
+		server := extractorStandIn(t, "/api/chat", http.StatusOK, `{"error": "out of memory"}`, nil)
+		extractor, _ := NewOllamaExtractor(server.URL, "llava")
+
+		_, err := extractor.ExtractBusinessCardData(context.Background(), testImages)
+		if err == nil || !strings.Contains(err.Error(), "out of memory") {
+			t.Fatalf("error = %v, want the reported error", err)
+		}
+	})
+
+	t.Run("malformed response", func(t *testing.T) {
+		server := extractorStandIn(t, "/api/chat", http.StatusOK, `not json`, nil)
+		extractor, _ := NewOllamaExtractor(server.URL, "llava")
+
+		if _, err := extractor.ExtractBusinessCardData(context.Background(), testImages); err == nil {
+			t.Fatal("expected an error for a malformed response")
+		}
+	})
+}
+
+func TestExtractJSONFromResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     string
+	}{
+		{"plain", `{"a": 1}`, `{"a": 1}`},
+		{"fenced", "```json\n{\"a\": 1}\n```", `{"a": 1}`},
+		{"commentary", "Sure! {\"a\": {\"b\": \"}\"}} Hope it helps", `{"a": {"b": "}"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractJSONFromResponse(tt.response); got != tt.want {
+				t.Errorf("extractJSONFromResponse() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
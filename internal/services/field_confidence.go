@@ -0,0 +1,102 @@
+package services
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+
+	"business-card-reader/internal/logger"
+	"business-card-reader/internal/models"
+)
+
+// SetConfidenceThreshold routes extracted business cards with a field rated below the threshold
+// to NEEDS_REVIEW instead of COMPLETED. Zero disables the check.
+func (b *BusinessCardService) SetConfidenceThreshold(threshold float64) {
+	logger.LogInfo("SetConfidenceThreshold", "Confidence threshold configured", map[string]interface{}{
+		"confidence_threshold": threshold,
+	})
+	b.confidenceThreshold = threshold
+}
+
+// parseFieldConfidence keeps the scores of the non-empty fields of an extraction result. Models
+// don't always follow the requested format, so scores that aren't numbers are skipped, quoted
+// numbers are accepted and values outside 0-1 are clamped.
+func parseFieldConfidence(businessCard *models.BusinessCard, scores map[string]json.RawMessage) map[string]float64 {
+	confidence := make(map[string]float64)
+	for _, field := range editableFields {
+		raw, ok := scores[field.path]
+		if !ok || *field.value(businessCard) == "" {
+			continue
+		}
+
+		var score float64
+		if err := json.Unmarshal(raw, &score); err != nil {
+			var text string
+			if json.Unmarshal(raw, &text) != nil {
+				continue
+			}
+			if score, err = strconv.ParseFloat(text, 64); err != nil {
+				continue
+			}
+		}
+		confidence[field.path] = min(max(score, 0), 1)
+	}
+
+	if len(confidence) == 0 {
+		return nil
+	}
+	return confidence
+}
+
+// deriveFieldConfidence rates a field derived from others, such as the name parts split from the
+// full name, like its least certain source. It stays unrated when a source wasn't rated.
+func deriveFieldConfidence(businessCard *models.BusinessCard, path string, sources ...string) {
+	if len(sources) == 0 {
+		return
+	}
+
+	score := 1.0
+	for _, source := range sources {
+		sourceScore, ok := businessCard.FieldConfidence[source]
+		if !ok {
+			return
+		}
+		score = min(score, sourceScore)
+	}
+	businessCard.FieldConfidence[path] = score
+}
+
+// lowConfidenceFields returns the non-empty fields that no person has verified and that are rated
+// below the threshold or not rated at all. An unrated field is as uncertain as it gets, so cards
+// from extractors that don't rate fields, such as Tesseract, always need a review.
+func (b *BusinessCardService) lowConfidenceFields(businessCard *models.BusinessCard) []string {
+	if b.confidenceThreshold <= 0 {
+		return nil
+	}
+
+	var fields []string
+	for _, field := range editableFields {
+		if *field.value(businessCard) == "" || slices.Contains(businessCard.VerifiedFields, field.path) {
+			continue
+		}
+		if score, ok := businessCard.FieldConfidence[field.path]; !ok || score < b.confidenceThreshold {
+			fields = append(fields, field.path)
+		}
+	}
+	return fields
+}
+
+// extractedStatus returns the status of a successfully extracted business card
+func (b *BusinessCardService) extractedStatus(businessCard *models.BusinessCard) string {
+	fields := b.lowConfidenceFields(businessCard)
+	if len(fields) == 0 {
+		return models.StatusCompleted
+	}
+
+	logger.LogInfo("ProcessBusinessCard", "Business card needs review", map[string]interface{}{
+		"business_card_id":      businessCard.ID,
+		"low_confidence_fields": fields,
+		"confidence_threshold":  b.confidenceThreshold,
+	})
+	return models.StatusNeedsReview
+}
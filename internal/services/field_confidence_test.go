@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"business-card-reader/internal/models"
+)
+
+func TestLowConfidenceFields(t *testing.T) {
+	newCard := func(confidence map[string]float64, verified ...string) *models.BusinessCard {
+		businessCard := &models.BusinessCard{FieldConfidence: confidence, VerifiedFields: verified}
+		businessCard.PersonalData.FullName = "Jane Doe"
+		businessCard.PersonalData.Email = "jane@example.com"
+		return businessCard
+	}
+
+	tests := []struct {
+		name      string
+		threshold float64
+		card      *models.BusinessCard
+		want      []string
+	}{
+		{
+			name:      "every field rated above the threshold",
+			threshold: 0.6,
+			card:      newCard(map[string]float64{"personal_data.full_name": 0.9, "personal_data.email": 0.7}),
+		},
+		{
+			name:      "field rated below the threshold",
+			threshold: 0.6,
+			card:      newCard(map[string]float64{"personal_data.full_name": 0.9, "personal_data.email": 0.4}),
+			want:      []string{"personal_data.email"},
+		},
+		{
+			name:      "filled field without a score",
+			threshold: 0.6,
+			card:      newCard(map[string]float64{"personal_data.full_name": 0.9}),
+			want:      []string{"personal_data.email"},
+		},
+		{
+			name:      "no confidence map at all",
+			threshold: 0.6,
+			card:      newCard(nil),
+			want:      []string{"personal_data.full_name", "personal_data.email"},
+		},
+		{
+			name:      "verified fields don't count",
+			threshold: 0.6,
+			card:      newCard(map[string]float64{"personal_data.full_name": 0.9}, "personal_data.email"),
+		},
+		{
+			name:      "threshold zero disables the check",
+			threshold: 0,
+			card:      newCard(nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &BusinessCardService{confidenceThreshold: tt.threshold}
+			if got := service.lowConfidenceFields(tt.card); !slices.Equal(got, tt.want) {
+				t.Errorf("lowConfidenceFields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizedNamePartsKeepConfidence(t *testing.T) {
+	t.Run("split from the full name", func(t *testing.T) {
+		businessCard := &models.BusinessCard{FieldConfidence: map[string]float64{"personal_data.full_name": 0.8}}
+		businessCard.PersonalData.FullName = "Jane Doe"
+
+		normalizeBusinessCardData(businessCard)
+
+		for _, path := range []string{"personal_data.first_name", "personal_data.last_name"} {
+			if score, ok := businessCard.FieldConfidence[path]; !ok || score != 0.8 {
+				t.Errorf("%s rated %v (%v), want 0.8", path, score, ok)
+			}
+		}
+	})
+
+	t.Run("joined from the name parts", func(t *testing.T) {
+		businessCard := &models.BusinessCard{FieldConfidence: map[string]float64{
+			"personal_data.first_name": 0.9,
+			"personal_data.last_name":  0.7,
+		}}
+		businessCard.PersonalData.FirstName = "Jane"
+		businessCard.PersonalData.LastName = "Doe"
+
+		normalizeBusinessCardData(businessCard)
+
+		if score := businessCard.FieldConfidence["personal_data.full_name"]; score != 0.7 {
+			t.Errorf("full name rated %v, want the lower score 0.7", score)
+		}
+	})
+
+	t.Run("unrated sources", func(t *testing.T) {
+		businessCard := &models.BusinessCard{}
+		businessCard.PersonalData.FullName = "Jane Doe"
+
+		normalizeBusinessCardData(businessCard)
+
+		if businessCard.FieldConfidence != nil {
+			t.Errorf("unrated card got scores %v", businessCard.FieldConfidence)
+		}
+	})
+}
+
+func TestUnratedExtractionNeedsReview(t *testing.T) {
+	extracted := models.BusinessCard{}
+	extracted.PersonalData.FullName = "Jane Doe"
+
+	tests := []struct {
+		name       string
+		confidence map[string]float64
+		want       string
+	}{
+		{name: "rated", confidence: map[string]float64{"personal_data.full_name": 0.95}, want: models.StatusCompleted},
+		{name: "unrated, as from Tesseract", want: models.StatusNeedsReview},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extracted.FieldConfidence = tt.confidence
+			service := NewBusinessCardService(NewMemoryRepository(), &stubExtractor{card: extracted})
+			service.SetConfidenceThreshold(0.6)
+
+			businessCard, err := service.ProcessBusinessCard(context.Background(), testUpload)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if businessCard.Status != tt.want {
+				t.Errorf("status = %s, want %s", businessCard.Status, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package services
+
+import (
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"business-card-reader/internal/config"
+	"business-card-reader/internal/models"
+)
+
+// Registry names of the field post-processors
+const (
+	PostProcessorNames  = "names"
+	PostProcessorEmails = "emails"
+	PostProcessorURLs   = "urls"
+	PostProcessorSocial = "social"
+)
+
+func init() {
+	RegisterPostProcessor(PostProcessorNames, func(cfg *config.Config) (PostProcessor, error) {
+		return nameProcessor{}, nil
+	})
+	RegisterPostProcessor(PostProcessorEmails, func(cfg *config.Config) (PostProcessor, error) {
+		return emailProcessor{}, nil
+	})
+	RegisterPostProcessor(PostProcessorURLs, func(cfg *config.Config) (PostProcessor, error) {
+		return urlProcessor{}, nil
+	})
+	RegisterPostProcessor(PostProcessorSocial, func(cfg *config.Config) (PostProcessor, error) {
+		return socialProfileProcessor{}, nil
+	})
+}
+
+// fieldsWithPaths returns the editable fields with the given paths
+func fieldsWithPaths(paths ...string) []editableField {
+	var fields []editableField
+	for _, field := range editableFields {
+		if slices.Contains(paths, field.path) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// nameProcessor fixes names printed or extracted in all capitals or all lower case
+type nameProcessor struct{}
+
+var nameFields = fieldsWithPaths("personal_data.full_name", "personal_data.first_name", "personal_data.last_name")
+
+// nameParticles stay lower case inside a name, as in Ludwig van Beethoven
+var nameParticles = []string{"al", "bin", "da", "das", "de", "del", "della", "der", "di", "do", "dos", "du", "la", "le", "van", "von", "y"}
+
+func (nameProcessor) Name() string {
+	return PostProcessorNames
+}
+
+func (nameProcessor) Process(businessCard *models.BusinessCard) []models.ValidationWarning {
+	var warnings []models.ValidationWarning
+	for _, field := range nameFields {
+		value := field.value(businessCard)
+		if *value == "" {
+			continue
+		}
+
+		if strings.ContainsFunc(*value, func(r rune) bool { return unicode.IsDigit(r) || r == '@' }) {
+			warnings = append(warnings, models.ValidationWarning{Field: field.path, Message: "contains characters that don't belong in a name"})
+			continue
+		}
+		*value = fixNameCasing(*value)
+	}
+	return warnings
+}
+
+// fixNameCasing capitalizes every word of a name that is all upper or all lower case. Names in
+// mixed case, such as McDonald, are left alone.
+func fixNameCasing(name string) string {
+	if name != strings.ToUpper(name) && name != strings.ToLower(name) {
+		return name
+	}
+
+	words := strings.Fields(strings.ToLower(name))
+	for i, word := range words {
+		if i > 0 && slices.Contains(nameParticles, word) {
+			continue
+		}
+
+		// Capitalize each part of hyphenated and apostrophized names: Jean-Luc, O'Brien
+		runes := []rune(word)
+		for j := range runes {
+			if j == 0 || runes[j-1] == '-' || runes[j-1] == '\'' {
+				runes[j] = unicode.ToUpper(runes[j])
+			}
+		}
+		words[i] = string(runes)
+	}
+	return strings.Join(words, " ")
+}
+
+// emailProcessor strips the punctuation OCR tends to pick up around email addresses and checks
+// that they are valid
+type emailProcessor struct{}
+
+var emailFields = fieldsWithPaths("personal_data.email", "company_data.email")
+
+func (emailProcessor) Name() string {
+	return PostProcessorEmails
+}
+
+func (emailProcessor) Process(businessCard *models.BusinessCard) []models.ValidationWarning {
+	var warnings []models.ValidationWarning
+	for _, field := range emailFields {
+		value := field.value(businessCard)
+		if *value == "" {
+			continue
+		}
+
+		*value = strings.ToLower(strings.Trim(*value, "<>()[].,;: "))
+		_, domain, _ := strings.Cut(*value, "@")
+		if validateEmail(*value) != nil || !strings.Contains(domain, ".") {
+			warnings = append(warnings, models.ValidationWarning{Field: field.path, Message: "not a valid email address"})
+		}
+	}
+	return warnings
+}
+
+// urlProcessor adds the missing https scheme to websites and checks that they are web addresses
+type urlProcessor struct{}
+
+var urlFields = fieldsWithPaths("personal_data.website", "company_data.website")
+
+func (urlProcessor) Name() string {
+	return PostProcessorURLs
+}
+
+func (urlProcessor) Process(businessCard *models.BusinessCard) []models.ValidationWarning {
+	var warnings []models.ValidationWarning
+	for _, field := range urlFields {
+		value := field.value(businessCard)
+		if *value == "" {
+			continue
+		}
+
+		canonical, ok := canonicalWebAddress(*value)
+		if !ok {
+			warnings = append(warnings, models.ValidationWarning{Field: field.path, Message: "not a valid web address"})
+			continue
+		}
+		*value = canonical
+	}
+	return warnings
+}
+
+// canonicalWebAddress returns an http(s) URL with a lower-case host, adding https:// when the
+// scheme is missing
+func canonicalWebAddress(value string) (string, bool) {
+	value = strings.TrimRight(strings.TrimSpace(value), ".,;")
+	if !strings.Contains(value, "://") {
+		value = "https://" + value
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") ||
+		!strings.Contains(parsed.Hostname(), ".") || strings.ContainsAny(parsed.Host, " \t") {
+		return "", false
+	}
+
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	parsed.Host = strings.ToLower(parsed.Host)
+	if parsed.Path == "/" && parsed.RawQuery == "" && parsed.Fragment == "" {
+		parsed.Path = ""
+	}
+	return parsed.String(), true
+}
+
+// socialProfileProcessor turns social media handles and profile URLs into canonical profile URLs
+type socialProfileProcessor struct{}
+
+// socialProfile describes the profile URLs of one social network field
+type socialProfile struct {
+	path    string
+	network string
+	hosts   []string
+	// baseURL is followed by the handle; LinkedIn profiles also need their kind, as in /in/ or /company/
+	baseURL string
+	kind    string
+}
+
+var socialProfiles = []socialProfile{
+	{path: "personal_data.linkedin", network: "LinkedIn", hosts: []string{"linkedin.com"}, baseURL: "https://www.linkedin.com/", kind: "in"},
+	{path: "company_data.social_media.linkedin", network: "LinkedIn", hosts: []string{"linkedin.com"}, baseURL: "https://www.linkedin.com/", kind: "company"},
+	{path: "company_data.social_media.twitter", network: "X", hosts: []string{"x.com", "twitter.com"}, baseURL: "https://x.com/"},
+	{path: "company_data.social_media.facebook", network: "Facebook", hosts: []string{"facebook.com", "fb.com"}, baseURL: "https://www.facebook.com/"},
+	{path: "company_data.social_media.instagram", network: "Instagram", hosts: []string{"instagram.com"}, baseURL: "https://www.instagram.com/"},
+}
+
+// linkedInKinds are the first path segments of LinkedIn profile URLs
+var linkedInKinds = []string{"company", "in", "school", "showcase"}
+
+var socialHandlePattern = regexp.MustCompile(`^[\p{L}\p{N}._-]+$`)
+
+func (socialProfileProcessor) Name() string {
+	return PostProcessorSocial
+}
+
+func (socialProfileProcessor) Process(businessCard *models.BusinessCard) []models.ValidationWarning {
+	var warnings []models.ValidationWarning
+	for _, profile := range socialProfiles {
+		field := fieldsWithPaths(profile.path)[0]
+		value := field.value(businessCard)
+		if *value == "" {
+			continue
+		}
+
+		canonical, message := profile.canonicalURL(*value)
+		if message != "" {
+			warnings = append(warnings, models.ValidationWarning{Field: profile.path, Message: message})
+			continue
+		}
+		*value = canonical
+	}
+	return warnings
+}
+
+// canonicalURL accepts a profile URL, with or without scheme, or a handle with or without @.
+// It returns a warning message when the value is neither.
+func (p socialProfile) canonicalURL(value string) (string, string) {
+	value = strings.TrimSpace(value)
+	path := strings.TrimPrefix(value, "@")
+
+	if host, rest, isURL := splitProfileURL(value); isURL {
+		if !p.isNetworkHost(host) {
+			return "", "not a " + p.network + " profile"
+		}
+		path = rest
+	}
+
+	// Drop the query and fragment, and the trailing slash
+	path, _, _ = strings.Cut(path, "?")
+	path, _, _ = strings.Cut(path, "#")
+	segments := strings.FieldsFunc(path, func(r rune) bool { return r == '/' })
+
+	kind := p.kind
+	if kind != "" && len(segments) >= 2 && slices.Contains(linkedInKinds, strings.ToLower(segments[0])) {
+		kind = strings.ToLower(segments[0])
+		segments = segments[1:]
+	}
+
+	if len(segments) != 1 || !socialHandlePattern.MatchString(strings.TrimPrefix(segments[0], "@")) {
+		return "", "not a valid " + p.network + " profile or handle"
+	}
+	handle := strings.TrimPrefix(segments[0], "@")
+
+	if kind != "" {
+		return p.baseURL + kind + "/" + handle, ""
+	}
+	return p.baseURL + handle, ""
+}
+
+func (p socialProfile) isNetworkHost(host string) bool {
+	return slices.ContainsFunc(p.hosts, func(h string) bool { return host == h || strings.HasSuffix(host, "."+h) })
+}
+
+// splitProfileURL returns the lower-case host and the path of a value that looks like a URL.
+// Handles may contain dots, so a dotted word alone only counts as a URL with a scheme, www. or
+// a path.
+func splitProfileURL(value string) (string, string, bool) {
+	withoutScheme := value
+	_, rest, hasScheme := strings.Cut(value, "://")
+	if hasScheme {
+		withoutScheme = rest
+	}
+
+	host, path, hasPath := strings.Cut(withoutScheme, "/")
+	host = strings.ToLower(host)
+	if !strings.Contains(host, ".") || !(hasScheme || hasPath || strings.HasPrefix(host, "www.")) {
+		return "", "", false
+	}
+	return host, path, true
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"business-card-reader/internal/logger"
+)
+
+// FilesystemImageStore keeps images as files below a root directory, using the object key
+// as the relative path
+type FilesystemImageStore struct {
+	root string
+}
+
+func NewFilesystemImageStore(root string) (*FilesystemImageStore, error) {
+	absoluteRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve image store path: %w", err)
+	}
+
+	if err := os.MkdirAll(absoluteRoot, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create image store directory: %w", err)
+	}
+
+	logger.LogInfo("NewFilesystemImageStore", "Filesystem image store initialized", map[string]interface{}{
+		"path": absoluteRoot,
+	})
+
+	return &FilesystemImageStore{root: absoluteRoot}, nil
+}
+
+func (f *FilesystemImageStore) Name() string {
+	return ImageStoreFilesystem
+}
+
+func (f *FilesystemImageStore) PutImage(ctx context.Context, key string, contentType string, data []byte) error {
+	path, err := f.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create image directory: %w", err)
+	}
+
+	// Write to a temporary file first so readers never see a partial image
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create image file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write image: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write image: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to store image: %w", err)
+	}
+
+	return nil
+}
+
+func (f *FilesystemImageStore) GetImage(ctx context.Context, key string) ([]byte, error) {
+	path, err := f.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrImageNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+
+	return data, nil
+}
+
+func (f *FilesystemImageStore) DeleteImage(ctx context.Context, key string) error {
+	path, err := f.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete image: %w", err)
+	}
+
+	return nil
+}
+
+// path maps an object key to a file below the root, rejecting keys that would escape it
+func (f *FilesystemImageStore) path(key string) (string, error) {
+	path := filepath.Join(f.root, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, f.root+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid image key: %s", key)
+	}
+	return path, nil
+}
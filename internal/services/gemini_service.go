@@ -2,15 +2,24 @@ package services
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
+	"business-card-reader/internal/config"
 	"business-card-reader/internal/logger"
 	"business-card-reader/internal/models"
 
 	"google.golang.org/genai"
 )
 
+// ExtractorGemini is the registry name of the Google Gemini extraction provider
+const ExtractorGemini = "gemini"
+
+func init() {
+	RegisterExtractor(ExtractorGemini, func(cfg *config.Config) (Extractor, error) {
+		return NewGeminiService(cfg.Gemini.APIKey, cfg.Gemini.ModelName)
+	})
+}
+
 type GeminiService struct {
 	client    *genai.Client
 	modelName string
@@ -43,13 +52,17 @@ func NewGeminiService(apiKey string, modelName string) (*GeminiService, error) {
 	}, nil
 }
 
+func (g *GeminiService) Name() string {
+	return ExtractorGemini
+}
+
 func (g *GeminiService) ExtractBusinessCardData(ctx context.Context, images []models.ImageData) (*models.BusinessCard, error) {
 	logger.LogInfo("ExtractBusinessCardData", "Starting Gemini AI processing", map[string]interface{}{
 		"image_count": len(images),
 		"model_name":  g.modelName,
 	})
 
-	prompt := g.buildExtractionPrompt()
+	prompt := buildExtractionPrompt()
 
 	// Prepare parts for the request
 	parts := []*genai.Part{{Text: prompt}}
@@ -98,124 +111,5 @@ func (g *GeminiService) ExtractBusinessCardData(ctx context.Context, images []mo
 		"response_length": len(responseText),
 	})
 
-	// Clean the response to extract JSON
-	jsonStr := g.extractJSONFromResponse(responseText)
-
-	logger.LogDebug("ExtractBusinessCardData", "Extracted JSON from response", map[string]interface{}{
-		"json_length": len(jsonStr),
-	})
-
-	// Parse the extracted data
-	var extractedData struct {
-		PersonalData models.PersonalData `json:"personal_data"`
-		CompanyData  models.CompanyData  `json:"company_data"`
-	}
-
-	if err := json.Unmarshal([]byte(jsonStr), &extractedData); err != nil {
-		logger.LogError("ExtractBusinessCardData", err, map[string]interface{}{
-			"step":        "parse_json",
-			"json_string": jsonStr,
-		})
-		return nil, fmt.Errorf("failed to parse extracted data: %w", err)
-	}
-
-	logger.LogInfo("ExtractBusinessCardData", "Business card data extracted successfully", map[string]interface{}{
-		"personal_name": extractedData.PersonalData.FullName,
-		"company_name":  extractedData.CompanyData.Name,
-		"has_email":     extractedData.PersonalData.Email != "",
-		"has_phone":     extractedData.PersonalData.Phone != "",
-	})
-
-	businessCard := &models.BusinessCard{
-		PersonalData:  extractedData.PersonalData,
-		CompanyData:   extractedData.CompanyData,
-		ExtractedText: responseText,
-		Images:        images,
-	}
-
-	return businessCard, nil
-}
-
-func (g *GeminiService) buildExtractionPrompt() string {
-	return `
-You are an expert at extracting information from business cards. Analyze the provided business card image(s) and extract all relevant information.
-
-Please extract the information and return it in the following JSON format:
-
-{
-  "personal_data": {
-    "full_name": "",
-    "first_name": "",
-    "last_name": "",
-    "job_title": "",
-    "department": "",
-    "email": "",
-    "phone": "",
-    "mobile": "",
-    "linkedin": "",
-    "website": ""
-  },
-  "company_data": {
-    "name": "",
-    "industry": "",
-    "website": "",
-    "email": "",
-    "phone": "",
-    "address": {
-      "street": "",
-      "city": "",
-      "state": "",
-      "postal_code": "",
-      "country": "",
-      "full": ""
-    },
-    "social_media": {
-      "linkedin": "",
-      "twitter": "",
-      "facebook": "",
-      "instagram": ""
-    }
-  }
-}
-
-Rules:
-1. Extract all visible text accurately
-2. If multiple images are provided, combine information from both
-3. Leave fields empty ("") if information is not available
-4. For phone numbers, distinguish between main phone and mobile if possible
-5. For websites, include the full URL if visible
-6. For social media, extract usernames or full URLs
-7. For addresses, provide both individual components and full address
-8. Return ONLY the JSON object, no additional text or formatting
-
-Analyze the business card(s) and extract the information:
-`
-}
-
-func (g *GeminiService) extractJSONFromResponse(response string) string {
-	// Find the JSON object in the response
-	start := -1
-	end := -1
-	braceCount := 0
-
-	for i, char := range response {
-		if char == '{' {
-			if start == -1 {
-				start = i
-			}
-			braceCount++
-		} else if char == '}' {
-			braceCount--
-			if braceCount == 0 && start != -1 {
-				end = i + 1
-				break
-			}
-		}
-	}
-
-	if start != -1 && end != -1 {
-		return response[start:end]
-	}
-
-	return response
+	return parseExtractionResponse(responseText, images)
 }
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"business-card-reader/internal/config"
+	"business-card-reader/internal/logger"
+)
+
+// Supported image stores for config.Config.ImageStore.Backend
+const (
+	ImageStoreInline     = "inline"
+	ImageStoreFilesystem = "filesystem"
+	ImageStoreS3         = "s3"
+)
+
+// ErrImageNotFound is returned by image stores when no object exists for the key
+var ErrImageNotFound = errors.New("image not found")
+
+// ImageStore keeps the raw image bytes outside of the business card record, which then
+// only references them by object key
+type ImageStore interface {
+	Name() string
+	PutImage(ctx context.Context, key string, contentType string, data []byte) error
+	GetImage(ctx context.Context, key string) ([]byte, error)
+	DeleteImage(ctx context.Context, key string) error
+}
+
+// NewImageStore creates the image store selected by cfg.ImageStore.Backend. It returns nil
+// for the inline backend, where images stay embedded in the business card record.
+func NewImageStore(ctx context.Context, cfg *config.Config) (ImageStore, error) {
+	backend := strings.ToLower(cfg.ImageStore.Backend)
+
+	logger.LogInfo("NewImageStore", "Initializing image store", map[string]interface{}{
+		"backend": backend,
+	})
+
+	switch backend {
+	case ImageStoreInline, "":
+		return nil, nil
+	case ImageStoreFilesystem:
+		return NewFilesystemImageStore(cfg.ImageStore.Path)
+	case ImageStoreS3:
+		return NewS3ImageStore(ctx, cfg.ImageStore.S3Region, cfg.ImageStore.S3Bucket, cfg.ImageStore.S3Endpoint, cfg.ImageStore.S3UsePathStyle)
+	default:
+		return nil, fmt.Errorf("unsupported image store: %s", cfg.ImageStore.Backend)
+	}
+}
+
+// imageObjectKey builds the object key of the index-th image of a business card
+func imageObjectKey(tenantID string, businessCardID string, index int, contentType string) string {
+	extension := ""
+	switch strings.ToLower(contentType) {
+	case "image/jpeg", "image/jpg":
+		extension = ".jpg"
+	case "image/png":
+		extension = ".png"
+	case "image/webp":
+		extension = ".webp"
+	}
+
+	return fmt.Sprintf("business-cards/%s/%s/%d%s", tenantID, businessCardID, index, extension)
+}
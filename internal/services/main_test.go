@@ -0,0 +1,13 @@
+package services
+
+import (
+	"os"
+	"testing"
+
+	"business-card-reader/internal/logger"
+)
+
+func TestMain(m *testing.M) {
+	logger.Init()
+	os.Exit(m.Run())
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"sort"
+
+	"business-card-reader/internal/models"
+)
+
+func (m *MemoryRepository) SaveAPIKey(ctx context.Context, apiKey *models.APIKey) error {
+	stored := *apiKey
+	stored.Key = ""
+	stored.Scopes = append([]string(nil), apiKey.Scopes...)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.apiKeys[apiKey.ID] = stored
+	return nil
+}
+
+func (m *MemoryRepository) GetAPIKey(ctx context.Context, id string) (*models.APIKey, error) {
+	m.mu.RLock()
+	apiKey, ok := m.apiKeys[id]
+	m.mu.RUnlock()
+
+	if !ok {
+		return nil, ErrAPIKeyNotFound
+	}
+
+	apiKey.Scopes = append([]string(nil), apiKey.Scopes...)
+	return &apiKey, nil
+}
+
+func (m *MemoryRepository) GetAllAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var apiKeys []models.APIKey
+	for _, apiKey := range m.apiKeys {
+		apiKey.Scopes = append([]string(nil), apiKey.Scopes...)
+		apiKeys = append(apiKeys, apiKey)
+	}
+
+	sort.Slice(apiKeys, func(i, j int) bool {
+		return apiKeys[i].CreatedAt.Before(apiKeys[j].CreatedAt)
+	})
+
+	return apiKeys, nil
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"sort"
+
+	"business-card-reader/internal/models"
+)
+
+func (m *MemoryRepository) SaveErasureReceipt(ctx context.Context, receipt *models.ErasureReceipt) error {
+	stored := cloneErasureReceipt(*receipt)
+	stored.Email = ""
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.erasureReceipts[receipt.ID] = stored
+	return nil
+}
+
+func (m *MemoryRepository) GetErasureReceipt(ctx context.Context, id string) (*models.ErasureReceipt, error) {
+	m.mu.RLock()
+	receipt, ok := m.erasureReceipts[id]
+	m.mu.RUnlock()
+
+	if !ok {
+		return nil, ErrErasureReceiptNotFound
+	}
+
+	receipt = cloneErasureReceipt(receipt)
+	return &receipt, nil
+}
+
+func (m *MemoryRepository) GetAllErasureReceipts(ctx context.Context) ([]models.ErasureReceipt, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var receipts []models.ErasureReceipt
+	for _, receipt := range m.erasureReceipts {
+		receipts = append(receipts, cloneErasureReceipt(receipt))
+	}
+
+	sort.Slice(receipts, func(i, j int) bool {
+		return receipts[i].RequestedAt.Before(receipts[j].RequestedAt)
+	})
+
+	return receipts, nil
+}
+
+func cloneErasureReceipt(receipt models.ErasureReceipt) models.ErasureReceipt {
+	receipt.ErasedBusinessCardIDs = append([]string(nil), receipt.ErasedBusinessCardIDs...)
+	receipt.SkippedBusinessCardIDs = append([]string(nil), receipt.SkippedBusinessCardIDs...)
+	return receipt
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"business-card-reader/internal/models"
+)
+
+// MemoryRepository keeps business cards in process memory. Data is lost on restart,
+// which makes it suitable for local development and tests.
+type MemoryRepository struct {
+	mu sync.RWMutex
+	// businessCards is keyed by tenant and ID, see tenantKey
+	businessCards        map[string]models.BusinessCard
+	webhookSubscriptions map[string]models.WebhookSubscription
+	webhookDeliveries    map[string]models.WebhookDelivery
+	apiKeys              map[string]models.APIKey
+	erasureReceipts      map[string]models.ErasureReceipt
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		businessCards:        make(map[string]models.BusinessCard),
+		webhookSubscriptions: make(map[string]models.WebhookSubscription),
+		webhookDeliveries:    make(map[string]models.WebhookDelivery),
+		apiKeys:              make(map[string]models.APIKey),
+		erasureReceipts:      make(map[string]models.ErasureReceipt),
+	}
+}
+
+func (m *MemoryRepository) SaveBusinessCard(ctx context.Context, businessCard *models.BusinessCard) error {
+	stored, err := cloneBusinessCard(businessCard)
+	if err != nil {
+		return fmt.Errorf("failed to save business card: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.businessCards[tenantKey(recordTenant(businessCard.TenantID), businessCard.ID)] = *stored
+	return nil
+}
+
+func (m *MemoryRepository) SaveBusinessCardIf(ctx context.Context, businessCard *models.BusinessCard, condition BusinessCardCondition) error {
+	stored, err := cloneBusinessCard(businessCard)
+	if err != nil {
+		return fmt.Errorf("failed to save business card: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := tenantKey(recordTenant(businessCard.TenantID), businessCard.ID)
+	current, ok := m.businessCards[key]
+	if !ok || current.Status != condition.Status {
+		return ErrBusinessCardChanged
+	}
+	if condition.Reviewer != "" && (current.Review == nil || current.Review.Reviewer != condition.Reviewer) {
+		return ErrBusinessCardChanged
+	}
+
+	m.businessCards[key] = *stored
+	return nil
+}
+
+func (m *MemoryRepository) GetBusinessCard(ctx context.Context, id string) (*models.BusinessCard, error) {
+	m.mu.RLock()
+	businessCard, ok := m.businessCards[tenantKey(TenantFromContext(ctx), id)]
+	m.mu.RUnlock()
+
+	if !ok {
+		return nil, ErrBusinessCardNotFound
+	}
+
+	return cloneBusinessCard(&businessCard)
+}
+
+func (m *MemoryRepository) GetAllBusinessCards(ctx context.Context) ([]models.BusinessCard, error) {
+	return m.filter(ctx, func(models.BusinessCard) bool { return true })
+}
+
+func (m *MemoryRepository) GetBusinessCardsByStatus(ctx context.Context, status string) ([]models.BusinessCard, error) {
+	return m.filter(ctx, func(businessCard models.BusinessCard) bool {
+		return businessCard.Status == status
+	})
+}
+
+func (m *MemoryRepository) DeleteBusinessCard(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := tenantKey(TenantFromContext(ctx), id)
+	if _, ok := m.businessCards[key]; !ok {
+		return ErrBusinessCardNotFound
+	}
+
+	delete(m.businessCards, key)
+	return nil
+}
+
+func (m *MemoryRepository) ListBusinessCards(ctx context.Context, query models.BusinessCardQuery) (*models.BusinessCardPage, error) {
+	cursor, err := decodeCursor(query.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pages are never listed across tenants
+	tenantID := TenantFromContext(ctx)
+	businessCards, err := m.filter(ctx, func(businessCard models.BusinessCard) bool {
+		return recordTenant(businessCard.TenantID) == tenantID && matchesBusinessCardQuery(&businessCard, query)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sortBusinessCards(businessCards, query)
+
+	start := 0
+	if cursor != nil {
+		start = sort.Search(len(businessCards), func(i int) bool {
+			return precedes(cursor.SortValue, cursor.ID, businessCardSortValue(&businessCards[i], query.SortBy), businessCards[i].ID, query.SortOrder)
+		})
+	}
+
+	end := start + query.Limit
+	page := &models.BusinessCardPage{}
+	if end < len(businessCards) {
+		last := &businessCards[end-1]
+		page.NextCursor = encodeCursor(pageCursor{SortValue: businessCardSortValue(last, query.SortBy), ID: last.ID})
+	} else {
+		end = len(businessCards)
+	}
+	page.BusinessCards = businessCards[start:end]
+
+	return page, nil
+}
+
+func (m *MemoryRepository) CreateTableIfNotExists(ctx context.Context) error {
+	// Nothing to provision for the in-memory store
+	return nil
+}
+
+// filter returns the business cards of the context's tenant, or of every tenant for a context
+// created by WithAllTenants, that match
+func (m *MemoryRepository) filter(ctx context.Context, match func(models.BusinessCard) bool) ([]models.BusinessCard, error) {
+	tenantID, scoped := listingTenant(ctx)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var businessCards []models.BusinessCard
+	for _, businessCard := range m.businessCards {
+		if scoped && recordTenant(businessCard.TenantID) != tenantID {
+			continue
+		}
+		if !match(businessCard) {
+			continue
+		}
+		clone, err := cloneBusinessCard(&businessCard)
+		if err != nil {
+			return nil, err
+		}
+		businessCards = append(businessCards, *clone)
+	}
+
+	// Map iteration order is random, keep results stable for callers
+	sort.Slice(businessCards, func(i, j int) bool {
+		return businessCards[i].CreatedAt.Before(businessCards[j].CreatedAt)
+	})
+
+	return businessCards, nil
+}
+
+// cloneBusinessCard returns a deep copy so callers can't mutate stored records
+func cloneBusinessCard(businessCard *models.BusinessCard) (*models.BusinessCard, error) {
+	data, err := json.Marshal(businessCard)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy business card: %w", err)
+	}
+
+	var clone models.BusinessCard
+	if err := json.Unmarshal(data, &clone); err != nil {
+		return nil, fmt.Errorf("failed to copy business card: %w", err)
+	}
+
+	return &clone, nil
+}
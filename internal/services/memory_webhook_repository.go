@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"business-card-reader/internal/models"
+)
+
+func (m *MemoryRepository) SaveWebhookSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	stored := *subscription
+	stored.Events = append([]string(nil), subscription.Events...)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.webhookSubscriptions[subscription.ID] = stored
+	return nil
+}
+
+func (m *MemoryRepository) GetWebhookSubscription(ctx context.Context, id string) (*models.WebhookSubscription, error) {
+	m.mu.RLock()
+	subscription, ok := m.webhookSubscriptions[id]
+	m.mu.RUnlock()
+
+	if !ok {
+		return nil, ErrWebhookSubscriptionNotFound
+	}
+
+	subscription.Events = append([]string(nil), subscription.Events...)
+	return &subscription, nil
+}
+
+func (m *MemoryRepository) GetAllWebhookSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var subscriptions []models.WebhookSubscription
+	for _, subscription := range m.webhookSubscriptions {
+		subscription.Events = append([]string(nil), subscription.Events...)
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].CreatedAt.Before(subscriptions[j].CreatedAt)
+	})
+
+	return subscriptions, nil
+}
+
+func (m *MemoryRepository) DeleteWebhookSubscription(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.webhookSubscriptions[id]; !ok {
+		return ErrWebhookSubscriptionNotFound
+	}
+
+	delete(m.webhookSubscriptions, id)
+	for deliveryID, delivery := range m.webhookDeliveries {
+		if delivery.SubscriptionID == id {
+			delete(m.webhookDeliveries, deliveryID)
+		}
+	}
+
+	return nil
+}
+
+func (m *MemoryRepository) SaveWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.webhookDeliveries[delivery.ID] = *delivery
+	return nil
+}
+
+func (m *MemoryRepository) GetWebhookDeliveriesBySubscription(ctx context.Context, subscriptionID string) ([]models.WebhookDelivery, error) {
+	return m.filterDeliveries(func(delivery models.WebhookDelivery) bool {
+		return delivery.SubscriptionID == subscriptionID
+	}), nil
+}
+
+func (m *MemoryRepository) GetDueWebhookDeliveries(ctx context.Context, now time.Time) ([]models.WebhookDelivery, error) {
+	return m.filterDeliveries(func(delivery models.WebhookDelivery) bool {
+		return delivery.Status == models.DeliveryStatusPending && !delivery.NextAttemptAt.After(now)
+	}), nil
+}
+
+func (m *MemoryRepository) filterDeliveries(match func(models.WebhookDelivery) bool) []models.WebhookDelivery {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var deliveries []models.WebhookDelivery
+	for _, delivery := range m.webhookDeliveries {
+		if match(delivery) {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedAt.Before(deliveries[j].CreatedAt)
+	})
+
+	return deliveries
+}
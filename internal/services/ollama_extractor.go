@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"business-card-reader/internal/config"
+	"business-card-reader/internal/logger"
+	"business-card-reader/internal/models"
+)
+
+// ExtractorOllama is the registry name of the local Ollama extraction provider
+const ExtractorOllama = "ollama"
+
+func init() {
+	RegisterExtractor(ExtractorOllama, func(cfg *config.Config) (Extractor, error) {
+		return NewOllamaExtractor(cfg.Ollama.BaseURL, cfg.Ollama.ModelName)
+	})
+}
+
+// OllamaExtractor uses a vision model served by a local Ollama instance, so card
+// images never leave the machine
+type OllamaExtractor struct {
+	baseURL    string
+	modelName  string
+	httpClient *http.Client
+}
+
+type ollamaChatRequest struct {
+	Model    string              `json:"model"`
+	Messages []ollamaChatMessage `json:"messages"`
+	Stream   bool                `json:"stream"`
+	Format   string              `json:"format"`
+	Options  map[string]any      `json:"options,omitempty"`
+}
+
+type ollamaChatMessage struct {
+	Role    string   `json:"role"`
+	Content string   `json:"content"`
+	Images  []string `json:"images,omitempty"`
+}
+
+type ollamaChatResponse struct {
+	Message ollamaChatMessage `json:"message"`
+	Error   string            `json:"error"`
+}
+
+func NewOllamaExtractor(baseURL string, modelName string) (*OllamaExtractor, error) {
+	if baseURL == "" {
+		return nil, fmt.Errorf("Ollama base URL is required")
+	}
+	if modelName == "" {
+		return nil, fmt.Errorf("Ollama model name is required")
+	}
+
+	logger.LogInfo("NewOllamaExtractor", "Ollama extractor initialized", map[string]interface{}{
+		"base_url":   baseURL,
+		"model_name": modelName,
+	})
+
+	return &OllamaExtractor{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		modelName:  modelName,
+		httpClient: &http.Client{Timeout: extractorHTTPTimeout},
+	}, nil
+}
+
+func (o *OllamaExtractor) Name() string {
+	return ExtractorOllama
+}
+
+func (o *OllamaExtractor) ExtractBusinessCardData(ctx context.Context, images []models.ImageData) (*models.BusinessCard, error) {
+	logger.LogInfo("ExtractBusinessCardData", "Starting Ollama processing", map[string]interface{}{
+		"image_count": len(images),
+		"model_name":  o.modelName,
+	})
+
+	encodedImages := make([]string, len(images))
+	for i, img := range images {
+		encodedImages[i] = base64.StdEncoding.EncodeToString(img.Data)
+	}
+
+	request := ollamaChatRequest{
+		Model: o.modelName,
+		Messages: []ollamaChatMessage{{
+			Role:    "user",
+			Content: buildExtractionPrompt(),
+			Images:  encodedImages,
+		}},
+		Stream:  false,
+		Format:  "json",
+		Options: map[string]any{"temperature": 0},
+	}
+
+	var response ollamaChatResponse
+	if err := postExtractionRequest(ctx, o.httpClient, o.baseURL+"/api/chat", nil, request, &response); err != nil {
+		logger.LogError("ExtractBusinessCardData", err, map[string]interface{}{
+			"step":       "chat",
+			"model_name": o.modelName,
+		})
+		return nil, fmt.Errorf("failed to generate content: %w", err)
+	}
+
+	if response.Error != "" {
+		return nil, fmt.Errorf("failed to generate content: %s", response.Error)
+	}
+
+	if response.Message.Content == "" {
+		logger.LogError("ExtractBusinessCardData", fmt.Errorf("no content generated"), map[string]interface{}{
+			"step": "validate_response",
+		})
+		return nil, fmt.Errorf("no content generated")
+	}
+
+	logger.LogDebug("ExtractBusinessCardData", "Received response from Ollama", map[string]interface{}{
+		"response_length": len(response.Message.Content),
+	})
+
+	return parseExtractionResponse(response.Message.Content, images)
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"business-card-reader/internal/config"
+	"business-card-reader/internal/logger"
+	"business-card-reader/internal/models"
+)
+
+// ExtractorOpenAI is the registry name of the OpenAI-compatible extraction provider
+const ExtractorOpenAI = "openai"
+
+func init() {
+	RegisterExtractor(ExtractorOpenAI, func(cfg *config.Config) (Extractor, error) {
+		return NewOpenAIExtractor(cfg.OpenAI.BaseURL, cfg.OpenAI.APIKey, cfg.OpenAI.ModelName)
+	})
+}
+
+// OpenAIExtractor talks to any server implementing the OpenAI /v1/chat/completions API
+// with image inputs, such as OpenAI itself, Azure OpenAI proxies, vLLM or LM Studio.
+type OpenAIExtractor struct {
+	baseURL    string
+	apiKey     string
+	modelName  string
+	httpClient *http.Client
+}
+
+type openAIChatRequest struct {
+	Model       string              `json:"model"`
+	Messages    []openAIChatMessage `json:"messages"`
+	Temperature float64             `json:"temperature"`
+}
+
+type openAIChatMessage struct {
+	Role    string              `json:"role"`
+	Content []openAIContentPart `json:"content"`
+}
+
+type openAIContentPart struct {
+	Type     string          `json:"type"`
+	Text     string          `json:"text,omitempty"`
+	ImageURL *openAIImageURL `json:"image_url,omitempty"`
+}
+
+type openAIImageURL struct {
+	URL string `json:"url"`
+}
+
+type openAIChatResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+	} `json:"choices"`
+}
+
+// NewOpenAIExtractor creates an extractor for the server at baseURL, which must include
+// the API version prefix (for example https://api.openai.com/v1). The API key is optional
+// for local servers that do not require one.
+func NewOpenAIExtractor(baseURL string, apiKey string, modelName string) (*OpenAIExtractor, error) {
+	if baseURL == "" {
+		return nil, fmt.Errorf("OpenAI base URL is required")
+	}
+	if modelName == "" {
+		return nil, fmt.Errorf("OpenAI model name is required")
+	}
+
+	logger.LogInfo("NewOpenAIExtractor", "OpenAI-compatible extractor initialized", map[string]interface{}{
+		"base_url":   baseURL,
+		"model_name": modelName,
+	})
+
+	return &OpenAIExtractor{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		apiKey:     apiKey,
+		modelName:  modelName,
+		httpClient: &http.Client{Timeout: extractorHTTPTimeout},
+	}, nil
+}
+
+func (o *OpenAIExtractor) Name() string {
+	return ExtractorOpenAI
+}
+
+func (o *OpenAIExtractor) ExtractBusinessCardData(ctx context.Context, images []models.ImageData) (*models.BusinessCard, error) {
+	logger.LogInfo("ExtractBusinessCardData", "Starting OpenAI-compatible processing", map[string]interface{}{
+		"image_count": len(images),
+		"model_name":  o.modelName,
+	})
+
+	parts := []openAIContentPart{{Type: "text", Text: buildExtractionPrompt()}}
+	for _, img := range images {
+		parts = append(parts, openAIContentPart{
+			Type: "image_url",
+			ImageURL: &openAIImageURL{
+				URL: fmt.Sprintf("data:%s;base64,%s", img.ContentType, base64.StdEncoding.EncodeToString(img.Data)),
+			},
+		})
+	}
+
+	request := openAIChatRequest{
+		Model:       o.modelName,
+		Messages:    []openAIChatMessage{{Role: "user", Content: parts}},
+		Temperature: 0,
+	}
+
+	headers := map[string]string{}
+	if o.apiKey != "" {
+		headers["Authorization"] = "Bearer " + o.apiKey
+	}
+
+	var response openAIChatResponse
+	if err := postExtractionRequest(ctx, o.httpClient, o.baseURL+"/chat/completions", headers, request, &response); err != nil {
+		logger.LogError("ExtractBusinessCardData", err, map[string]interface{}{
+			"step":       "chat_completion",
+			"model_name": o.modelName,
+		})
+		return nil, fmt.Errorf("failed to generate content: %w", err)
+	}
+
+	if len(response.Choices) == 0 || response.Choices[0].Message.Content == "" {
+		logger.LogError("ExtractBusinessCardData", fmt.Errorf("no content generated"), map[string]interface{}{
+			"step":          "validate_response",
+			"choices_count": len(response.Choices),
+		})
+		return nil, fmt.Errorf("no content generated")
+	}
+
+	responseText := response.Choices[0].Message.Content
+
+	logger.LogDebug("ExtractBusinessCardData", "Received response from OpenAI-compatible API", map[string]interface{}{
+		"response_length": len(responseText),
+	})
+
+	return parseExtractionResponse(responseText, images)
+}
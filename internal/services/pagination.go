@@ -0,0 +1,152 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"business-card-reader/internal/models"
+)
+
+// Page sizes for business card listings
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+// sortableTimeFormat is fixed-width UTC so that formatted timestamps sort lexicographically
+const sortableTimeFormat = "2006-01-02T15:04:05.000000000Z"
+
+// ErrInvalidQuery is returned when a listing query has an unsupported value or a malformed cursor
+var ErrInvalidQuery = errors.New("invalid query")
+
+// pageCursor is the opaque position handed out as next_cursor. Ordered backends resume after
+// SortValue and ID, DynamoDB resumes from its LastEvaluatedKey.
+type pageCursor struct {
+	SortValue string            `json:"s,omitempty"`
+	ID        string            `json:"id,omitempty"`
+	Key       map[string]string `json:"k,omitempty"`
+}
+
+func encodeCursor(cursor pageCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(encoded string) (*pageCursor, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidQuery)
+	}
+
+	var cursor pageCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidQuery)
+	}
+
+	return &cursor, nil
+}
+
+// normalizeBusinessCardQuery applies defaults and rejects unsupported values
+func normalizeBusinessCardQuery(query models.BusinessCardQuery) (models.BusinessCardQuery, error) {
+	query.Status = strings.ToUpper(strings.TrimSpace(query.Status))
+	query.CompanyName = strings.TrimSpace(query.CompanyName)
+
+	if query.SortBy == "" {
+		query.SortBy = models.SortByCreatedAt
+	}
+	if query.SortBy != models.SortByCreatedAt && query.SortBy != models.SortByProcessedAt {
+		return query, fmt.Errorf("%w: sort_by must be created_at or processed_at", ErrInvalidQuery)
+	}
+
+	query.SortOrder = strings.ToLower(query.SortOrder)
+	if query.SortOrder == "" {
+		query.SortOrder = models.SortOrderDesc
+	}
+	if query.SortOrder != models.SortOrderAsc && query.SortOrder != models.SortOrderDesc {
+		return query, fmt.Errorf("%w: order must be asc or desc", ErrInvalidQuery)
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = DefaultPageSize
+	}
+	if query.Limit > MaxPageSize {
+		query.Limit = MaxPageSize
+	}
+
+	if query.CreatedAfter != nil && query.CreatedBefore != nil && query.CreatedAfter.After(*query.CreatedBefore) {
+		return query, fmt.Errorf("%w: created_after must not be later than created_before", ErrInvalidQuery)
+	}
+
+	if _, err := decodeCursor(query.Cursor); err != nil {
+		return query, err
+	}
+
+	return query, nil
+}
+
+// normalizeCompanyName is the form company names are matched in
+func normalizeCompanyName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+func sortableTime(t time.Time) string {
+	return t.UTC().Format(sortableTimeFormat)
+}
+
+// businessCardSortValue returns the sortable value of the query's sort field
+func businessCardSortValue(businessCard *models.BusinessCard, sortBy string) string {
+	if sortBy == models.SortByProcessedAt {
+		return sortableTime(businessCard.ProcessedAt)
+	}
+	return sortableTime(businessCard.CreatedAt)
+}
+
+// matchesBusinessCardQuery applies the query filters to a single business card
+func matchesBusinessCardQuery(businessCard *models.BusinessCard, query models.BusinessCardQuery) bool {
+	if query.Status != "" && businessCard.Status != query.Status {
+		return false
+	}
+	if query.CompanyName != "" && normalizeCompanyName(businessCard.CompanyData.Name) != normalizeCompanyName(query.CompanyName) {
+		return false
+	}
+	if query.Email != "" && emailKey(businessCard.PersonalData.Email) != query.Email {
+		return false
+	}
+	if query.CreatedAfter != nil && businessCard.CreatedAt.Before(*query.CreatedAfter) {
+		return false
+	}
+	if query.CreatedBefore != nil && businessCard.CreatedAt.After(*query.CreatedBefore) {
+		return false
+	}
+	return true
+}
+
+// sortBusinessCards orders cards by the query's sort field, breaking ties by ID
+func sortBusinessCards(businessCards []models.BusinessCard, query models.BusinessCardQuery) {
+	sort.Slice(businessCards, func(i, j int) bool {
+		return precedes(businessCardSortValue(&businessCards[i], query.SortBy), businessCards[i].ID,
+			businessCardSortValue(&businessCards[j], query.SortBy), businessCards[j].ID, query.SortOrder)
+	})
+}
+
+// precedes reports whether position a comes before position b in the given sort order
+func precedes(aValue string, aID string, bValue string, bID string, sortOrder string) bool {
+	if aValue == bValue && aID == bID {
+		return false
+	}
+
+	descending := sortOrder == models.SortOrderDesc
+	if aValue != bValue {
+		return (aValue < bValue) != descending
+	}
+	return (aID < bID) != descending
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"business-card-reader/internal/models"
+)
+
+// seedListingCards stores cards whose processing order differs from their creation order, and one
+// card of another tenant
+func seedListingCards(t *testing.T, repository Repository) []models.BusinessCard {
+	t.Helper()
+	ctx := context.Background()
+	start := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	processingOrder := []int{4, 0, 6, 2, 5, 1, 3}
+
+	var businessCards []models.BusinessCard
+	for i, processed := range processingOrder {
+		businessCard := models.BusinessCard{
+			ID:          fmt.Sprintf("card-%d", i),
+			TenantID:    "acme",
+			Status:      models.StatusCompleted,
+			CreatedAt:   start.Add(time.Duration(i) * time.Minute),
+			ProcessedAt: start.Add(time.Hour + time.Duration(processed)*time.Minute),
+		}
+		if i%2 == 1 {
+			businessCard.Status = models.StatusFailed
+		}
+		if i%3 == 0 {
+			businessCard.CompanyData.Name = "Acme Corp"
+		}
+		if err := repository.SaveBusinessCard(ctx, &businessCard); err != nil {
+			t.Fatal(err)
+		}
+		businessCards = append(businessCards, businessCard)
+	}
+
+	other := models.BusinessCard{ID: "other", TenantID: "globex", Status: models.StatusCompleted, CreatedAt: start, ProcessedAt: start}
+	if err := repository.SaveBusinessCard(ctx, &other); err != nil {
+		t.Fatal(err)
+	}
+	return businessCards
+}
+
+func TestListBusinessCardsPagesInOrder(t *testing.T) {
+	acme := WithTenant(context.Background(), "acme")
+	createdAfter := time.Date(2024, 1, 15, 10, 1, 0, 0, time.UTC)
+
+	queries := map[string]models.BusinessCardQuery{
+		"all":           {},
+		"by status":     {Status: models.StatusCompleted},
+		"by company":    {CompanyName: "acme corp"},
+		"created after": {CreatedAfter: &createdAfter},
+	}
+
+	for backend, newRepository := range repositoryBackends {
+		t.Run(backend, func(t *testing.T) {
+			repository := newRepository(t)
+			if err := repository.CreateTableIfNotExists(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			businessCards := seedListingCards(t, repository)
+			service := NewBusinessCardService(repository, &stubExtractor{})
+
+			for name, filters := range queries {
+				for _, sortBy := range []string{models.SortByCreatedAt, models.SortByProcessedAt} {
+					for _, order := range []string{models.SortOrderAsc, models.SortOrderDesc} {
+						t.Run(fmt.Sprintf("%s by %s %s", name, sortBy, order), func(t *testing.T) {
+							query := filters
+							query.SortBy, query.SortOrder, query.Limit = sortBy, order, 2
+
+							var got []string
+							for pages := 0; ; pages++ {
+								if pages > len(businessCards) {
+									t.Fatal("cursor never ends")
+								}
+								page, err := service.ListBusinessCards(acme, query)
+								if err != nil {
+									t.Fatal(err)
+								}
+								for _, businessCard := range page.BusinessCards {
+									got = append(got, businessCard.ID)
+								}
+								if page.NextCursor == "" {
+									break
+								}
+								query.Cursor = page.NextCursor
+							}
+
+							if want := expectedListing(businessCards, query); !slices.Equal(got, want) {
+								t.Errorf("got %v, want %v", got, want)
+							}
+						})
+					}
+				}
+			}
+		})
+	}
+}
+
+// expectedListing filters and sorts the seeded cards the way every backend must
+func expectedListing(businessCards []models.BusinessCard, query models.BusinessCardQuery) []string {
+	query, _ = normalizeBusinessCardQuery(query)
+
+	var matching []models.BusinessCard
+	for _, businessCard := range businessCards {
+		if matchesBusinessCardQuery(&businessCard, query) {
+			matching = append(matching, businessCard)
+		}
+	}
+	sortBusinessCards(matching, query)
+
+	ids := make([]string, len(matching))
+	for i, businessCard := range matching {
+		ids[i] = businessCard.ID
+	}
+	return ids
+}
+
+func TestDynamoServiceRefusesProcessedAtSortWithoutIndexes(t *testing.T) {
+	_, client := newTestDynamoService(t)
+	for _, index := range businessCardIndexes {
+		client.DropIndex("business-card-reader", index.name)
+	}
+	repository := NewDynamoServiceWithClient(client, "business-card-reader")
+	if err := repository.CreateTableIfNotExists(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := repository.ListBusinessCards(context.Background(), models.BusinessCardQuery{SortBy: models.SortByProcessedAt, SortOrder: models.SortOrderDesc, Limit: 10})
+	if !errors.Is(err, ErrInvalidQuery) {
+		t.Errorf("got %v, want ErrInvalidQuery", err)
+	}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursors := []pageCursor{
+		{SortValue: "2024-01-15T10:00:00.000000000Z", ID: "card-1"},
+		{Key: map[string]string{"id": "acme#card-1", "list_pk": "acme#all", "created_at_key": "2024-01-15T10:00:00.000000000Z#card-1"}},
+	}
+	for _, cursor := range cursors {
+		decoded, err := decodeCursor(encodeCursor(cursor))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(*decoded, cursor) {
+			t.Errorf("decoded cursor = %+v, want %+v", *decoded, cursor)
+		}
+	}
+
+	if cursor, err := decodeCursor(""); cursor != nil || err != nil {
+		t.Errorf("empty cursor decoded to %+v (%v), want the first page", cursor, err)
+	}
+	for _, malformed := range []string{"not base64!", "bm90IGpzb24"} {
+		if _, err := decodeCursor(malformed); !errors.Is(err, ErrInvalidQuery) {
+			t.Errorf("decodeCursor(%q): got %v, want ErrInvalidQuery", malformed, err)
+		}
+	}
+}
+
+func TestNormalizeBusinessCardQuery(t *testing.T) {
+	after := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		query   models.BusinessCardQuery
+		want    models.BusinessCardQuery
+		wantErr bool
+	}{
+		{
+			name:  "defaults",
+			query: models.BusinessCardQuery{},
+			want:  models.BusinessCardQuery{SortBy: models.SortByCreatedAt, SortOrder: models.SortOrderDesc, Limit: DefaultPageSize},
+		},
+		{
+			name:  "cleans filters and caps the limit",
+			query: models.BusinessCardQuery{Status: " completed ", CompanyName: " Acme ", SortBy: models.SortByProcessedAt, SortOrder: "ASC", Limit: 1000},
+			want:  models.BusinessCardQuery{Status: models.StatusCompleted, CompanyName: "Acme", SortBy: models.SortByProcessedAt, SortOrder: models.SortOrderAsc, Limit: MaxPageSize},
+		},
+		{name: "unknown sort field", query: models.BusinessCardQuery{SortBy: "name"}, wantErr: true},
+		{name: "unknown order", query: models.BusinessCardQuery{SortOrder: "up"}, wantErr: true},
+		{name: "empty time range", query: models.BusinessCardQuery{CreatedAfter: &after, CreatedBefore: &before}, wantErr: true},
+		{name: "malformed cursor", query: models.BusinessCardQuery{Cursor: "%%%"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeBusinessCardQuery(tt.query)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidQuery) {
+					t.Errorf("got %v, want ErrInvalidQuery", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
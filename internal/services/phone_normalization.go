@@ -0,0 +1,146 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"business-card-reader/internal/config"
+	"business-card-reader/internal/models"
+
+	"github.com/ttacon/libphonenumber"
+)
+
+// PostProcessorPhones is the registry name of the phone number post-processor
+const PostProcessorPhones = "phones"
+
+func init() {
+	RegisterPostProcessor(PostProcessorPhones, func(cfg *config.Config) (PostProcessor, error) {
+		return newPhoneNumberProcessor(cfg.Phone.DefaultRegion)
+	})
+}
+
+// phoneFields are the fields normalized to E.164
+var phoneFields = []struct {
+	path  string
+	value func(businessCard *models.BusinessCard) string
+}{
+	{"personal_data.phone", func(c *models.BusinessCard) string { return c.PersonalData.Phone }},
+	{"personal_data.mobile", func(c *models.BusinessCard) string { return c.PersonalData.Mobile }},
+	{"company_data.phone", func(c *models.BusinessCard) string { return c.CompanyData.Phone }},
+}
+
+// countryRegions maps country names as they are printed on business cards to their ISO 3166
+// region codes. Two-letter codes are recognized without an entry.
+var countryRegions = map[string]string{
+	"argentina": "AR", "australia": "AU", "austria": "AT", "österreich": "AT", "belgium": "BE",
+	"belgië": "BE", "belgique": "BE", "brazil": "BR", "brasil": "BR", "canada": "CA", "chile": "CL",
+	"china": "CN", "colombia": "CO", "czech republic": "CZ", "czechia": "CZ", "denmark": "DK",
+	"danmark": "DK", "egypt": "EG", "finland": "FI", "suomi": "FI", "france": "FR", "germany": "DE",
+	"deutschland": "DE", "deu": "DE", "greece": "GR", "hong kong": "HK", "hungary": "HU", "india": "IN",
+	"indonesia": "ID", "ireland": "IE", "israel": "IL", "italy": "IT", "italia": "IT", "japan": "JP",
+	"korea": "KR", "south korea": "KR", "republic of korea": "KR", "luxembourg": "LU", "malaysia": "MY",
+	"mexico": "MX", "méxico": "MX", "netherlands": "NL", "the netherlands": "NL", "nederland": "NL",
+	"holland": "NL", "new zealand": "NZ", "nigeria": "NG", "norway": "NO", "norge": "NO", "peru": "PE",
+	"perú": "PE", "philippines": "PH", "poland": "PL", "polska": "PL", "portugal": "PT", "romania": "RO",
+	"românia": "RO", "russia": "RU", "saudi arabia": "SA", "singapore": "SG", "south africa": "ZA",
+	"spain": "ES", "españa": "ES", "sweden": "SE", "sverige": "SE", "switzerland": "CH", "schweiz": "CH",
+	"suisse": "CH", "svizzera": "CH", "taiwan": "TW", "thailand": "TH", "turkey": "TR", "türkiye": "TR",
+	"ukraine": "UA", "united arab emirates": "AE", "uae": "AE", "united kingdom": "GB", "uk": "GB",
+	"great britain": "GB", "england": "GB", "scotland": "GB", "wales": "GB", "gbr": "GB",
+	"united states": "US", "united states of america": "US", "usa": "US", "america": "US",
+	"vietnam": "VN", "viet nam": "VN",
+}
+
+// regionForCountry returns the region code of a country name or code, or "" when it isn't known
+func regionForCountry(country string) string {
+	key := strings.ToLower(strings.Join(strings.Fields(strings.ReplaceAll(country, ".", "")), " "))
+	if region, ok := countryRegions[key]; ok {
+		return region
+	}
+
+	region := strings.ToUpper(key)
+	if len(region) == 2 && libphonenumber.GetCountryCodeForRegion(region) != 0 {
+		return region
+	}
+	return ""
+}
+
+// phoneNumberProcessor parses the phone fields and records their E.164 form. Numbers without
+// country code are read in the region of the company address, falling back to the configured
+// default region.
+type phoneNumberProcessor struct {
+	defaultRegion string
+}
+
+func newPhoneNumberProcessor(defaultRegion string) (*phoneNumberProcessor, error) {
+	defaultRegion = strings.ToUpper(strings.TrimSpace(defaultRegion))
+	if defaultRegion != "" && regionForCountry(defaultRegion) != defaultRegion {
+		return nil, fmt.Errorf("unknown phone region %q", defaultRegion)
+	}
+	return &phoneNumberProcessor{defaultRegion: defaultRegion}, nil
+}
+
+func (p *phoneNumberProcessor) Name() string {
+	return PostProcessorPhones
+}
+
+func (p *phoneNumberProcessor) Process(businessCard *models.BusinessCard) []models.ValidationWarning {
+	region := regionForCountry(businessCard.CompanyData.Address.Country)
+	if region == "" {
+		region = p.defaultRegion
+	}
+
+	var warnings []models.ValidationWarning
+	businessCard.PhoneNumbers = nil
+	for _, field := range phoneFields {
+		original := field.value(businessCard)
+		if original == "" {
+			continue
+		}
+
+		phoneNumber := parsePhoneNumber(original, region)
+		phoneNumber.Field = field.path
+		if !phoneNumber.Valid {
+			warnings = append(warnings, models.ValidationWarning{Field: field.path, Message: phoneNumber.Error})
+		}
+		businessCard.PhoneNumbers = append(businessCard.PhoneNumbers, phoneNumber)
+	}
+	return warnings
+}
+
+func parsePhoneNumber(original string, region string) models.PhoneNumber {
+	phoneNumber := models.PhoneNumber{Original: original}
+
+	parsed, err := libphonenumber.Parse(original, region)
+	if err != nil {
+		switch {
+		case err == libphonenumber.ErrInvalidCountryCode && region == "":
+			phoneNumber.Error = "number has no country code and the country is unknown"
+		case err == libphonenumber.ErrInvalidCountryCode:
+			phoneNumber.Error = "unknown country code"
+		default:
+			phoneNumber.Error = "not a phone number"
+		}
+		return phoneNumber
+	}
+
+	phoneNumber.Region = libphonenumber.GetRegionCodeForNumber(parsed)
+	if !libphonenumber.IsValidNumber(parsed) {
+		phoneNumber.Error = "not a valid number for its region"
+		return phoneNumber
+	}
+
+	phoneNumber.E164 = libphonenumber.Format(parsed, libphonenumber.E164)
+	phoneNumber.Valid = true
+	return phoneNumber
+}
+
+// phoneNumberE164 returns the E.164 form of a phone field, or "" when it couldn't be normalized
+func phoneNumberE164(businessCard *models.BusinessCard, path string) string {
+	for _, phoneNumber := range businessCard.PhoneNumbers {
+		if phoneNumber.Field == path {
+			return phoneNumber.E164
+		}
+	}
+	return ""
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"testing"
+
+	"business-card-reader/internal/models"
+)
+
+func TestParsePhoneNumber(t *testing.T) {
+	tests := []struct {
+		name       string
+		original   string
+		region     string
+		wantE164   string
+		wantRegion string
+		wantError  string
+	}{
+		{name: "international", original: "+49 (30) 1234567", wantE164: "+49301234567", wantRegion: "DE"},
+		{name: "national in region", original: "030 1234567", region: "DE", wantE164: "+49301234567", wantRegion: "DE"},
+		{name: "international overrides region", original: "+44 20 7946 0958", region: "DE", wantE164: "+442079460958", wantRegion: "GB"},
+		{name: "international prefix", original: "0044 20 7946 0958", region: "DE", wantE164: "+442079460958", wantRegion: "GB"},
+		{name: "us number", original: "(415) 555-2671", region: "US", wantE164: "+14155552671", wantRegion: "US"},
+		{name: "unknown region", original: "030 1234567", wantError: "number has no country code and the country is unknown"},
+		{name: "invalid for region", original: "+49 12", wantRegion: "DE", wantError: "not a valid number for its region"},
+		{name: "text", original: "call me", region: "DE", wantError: "not a phone number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			phoneNumber := parsePhoneNumber(tt.original, tt.region)
+			if phoneNumber.Original != tt.original {
+				t.Errorf("original = %q", phoneNumber.Original)
+			}
+			if phoneNumber.E164 != tt.wantE164 || phoneNumber.Error != tt.wantError || phoneNumber.Valid != (tt.wantError == "") {
+				t.Errorf("got %+v, want E.164 %q with error %q", phoneNumber, tt.wantE164, tt.wantError)
+			}
+			if tt.wantRegion != "" && phoneNumber.Region != tt.wantRegion {
+				t.Errorf("region = %q, want %q", phoneNumber.Region, tt.wantRegion)
+			}
+		})
+	}
+}
+
+func TestRegionForCountry(t *testing.T) {
+	for country, want := range map[string]string{
+		"Germany":             "DE",
+		"DEUTSCHLAND":         "DE",
+		"U.S.A.":              "US",
+		"  united   kingdom ": "GB",
+		"fr":                  "FR",
+		"XX":                  "",
+		"Atlantis":            "",
+		"":                    "",
+	} {
+		if got := regionForCountry(country); got != want {
+			t.Errorf("regionForCountry(%q) = %q, want %q", country, got, want)
+		}
+	}
+}
+
+func TestPhoneNumberProcessor(t *testing.T) {
+	if _, err := newPhoneNumberProcessor("Atlantis"); err == nil {
+		t.Error("got a processor for an unknown default region")
+	}
+	processor, err := newPhoneNumberProcessor("us")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		country      string
+		wantE164     map[string]string
+		wantWarnings int
+	}{
+		{
+			name:    "region from the address",
+			country: "Germany",
+			wantE164: map[string]string{
+				"personal_data.phone":  "+49301234567",
+				"personal_data.mobile": "+491717654321",
+				"company_data.phone":   "",
+			},
+			wantWarnings: 1,
+		},
+		{
+			name:    "default region",
+			country: "",
+			wantE164: map[string]string{
+				"personal_data.phone":  "",
+				"personal_data.mobile": "+491717654321",
+				"company_data.phone":   "",
+			},
+			wantWarnings: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			businessCard := &models.BusinessCard{}
+			businessCard.PersonalData.Phone = "030 1234567"
+			businessCard.PersonalData.Mobile = "+49 171 7654321"
+			businessCard.CompanyData.Phone = "n/a"
+			businessCard.CompanyData.Address.Country = tt.country
+			// Numbers of an earlier run are replaced
+			businessCard.PhoneNumbers = []models.PhoneNumber{{Field: "personal_data.phone", E164: "+10000000000"}}
+
+			warnings := processor.Process(businessCard)
+			if len(warnings) != tt.wantWarnings {
+				t.Errorf("warnings = %+v, want %d", warnings, tt.wantWarnings)
+			}
+			if len(businessCard.PhoneNumbers) != len(phoneFields) {
+				t.Errorf("phone numbers = %+v, want one per field", businessCard.PhoneNumbers)
+			}
+			for path, want := range tt.wantE164 {
+				if got := phoneNumberE164(businessCard, path); got != want {
+					t.Errorf("%s = %q, want %q", path, got, want)
+				}
+			}
+		})
+	}
+}
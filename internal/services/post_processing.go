@@ -0,0 +1,118 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"business-card-reader/internal/config"
+	"business-card-reader/internal/logger"
+	"business-card-reader/internal/models"
+)
+
+// PostProcessor validates and canonicalizes extracted data. It changes the business card in place
+// and returns a warning for every value it couldn't make valid.
+type PostProcessor interface {
+	// Name identifies the post-processor in the configuration and in validation warnings
+	Name() string
+	Process(businessCard *models.BusinessCard) []models.ValidationWarning
+}
+
+// PostProcessorFactory builds a PostProcessor from the application configuration
+type PostProcessorFactory func(cfg *config.Config) (PostProcessor, error)
+
+var (
+	postProcessorRegistryMu sync.RWMutex
+	postProcessorRegistry   = map[string]PostProcessorFactory{}
+)
+
+// RegisterPostProcessor makes a post-processor available under the given name.
+// Post-processors register themselves from an init function in their own file.
+func RegisterPostProcessor(name string, factory PostProcessorFactory) {
+	postProcessorRegistryMu.Lock()
+	defer postProcessorRegistryMu.Unlock()
+
+	name = strings.ToLower(name)
+	if _, exists := postProcessorRegistry[name]; exists {
+		panic(fmt.Sprintf("post-processor %q registered twice", name))
+	}
+	postProcessorRegistry[name] = factory
+}
+
+// RegisteredPostProcessors returns the names of all available post-processors
+func RegisteredPostProcessors() []string {
+	postProcessorRegistryMu.RLock()
+	defer postProcessorRegistryMu.RUnlock()
+
+	names := make([]string, 0, len(postProcessorRegistry))
+	for name := range postProcessorRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewPostProcessors creates the chain selected by cfg.PostProcessing.Processors, in that order
+func NewPostProcessors(cfg *config.Config) ([]PostProcessor, error) {
+	processors := make([]PostProcessor, 0, len(cfg.PostProcessing.Processors))
+	seen := make(map[string]bool)
+	for _, name := range cfg.PostProcessing.Processors {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if seen[name] {
+			return nil, fmt.Errorf("post-processor %q configured twice", name)
+		}
+		seen[name] = true
+
+		postProcessorRegistryMu.RLock()
+		factory, ok := postProcessorRegistry[name]
+		postProcessorRegistryMu.RUnlock()
+
+		if !ok {
+			return nil, fmt.Errorf("unsupported post-processor %q, available post-processors: %s", name, strings.Join(RegisteredPostProcessors(), ", "))
+		}
+
+		processor, err := factory(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize post-processor %q: %w", name, err)
+		}
+		processors = append(processors, processor)
+	}
+
+	return processors, nil
+}
+
+// SetPostProcessors configures the chain that runs after every extraction, correction, import
+// and merge
+func (b *BusinessCardService) SetPostProcessors(processors []PostProcessor) {
+	names := make([]string, len(processors))
+	for i, processor := range processors {
+		names[i] = processor.Name()
+	}
+	logger.LogInfo("SetPostProcessors", "Post-processors configured", map[string]interface{}{
+		"post_processors": names,
+	})
+	b.postProcessors = processors
+}
+
+// postProcess runs the post-processor chain and replaces the card's validation warnings
+func (b *BusinessCardService) postProcess(businessCard *models.BusinessCard) {
+	businessCard.ValidationWarnings = nil
+	for _, processor := range b.postProcessors {
+		for _, warning := range processor.Process(businessCard) {
+			warning.Processor = processor.Name()
+			businessCard.ValidationWarnings = append(businessCard.ValidationWarnings, warning)
+		}
+	}
+
+	if len(businessCard.ValidationWarnings) > 0 {
+		fields := make([]string, len(businessCard.ValidationWarnings))
+		for i, warning := range businessCard.ValidationWarnings {
+			fields[i] = warning.Field
+		}
+		logger.LogWarn("PostProcess", "Business card has validation warnings", map[string]interface{}{
+			"business_card_id": businessCard.ID,
+			"fields":           fields,
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"business-card-reader/internal/config"
+	"business-card-reader/internal/models"
+)
+
+func TestFixNameCasing(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"JANE DOE", "Jane Doe"},
+		{"jean-luc o'brien", "Jean-Luc O'Brien"},
+		{"LUDWIG VAN BEETHOVEN", "Ludwig van Beethoven"},
+		{"VAN HALEN", "Van Halen"},
+		{"ÉMILE ZOLA", "Émile Zola"},
+		{"Ronald McDonald", "Ronald McDonald"},
+		{"DE", "De"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fixNameCasing(tt.name); got != tt.want {
+				t.Errorf("fixNameCasing(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanonicalWebAddress(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"www.Acme.COM", "https://www.acme.com"},
+		{"http://acme.com/", "http://acme.com"},
+		{"HTTPS://acme.com/Contact?ref=card.", "https://acme.com/Contact?ref=card"},
+		{"acme.com/", "https://acme.com"},
+		{"ftp://acme.com", ""},
+		{"acme", ""},
+		{"acme .com", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok := canonicalWebAddress(tt.value)
+			if got != tt.want || ok != (tt.want != "") {
+				t.Errorf("canonicalWebAddress(%q) = %q, %v, want %q", tt.value, got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestSocialProfileCanonicalURL(t *testing.T) {
+	profile := func(path string) socialProfile {
+		for _, profile := range socialProfiles {
+			if profile.path == path {
+				return profile
+			}
+		}
+		t.Fatalf("no social profile for %s", path)
+		return socialProfile{}
+	}
+	personalLinkedIn := profile("personal_data.linkedin")
+	companyLinkedIn := profile("company_data.social_media.linkedin")
+	twitter := profile("company_data.social_media.twitter")
+
+	tests := []struct {
+		name        string
+		profile     socialProfile
+		value       string
+		want        string
+		wantWarning bool
+	}{
+		{"linkedin handle", personalLinkedIn, "@janedoe", "https://www.linkedin.com/in/janedoe", false},
+		{"linkedin url without scheme", personalLinkedIn, "linkedin.com/in/jane-doe/", "https://www.linkedin.com/in/jane-doe", false},
+		{"linkedin url with query", personalLinkedIn, "https://de.linkedin.com/in/janedoe?trk=card", "https://www.linkedin.com/in/janedoe", false},
+		{"linkedin company page", companyLinkedIn, "www.linkedin.com/company/acme", "https://www.linkedin.com/company/acme", false},
+		{"company handle", companyLinkedIn, "acme", "https://www.linkedin.com/company/acme", false},
+		{"twitter handle", twitter, "@acme_corp", "https://x.com/acme_corp", false},
+		{"twitter url", twitter, "https://twitter.com/acme_corp", "https://x.com/acme_corp", false},
+		{"dotted handle", twitter, "acme.corp", "https://x.com/acme.corp", false},
+		{"other network", twitter, "https://facebook.com/acme", "", true},
+		{"lookalike host", twitter, "https://notx.com/acme", "", true},
+		{"too many segments", twitter, "x.com/acme/status/1", "", true},
+		{"handle with spaces", twitter, "acme corp", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, message := tt.profile.canonicalURL(tt.value)
+			if got != tt.want || (message != "") != tt.wantWarning {
+				t.Errorf("canonicalURL(%q) = %q, %q, want %q", tt.value, got, message, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostalCodeProcessor(t *testing.T) {
+	tests := []struct {
+		country     string
+		postalCode  string
+		want        string
+		wantWarning bool
+	}{
+		{"United States", "941031234", "94103-1234", false},
+		{"USA", "94103", "94103", false},
+		{"Canada", "m5v2t6", "M5V 2T6", false},
+		{"UK", "SW1A1AA", "SW1A 1AA", false},
+		{"GB", "ec1a 1bb", "EC1A 1BB", false},
+		{"Netherlands", "1012-AB", "1012 AB", false},
+		{"Germany", "10115", "10115", false},
+		{"Germany", "1011", "1011", true},
+		{"Japan", "1000001", "100-0001", false},
+		{"Atlantis", "ABC", "ABC", false},
+		{"", "10115", "10115", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.country+" "+tt.postalCode, func(t *testing.T) {
+			businessCard := &models.BusinessCard{}
+			businessCard.CompanyData.Address.Country = tt.country
+			businessCard.CompanyData.Address.PostalCode = tt.postalCode
+
+			warnings := postalCodeProcessor{}.Process(businessCard)
+			if got := businessCard.CompanyData.Address.PostalCode; got != tt.want {
+				t.Errorf("postal code = %q, want %q", got, tt.want)
+			}
+			if (len(warnings) > 0) != tt.wantWarning {
+				t.Errorf("warnings = %+v", warnings)
+			}
+		})
+	}
+}
+
+func TestNewPostProcessors(t *testing.T) {
+	tests := []struct {
+		name       string
+		processors []string
+		wantNames  []string
+		wantErr    string
+	}{
+		{"configured order", []string{"URLs", " names "}, []string{PostProcessorURLs, PostProcessorNames}, ""},
+		{"none", nil, nil, ""},
+		{"unknown", []string{"names", "spelling"}, nil, "unsupported post-processor"},
+		{"twice", []string{"names", "NAMES"}, nil, "configured twice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.PostProcessing.Processors = tt.processors
+
+			processors, err := NewPostProcessors(cfg)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("got %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, processor := range processors {
+				names = append(names, processor.Name())
+			}
+			if strings.Join(names, ",") != strings.Join(tt.wantNames, ",") {
+				t.Errorf("post-processors = %v, want %v", names, tt.wantNames)
+			}
+		})
+	}
+}
+
+func TestPostProcessChain(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.PostProcessing.Processors = RegisteredPostProcessors()
+	processors, err := NewPostProcessors(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := NewBusinessCardService(NewMemoryRepository(), &stubExtractor{})
+	service.SetPostProcessors(processors)
+
+	businessCard := &models.BusinessCard{
+		// Warnings of an earlier run are replaced
+		ValidationWarnings: []models.ValidationWarning{{Field: "personal_data.email", Message: "stale"}},
+	}
+	businessCard.PersonalData.FullName = "JANE DOE"
+	businessCard.PersonalData.Email = "<Jane.Doe@Acme.com>."
+	businessCard.PersonalData.LinkedIn = "@janedoe"
+	businessCard.CompanyData.Website = "Acme.com"
+	businessCard.CompanyData.Email = "info@acme"
+	businessCard.CompanyData.Address.Country = "Germany"
+	businessCard.CompanyData.Address.PostalCode = "1011"
+	businessCard.PersonalData.Phone = "030 1234567"
+
+	service.postProcess(businessCard)
+
+	want := []struct{ path, got, want string }{
+		{"full name", businessCard.PersonalData.FullName, "Jane Doe"},
+		{"email", businessCard.PersonalData.Email, "jane.doe@acme.com"},
+		{"linkedin", businessCard.PersonalData.LinkedIn, "https://www.linkedin.com/in/janedoe"},
+		{"website", businessCard.CompanyData.Website, "https://acme.com"},
+		{"phone", phoneNumberE164(businessCard, "personal_data.phone"), "+49301234567"},
+	}
+	for _, field := range want {
+		if field.got != field.want {
+			t.Errorf("%s = %q, want %q", field.path, field.got, field.want)
+		}
+	}
+
+	warned := make(map[string]string)
+	for _, warning := range businessCard.ValidationWarnings {
+		warned[warning.Field] = warning.Processor
+	}
+	wantWarnings := map[string]string{
+		"company_data.email":               PostProcessorEmails,
+		"company_data.address.postal_code": PostProcessorPostalCodes,
+	}
+	if len(warned) != len(wantWarnings) || len(businessCard.ValidationWarnings) != len(wantWarnings) {
+		t.Errorf("warnings = %+v, want one for each of %v", businessCard.ValidationWarnings, wantWarnings)
+	}
+	for field, processor := range wantWarnings {
+		if warned[field] != processor {
+			t.Errorf("warning for %s from %q, want %q", field, warned[field], processor)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+
+	"business-card-reader/internal/config"
+	"business-card-reader/internal/models"
+)
+
+// PostProcessorPostalCodes is the registry name of the postal code post-processor
+const PostProcessorPostalCodes = "postal_codes"
+
+func init() {
+	RegisterPostProcessor(PostProcessorPostalCodes, func(cfg *config.Config) (PostProcessor, error) {
+		return postalCodeProcessor{}, nil
+	})
+}
+
+// postalCodeFormat validates a postal code without spaces and hyphens. The canonical form has
+// the separator inserted at the given position, counted from the end when negative.
+type postalCodeFormat struct {
+	pattern   *regexp.Regexp
+	separator string
+	at        int
+}
+
+var (
+	fourDigits = postalCodeFormat{pattern: regexp.MustCompile(`^\d{4}$`)}
+	fiveDigits = postalCodeFormat{pattern: regexp.MustCompile(`^\d{5}$`)}
+	sixDigits  = postalCodeFormat{pattern: regexp.MustCompile(`^\d{6}$`)}
+)
+
+// postalCodeFormats are the postal code formats of the regions business cards commonly come from.
+// Other regions aren't checked.
+var postalCodeFormats = map[string]postalCodeFormat{
+	"US": {pattern: regexp.MustCompile(`^\d{5}(\d{4})?$`), separator: "-", at: 5},
+	"CA": {pattern: regexp.MustCompile(`^[A-Z]\d[A-Z]\d[A-Z]\d$`), separator: " ", at: 3},
+	"GB": {pattern: regexp.MustCompile(`^[A-Z]{1,2}\d[A-Z\d]?\d[A-Z]{2}$`), separator: " ", at: -3},
+	"IE": {pattern: regexp.MustCompile(`^[A-Z]\d[\dW][A-Z\d]{4}$`), separator: " ", at: 3},
+	"NL": {pattern: regexp.MustCompile(`^\d{4}[A-Z]{2}$`), separator: " ", at: 4},
+	"BR": {pattern: regexp.MustCompile(`^\d{8}$`), separator: "-", at: 5},
+	"JP": {pattern: regexp.MustCompile(`^\d{7}$`), separator: "-", at: 3},
+	"PT": {pattern: regexp.MustCompile(`^\d{7}$`), separator: "-", at: 4},
+	"PL": {pattern: regexp.MustCompile(`^\d{5}$`), separator: "-", at: 2},
+	"CZ": {pattern: regexp.MustCompile(`^\d{5}$`), separator: " ", at: 3},
+	"GR": {pattern: regexp.MustCompile(`^\d{5}$`), separator: " ", at: 3},
+	"SE": {pattern: regexp.MustCompile(`^\d{5}$`), separator: " ", at: 3},
+	"AR": fourDigits, "AT": fourDigits, "AU": fourDigits, "BE": fourDigits, "CH": fourDigits, "DK": fourDigits,
+	"HU": fourDigits, "LU": fourDigits, "NO": fourDigits, "NZ": fourDigits, "PH": fourDigits, "ZA": fourDigits,
+	"DE": fiveDigits, "EG": fiveDigits, "ES": fiveDigits, "FI": fiveDigits, "FR": fiveDigits, "ID": fiveDigits,
+	"IT": fiveDigits, "KR": fiveDigits, "MX": fiveDigits, "MY": fiveDigits, "SA": fiveDigits, "TH": fiveDigits,
+	"TR": fiveDigits, "UA": fiveDigits,
+	"CN": sixDigits, "CO": sixDigits, "IN": sixDigits, "RO": sixDigits, "RU": sixDigits, "SG": sixDigits,
+	"VN": sixDigits,
+}
+
+// postalCodeProcessor checks the postal code of the company address against the format of its
+// country and writes it in the canonical form
+type postalCodeProcessor struct{}
+
+func (postalCodeProcessor) Name() string {
+	return PostProcessorPostalCodes
+}
+
+func (postalCodeProcessor) Process(businessCard *models.BusinessCard) []models.ValidationWarning {
+	address := &businessCard.CompanyData.Address
+	if address.PostalCode == "" {
+		return nil
+	}
+
+	region := regionForCountry(address.Country)
+	format, ok := postalCodeFormats[region]
+	if !ok {
+		return nil
+	}
+
+	canonical, ok := format.canonical(address.PostalCode)
+	if !ok {
+		return []models.ValidationWarning{{
+			Field:   "company_data.address.postal_code",
+			Message: "not a valid postal code for " + region,
+		}}
+	}
+	address.PostalCode = canonical
+	return nil
+}
+
+func (f postalCodeFormat) canonical(postalCode string) (string, bool) {
+	compact := strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(postalCode))
+
+	if !f.pattern.MatchString(compact) {
+		return "", false
+	}
+
+	at := f.at
+	if at < 0 {
+		at += len(compact)
+	}
+	if f.separator == "" || at <= 0 || at >= len(compact) {
+		return compact, true
+	}
+	return compact[:at] + f.separator + compact[at:], true
+}
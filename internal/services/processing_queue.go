@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"business-card-reader/internal/logger"
+)
+
+// processingJobTimeout bounds the extraction of a single queued business card
+const processingJobTimeout = 5 * time.Minute
+
+// ErrProcessingQueueFull is returned when the async queue cannot accept more business cards
+var ErrProcessingQueueFull = errors.New("processing queue is full")
+
+// ProcessingQueue is a bounded worker pool that processes business cards by ID. Each job runs
+// with a context scoped to the card's tenant.
+type ProcessingQueue struct {
+	jobs     chan processingJob
+	workers  int
+	process  func(ctx context.Context, id string)
+	wg       sync.WaitGroup
+	mu       sync.RWMutex
+	stopped  bool
+	stopOnce sync.Once
+}
+
+func NewProcessingQueue(workers int, queueSize int, process func(ctx context.Context, id string)) *ProcessingQueue {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+
+	return &ProcessingQueue{
+		jobs:    make(chan processingJob, queueSize),
+		workers: workers,
+		process: process,
+	}
+}
+
+// Start launches the workers
+func (q *ProcessingQueue) Start() {
+	for i := 0; i < q.workers; i++ {
+		q.wg.Add(1)
+		go q.run(i)
+	}
+}
+
+type processingJob struct {
+	tenantID string
+	id       string
+}
+
+// Enqueue schedules a business card without blocking, failing when the queue is full
+func (q *ProcessingQueue) Enqueue(tenantID string, id string) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.stopped {
+		return errors.New("processing queue is stopped")
+	}
+
+	select {
+	case q.jobs <- processingJob{tenantID: tenantID, id: id}:
+		return nil
+	default:
+		return ErrProcessingQueueFull
+	}
+}
+
+// Stop rejects new jobs and waits for the queued ones to finish
+func (q *ProcessingQueue) Stop() {
+	q.stopOnce.Do(func() {
+		q.mu.Lock()
+		q.stopped = true
+		close(q.jobs)
+		q.mu.Unlock()
+
+		q.wg.Wait()
+	})
+}
+
+func (q *ProcessingQueue) run(worker int) {
+	defer q.wg.Done()
+
+	for job := range q.jobs {
+		logger.LogDebug("ProcessingQueue", "Worker picked up business card", map[string]interface{}{
+			"worker":           worker,
+			"business_card_id": job.id,
+			"tenant_id":        job.tenantID,
+		})
+
+		ctx, cancel := context.WithTimeout(WithTenant(context.Background(), job.tenantID), processingJobTimeout)
+		q.process(ctx, job.id)
+		cancel()
+	}
+}
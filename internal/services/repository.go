@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"business-card-reader/internal/config"
+	"business-card-reader/internal/logger"
+	"business-card-reader/internal/models"
+)
+
+// Supported storage backends for config.Config.Storage.Backend
+const (
+	StorageBackendDynamoDB = "dynamodb"
+	StorageBackendMemory   = "memory"
+	StorageBackendSQLite   = "sqlite"
+)
+
+// ErrBusinessCardNotFound is returned by repositories when no business card matches the requested ID
+var ErrBusinessCardNotFound = errors.New("business card not found")
+
+// ErrWebhookSubscriptionNotFound is returned by repositories when no webhook subscription matches the requested ID
+var ErrWebhookSubscriptionNotFound = errors.New("webhook subscription not found")
+
+// ErrAPIKeyNotFound is returned by repositories when no API key matches the requested ID
+var ErrAPIKeyNotFound = errors.New("API key not found")
+
+// ErrErasureReceiptNotFound is returned by repositories when no erasure receipt matches the requested ID
+var ErrErasureReceiptNotFound = errors.New("erasure receipt not found")
+
+// ErrBusinessCardChanged is returned by a conditional save when the stored business card no longer
+// matches the condition
+var ErrBusinessCardChanged = errors.New("business card was changed concurrently")
+
+// BusinessCardCondition is the state a conditional save expects the stored business card in
+type BusinessCardCondition struct {
+	Status string
+	// Reviewer is compared with the stored review's reviewer when set
+	Reviewer string
+}
+
+// Repository is implemented by every storage backend
+type Repository interface {
+	BusinessCardRepository
+	WebhookRepository
+	APIKeyRepository
+}
+
+// BusinessCardRepository is the storage abstraction used by BusinessCardService
+type BusinessCardRepository interface {
+	SaveBusinessCard(ctx context.Context, businessCard *models.BusinessCard) error
+	// SaveBusinessCardIf replaces the stored business card only while it matches the condition,
+	// returning ErrBusinessCardChanged otherwise. The check and the write are atomic.
+	SaveBusinessCardIf(ctx context.Context, businessCard *models.BusinessCard, condition BusinessCardCondition) error
+	GetBusinessCard(ctx context.Context, id string) (*models.BusinessCard, error)
+	GetAllBusinessCards(ctx context.Context) ([]models.BusinessCard, error)
+	GetBusinessCardsByStatus(ctx context.Context, status string) ([]models.BusinessCard, error)
+	// DeleteBusinessCard removes the record, returning ErrBusinessCardNotFound when it doesn't exist
+	DeleteBusinessCard(ctx context.Context, id string) error
+	// ListBusinessCards returns one page of business cards matching a normalized query
+	ListBusinessCards(ctx context.Context, query models.BusinessCardQuery) (*models.BusinessCardPage, error)
+	CreateTableIfNotExists(ctx context.Context) error
+	ErasureReceiptRepository
+}
+
+// ErasureReceiptRepository keeps the receipts of erasure requests for audits
+type ErasureReceiptRepository interface {
+	SaveErasureReceipt(ctx context.Context, receipt *models.ErasureReceipt) error
+	GetErasureReceipt(ctx context.Context, id string) (*models.ErasureReceipt, error)
+	GetAllErasureReceipts(ctx context.Context) ([]models.ErasureReceipt, error)
+}
+
+// WebhookRepository stores webhook subscriptions and their delivery log
+type WebhookRepository interface {
+	SaveWebhookSubscription(ctx context.Context, subscription *models.WebhookSubscription) error
+	GetWebhookSubscription(ctx context.Context, id string) (*models.WebhookSubscription, error)
+	GetAllWebhookSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error)
+	DeleteWebhookSubscription(ctx context.Context, id string) error
+	SaveWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	GetWebhookDeliveriesBySubscription(ctx context.Context, subscriptionID string) ([]models.WebhookDelivery, error)
+	// GetDueWebhookDeliveries returns pending deliveries whose next attempt is at or before now
+	GetDueWebhookDeliveries(ctx context.Context, now time.Time) ([]models.WebhookDelivery, error)
+}
+
+// APIKeyRepository stores API keys, including revoked ones
+type APIKeyRepository interface {
+	SaveAPIKey(ctx context.Context, apiKey *models.APIKey) error
+	GetAPIKey(ctx context.Context, id string) (*models.APIKey, error)
+	GetAllAPIKeys(ctx context.Context) ([]models.APIKey, error)
+}
+
+// NewRepository creates the repository selected by cfg.Storage.Backend
+func NewRepository(cfg *config.Config) (Repository, error) {
+	backend := strings.ToLower(cfg.Storage.Backend)
+
+	logger.LogInfo("NewRepository", "Initializing repository", map[string]interface{}{
+		"backend": backend,
+	})
+
+	switch backend {
+	case StorageBackendDynamoDB, "":
+		dynamoService, err := NewDynamoService(cfg.AWS.Region)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Webhooks.DeliveryRetention > 0 {
+			dynamoService.SetWebhookDeliveryRetention(cfg.Webhooks.DeliveryRetention)
+		}
+		return dynamoService, nil
+	case StorageBackendMemory:
+		return NewMemoryRepository(), nil
+	case StorageBackendSQLite:
+		sqliteRepository, err := NewSQLiteRepository(cfg.Storage.SQLitePath)
+		if err != nil {
+			return nil, err
+		}
+		return sqliteRepository, nil
+	default:
+		return nil, fmt.Errorf("unsupported storage backend: %s", cfg.Storage.Backend)
+	}
+}
@@ -0,0 +1,285 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"business-card-reader/internal/config"
+	"business-card-reader/internal/models"
+)
+
+// fullBusinessCard fills every kind of field a repository has to keep
+func fullBusinessCard(id string, status string, created time.Time) models.BusinessCard {
+	retried := created.Add(time.Hour)
+	businessCard := models.BusinessCard{
+		ID:                 id,
+		TenantID:           "acme",
+		Images:             []models.ImageData{{FileName: "front.jpg", ContentType: "image/jpeg", Data: []byte("jpeg bytes")}},
+		ExtractedText:      "Jane Doe\nAcme Corp",
+		ExtractionProvider: "gemini",
+		Source:             models.SourceScan,
+		CreatedAt:          created,
+		ProcessedAt:        created.Add(time.Minute),
+		Status:             status,
+		RetryCount:         1,
+		LastRetryAt:        &retried,
+		VerifiedFields:     []string{"personal_data.email"},
+		FieldConfidence:    map[string]float64{"personal_data.full_name": 0.9},
+		Review:             &models.Review{State: models.ReviewStateClaimed, Reviewer: "alice"},
+	}
+	businessCard.PersonalData.FullName = "Jane Doe"
+	businessCard.PersonalData.Email = "jane@acme.com"
+	businessCard.CompanyData.Name = "Acme Corp"
+	businessCard.CompanyData.Address.City = "Berlin"
+	return businessCard
+}
+
+func TestRepositoryStoresBusinessCards(t *testing.T) {
+	ctx := WithTenant(context.Background(), "acme")
+	created := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	for backend, newRepository := range repositoryBackends {
+		t.Run(backend, func(t *testing.T) {
+			repository := newRepository(t)
+			if err := repository.CreateTableIfNotExists(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			first := fullBusinessCard("card-1", models.StatusCompleted, created)
+			second := fullBusinessCard("card-2", models.StatusFailed, created.Add(time.Minute))
+			for _, businessCard := range []models.BusinessCard{second, first} {
+				if err := repository.SaveBusinessCard(ctx, &businessCard); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			stored, err := repository.GetBusinessCard(ctx, "card-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(*stored, first) {
+				t.Errorf("stored card differs:\ngot  %+v\nwant %+v", *stored, first)
+			}
+
+			all, err := repository.GetAllBusinessCards(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(all) != 2 {
+				t.Errorf("got %d business cards, want 2", len(all))
+			}
+
+			failed, err := repository.GetBusinessCardsByStatus(ctx, models.StatusFailed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(failed) != 1 || failed[0].ID != "card-2" {
+				t.Errorf("failed business cards = %+v, want card-2", failed)
+			}
+
+			t.Run("overwrite", func(t *testing.T) {
+				second.Status = models.StatusCompleted
+				second.Error = ""
+				if err := repository.SaveBusinessCard(ctx, &second); err != nil {
+					t.Fatal(err)
+				}
+				if failed, err := repository.GetBusinessCardsByStatus(ctx, models.StatusFailed); err != nil || len(failed) != 0 {
+					t.Errorf("%d failed business cards after the overwrite (%v)", len(failed), err)
+				}
+				if all, err := repository.GetAllBusinessCards(ctx); err != nil || len(all) != 2 {
+					t.Errorf("%d business cards after the overwrite (%v), want 2", len(all), err)
+				}
+			})
+
+			t.Run("conditional save", func(t *testing.T) {
+				claimed := fullBusinessCard("card-1", models.StatusInReview, created)
+				mismatches := []BusinessCardCondition{
+					{Status: models.StatusNeedsReview},
+					{Status: models.StatusCompleted, Reviewer: "bob"},
+				}
+				for _, condition := range mismatches {
+					if err := repository.SaveBusinessCardIf(ctx, &claimed, condition); !errors.Is(err, ErrBusinessCardChanged) {
+						t.Errorf("condition %+v: got %v, want ErrBusinessCardChanged", condition, err)
+					}
+				}
+				if err := repository.SaveBusinessCardIf(ctx, &claimed, BusinessCardCondition{Status: models.StatusCompleted, Reviewer: "alice"}); err != nil {
+					t.Fatal(err)
+				}
+				if stored, err := repository.GetBusinessCard(ctx, "card-1"); err != nil || stored.Status != models.StatusInReview {
+					t.Errorf("conditional save stored %+v (%v)", stored, err)
+				}
+
+				missing := fullBusinessCard("missing", models.StatusCompleted, created)
+				if err := repository.SaveBusinessCardIf(ctx, &missing, BusinessCardCondition{Status: models.StatusCompleted}); !errors.Is(err, ErrBusinessCardChanged) {
+					t.Errorf("conditional save of a missing card: got %v, want ErrBusinessCardChanged", err)
+				}
+			})
+
+			t.Run("delete", func(t *testing.T) {
+				if err := repository.DeleteBusinessCard(ctx, "card-1"); err != nil {
+					t.Fatal(err)
+				}
+				if _, err := repository.GetBusinessCard(ctx, "card-1"); !errors.Is(err, ErrBusinessCardNotFound) {
+					t.Errorf("get after delete: got %v, want ErrBusinessCardNotFound", err)
+				}
+				if err := repository.DeleteBusinessCard(ctx, "card-1"); !errors.Is(err, ErrBusinessCardNotFound) {
+					t.Errorf("second delete: got %v, want ErrBusinessCardNotFound", err)
+				}
+			})
+		})
+	}
+}
+
+func TestMemoryRepositoryReturnsCopies(t *testing.T) {
+	ctx := context.Background()
+	repository := NewMemoryRepository()
+	businessCard := fullBusinessCard("card-1", models.StatusCompleted, time.Now())
+	businessCard.TenantID = ""
+	if err := repository.SaveBusinessCard(ctx, &businessCard); err != nil {
+		t.Fatal(err)
+	}
+
+	// Changes after the save and to fetched copies stay out of the store
+	businessCard.Images[0].Data[0] = 'X'
+	fetched, err := repository.GetBusinessCard(ctx, "card-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fetched.PersonalData.FullName = "Changed"
+	fetched.VerifiedFields[0] = "changed"
+
+	stored, err := repository.GetBusinessCard(ctx, "card-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.PersonalData.FullName != "Jane Doe" || stored.VerifiedFields[0] != "personal_data.email" || string(stored.Images[0].Data) != "jpeg bytes" {
+		t.Errorf("stored card was changed through a copy: %+v", stored)
+	}
+}
+
+func TestSQLiteRepositoryPersists(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cards.db")
+
+	repository, err := NewSQLiteRepository(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repository.CreateTableIfNotExists(ctx); err != nil {
+		t.Fatal(err)
+	}
+	businessCard := fullBusinessCard("card-1", models.StatusCompleted, time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC))
+	businessCard.TenantID = ""
+	if err := repository.SaveBusinessCard(ctx, &businessCard); err != nil {
+		t.Fatal(err)
+	}
+	repository.Close()
+
+	reopened, err := NewSQLiteRepository(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	if err := reopened.CreateTableIfNotExists(ctx); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := reopened.GetBusinessCard(ctx, "card-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.PersonalData.FullName != "Jane Doe" || string(stored.Images[0].Data) != "jpeg bytes" {
+		t.Errorf("reopened database returned %+v", stored)
+	}
+}
+
+func TestSQLiteRepositoryMigratesOlderDatabases(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cards.db")
+
+	// The table as the first release created it
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`CREATE TABLE business_cards (id TEXT PRIMARY KEY, status TEXT NOT NULL, created_at TEXT NOT NULL, data TEXT NOT NULL)`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`INSERT INTO business_cards (id, status, created_at, data) VALUES (?, ?, ?, ?)`,
+		"legacy", models.StatusCompleted, "2024-01-15T10:00:00Z",
+		`{"id":"legacy","status":"COMPLETED","created_at":"2024-01-15T10:00:00Z","processed_at":"2024-01-15T10:01:00Z","company_data":{"name":"Acme Corp"},"personal_data":{"email":"Jane@Acme.com"}}`,
+	); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	repository, err := NewSQLiteRepository(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer repository.Close()
+	if err := repository.CreateTableIfNotExists(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// The card moved to the default tenant and can be found through the new columns
+	queries := map[string]models.BusinessCardQuery{
+		"by company":           {CompanyName: "acme corp"},
+		"by email":             {Email: "jane@acme.com"},
+		"sorted by processing": {SortBy: models.SortByProcessedAt},
+	}
+	for name, query := range queries {
+		query, err := normalizeBusinessCardQuery(query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		page, err := repository.ListBusinessCards(ctx, query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page.BusinessCards) != 1 || page.BusinessCards[0].ID != "legacy" {
+			t.Errorf("%s: got %+v, want the legacy card", name, page.BusinessCards)
+		}
+	}
+}
+
+func TestNewRepository(t *testing.T) {
+	tests := []struct {
+		backend string
+		wantErr bool
+	}{
+		{StorageBackendMemory, false},
+		{StorageBackendSQLite, false},
+		{"SQLite", false},
+		{"postgres", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.backend, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.Storage.Backend = tt.backend
+			cfg.Storage.SQLitePath = filepath.Join(t.TempDir(), "cards.db")
+
+			repository, err := NewRepository(cfg)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("got a repository, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if closer, ok := repository.(interface{ Close() error }); ok {
+				defer closer.Close()
+			}
+			if err := repository.CreateTableIfNotExists(context.Background()); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
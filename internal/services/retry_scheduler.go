@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"business-card-reader/internal/config"
+	"business-card-reader/internal/logger"
+	"business-card-reader/internal/models"
+)
+
+// RetryScheduler periodically retries FAILED business cards of every tenant with exponential
+// backoff and moves them to DEAD_LETTER once they have used up their attempts
+type RetryScheduler struct {
+	service        *BusinessCardService
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	interval       time.Duration
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func NewRetryScheduler(service *BusinessCardService, cfg *config.Config) *RetryScheduler {
+	maxAttempts := cfg.Retry.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	return &RetryScheduler{
+		service:        service,
+		maxAttempts:    maxAttempts,
+		initialBackoff: cfg.Retry.InitialBackoff,
+		maxBackoff:     cfg.Retry.MaxBackoff,
+		interval:       cfg.Retry.Interval,
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+}
+
+// Start launches the scheduler loop
+func (r *RetryScheduler) Start() {
+	go r.run()
+
+	logger.LogInfo("RetryScheduler", "Retry scheduler started", map[string]interface{}{
+		"max_attempts":    r.maxAttempts,
+		"initial_backoff": r.initialBackoff.String(),
+		"max_backoff":     r.maxBackoff.String(),
+		"interval":        r.interval.String(),
+	})
+}
+
+// Stop ends the loop, waiting for the retry in progress to finish
+func (r *RetryScheduler) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.stop)
+		<-r.done
+	})
+}
+
+func (r *RetryScheduler) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			r.retryDueBusinessCards()
+		}
+	}
+}
+
+func (r *RetryScheduler) retryDueBusinessCards() {
+	failedCards, err := r.service.GetFailedBusinessCards(WithAllTenants(context.Background()))
+	if err != nil {
+		logger.LogError("RetryScheduler", err, map[string]interface{}{
+			"step": "load_failed_business_cards",
+		})
+		return
+	}
+
+	now := time.Now()
+	for _, businessCard := range failedCards {
+		select {
+		case <-r.stop:
+			return
+		default:
+		}
+
+		ctx := WithTenant(context.Background(), recordTenant(businessCard.TenantID))
+		if businessCard.RetryCount >= r.maxAttempts {
+			if _, err := r.service.MoveToDeadLetter(ctx, businessCard.ID); err != nil {
+				logger.LogError("RetryScheduler", err, map[string]interface{}{
+					"step":             "move_to_dead_letter",
+					"business_card_id": businessCard.ID,
+				})
+			}
+			continue
+		}
+
+		if !r.isDue(businessCard, now) {
+			continue
+		}
+
+		logger.LogInfo("RetryScheduler", "Retrying failed business card", map[string]interface{}{
+			"business_card_id": businessCard.ID,
+			"retry_count":      businessCard.RetryCount,
+		})
+
+		retryCtx, cancel := context.WithTimeout(ctx, processingJobTimeout)
+		_, err := r.service.RetryFailedProcessing(retryCtx, businessCard.ID)
+		cancel()
+		if errors.Is(err, ErrExtractionBusy) {
+			// Leave the remaining cards for the next run instead of waiting for a slot for each
+			logger.LogInfo("RetryScheduler", "Extraction provider busy, postponing retries", map[string]interface{}{
+				"business_card_id": businessCard.ID,
+			})
+			return
+		}
+		if err != nil {
+			logger.LogWarn("RetryScheduler", "Scheduled retry failed", map[string]interface{}{
+				"business_card_id": businessCard.ID,
+				"error":            err.Error(),
+			})
+		}
+	}
+}
+
+// isDue reports whether the backoff since the last attempt has elapsed
+func (r *RetryScheduler) isDue(businessCard models.BusinessCard, now time.Time) bool {
+	if businessCard.LastRetryAt == nil {
+		return true
+	}
+
+	delay := exponentialBackoff(r.initialBackoff, r.maxBackoff, businessCard.RetryCount)
+	return !now.Before(businessCard.LastRetryAt.Add(delay))
+}
+
+// exponentialBackoff returns the delay after the given number of failed attempts,
+// starting at initial and doubling each time up to max
+func exponentialBackoff(initial time.Duration, max time.Duration, attempts int) time.Duration {
+	delay := initial
+	for i := 1; i < attempts && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	return delay
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"slices"
+
+	"business-card-reader/internal/models"
+)
+
+// roleIncludes lists the roles each role grants besides itself
+var roleIncludes = map[string][]string{
+	models.RoleAdmin:    {models.RoleViewer, models.RoleScanner, models.RoleReviewer},
+	models.RoleScanner:  {models.RoleViewer},
+	models.RoleReviewer: {models.RoleViewer},
+}
+
+// scopeRoles maps API key scopes to the roles they grant
+var scopeRoles = map[string][]string{
+	models.ScopeRead:  {models.RoleViewer},
+	models.ScopeWrite: {models.RoleScanner, models.RoleReviewer},
+	models.ScopeAdmin: {models.RoleAdmin},
+}
+
+// IsRole reports whether the role is one of the models.Role constants
+func IsRole(role string) bool {
+	return role == models.RoleViewer || role == models.RoleScanner || role == models.RoleReviewer || role == models.RoleAdmin
+}
+
+// HasRole reports whether the granted roles include the required one, directly or through a
+// role that includes it
+func HasRole(granted []string, required string) bool {
+	for _, role := range granted {
+		if role == required || slices.Contains(roleIncludes[role], required) {
+			return true
+		}
+	}
+	return false
+}
+
+// ScopeRoles returns the roles granted by API key scopes
+func ScopeRoles(scopes []string) []string {
+	var roles []string
+	for _, scope := range scopes {
+		for _, role := range scopeRoles[scope] {
+			if !slices.Contains(roles, role) {
+				roles = append(roles, role)
+			}
+		}
+	}
+	return roles
+}
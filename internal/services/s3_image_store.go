@@ -0,0 +1,102 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// S3ImageStore keeps images in an S3 bucket. Any S3-compatible server such as MinIO can be
+// used by setting a custom endpoint with path-style addressing.
+type S3ImageStore struct {
+	client *s3.Client
+	bucket string
+}
+
+func NewS3ImageStore(ctx context.Context, region string, bucket string, endpoint string, usePathStyle bool) (*S3ImageStore, error) {
+	if bucket == "" {
+		return nil, fmt.Errorf("an S3 bucket is required for the s3 image store")
+	}
+
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+	if err != nil {
+		log.Printf("[S3ImageStore] Failed to load AWS config: %v", err)
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+		o.UsePathStyle = usePathStyle
+	})
+
+	log.Printf("[S3ImageStore] Initialized with bucket: %s, region: %s", bucket, region)
+
+	return &S3ImageStore{
+		client: client,
+		bucket: bucket,
+	}, nil
+}
+
+func (s *S3ImageStore) Name() string {
+	return ImageStoreS3
+}
+
+func (s *S3ImageStore) PutImage(ctx context.Context, key string, contentType string, data []byte) error {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(s.bucket),
+		Key:           aws.String(key),
+		Body:          bytes.NewReader(data),
+		ContentType:   aws.String(contentType),
+		ContentLength: aws.Int64(int64(len(data))),
+	})
+	if err != nil {
+		log.Printf("[S3ImageStore] Failed to upload image %s: %v", key, err)
+		return fmt.Errorf("failed to upload image: %w", err)
+	}
+
+	return nil
+}
+
+func (s *S3ImageStore) GetImage(ctx context.Context, key string) ([]byte, error) {
+	result, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, ErrImageNotFound
+		}
+		return nil, fmt.Errorf("failed to download image: %w", err)
+	}
+	defer result.Body.Close()
+
+	data, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+
+	return data, nil
+}
+
+func (s *S3ImageStore) DeleteImage(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete image: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeS3 serves the path-style object calls S3ImageStore makes from memory
+type fakeS3 struct {
+	mu           sync.Mutex
+	objects      map[string][]byte
+	contentTypes map[string]string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		f.objects[key] = data
+		f.contentTypes[key] = r.Header.Get("Content-Type")
+		w.Header().Set("ETag", `"etag"`)
+	case http.MethodGet:
+		data, ok := f.objects[key]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`)
+			return
+		}
+		w.Header().Set("Content-Type", f.contentTypes[key])
+		w.Write(data)
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestS3ImageStore(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	t.Setenv("AWS_SESSION_TOKEN", "")
+
+	fake := &fakeS3{objects: make(map[string][]byte), contentTypes: make(map[string]string)}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	ctx := context.Background()
+	store, err := NewS3ImageStore(ctx, "us-east-1", "cards", server.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := imageObjectKey("acme", "card-1", 0, "image/png")
+	if err := store.PutImage(ctx, key, "image/png", []byte("png bytes")); err != nil {
+		t.Fatalf("PutImage() error = %v", err)
+	}
+	if got := string(fake.objects["cards/"+key]); got != "png bytes" {
+		t.Fatalf("stored object = %q, want it at cards/%s", got, key)
+	}
+	if got := fake.contentTypes["cards/"+key]; got != "image/png" {
+		t.Errorf("content type = %q", got)
+	}
+
+	data, err := store.GetImage(ctx, key)
+	if err != nil || string(data) != "png bytes" {
+		t.Fatalf("GetImage() = %q, %v", data, err)
+	}
+
+	if err := store.DeleteImage(ctx, key); err != nil {
+		t.Fatalf("DeleteImage() error = %v", err)
+	}
+	if _, err := store.GetImage(ctx, key); !errors.Is(err, ErrImageNotFound) {
+		t.Fatalf("GetImage() after delete error = %v, want ErrImageNotFound", err)
+	}
+}
+
+func TestBusinessCardServiceWithS3ImageStore(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+
+	fake := &fakeS3{objects: make(map[string][]byte), contentTypes: make(map[string]string)}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	ctx := WithTenant(context.Background(), "acme")
+	store, err := NewS3ImageStore(ctx, "us-east-1", "cards", server.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repository := NewMemoryRepository()
+	service := NewBusinessCardService(repository, &stubExtractor{})
+	service.SetImageStore(store)
+
+	businessCard, err := service.ProcessBusinessCard(ctx, testUpload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The record only references the object, the bytes are in the bucket
+	record, err := repository.GetBusinessCard(ctx, businessCard.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	image := record.Images[0]
+	if len(image.Data) != 0 || image.ObjectKey == "" || image.Checksum == "" {
+		t.Fatalf("stored image = %+v, want only a reference", image)
+	}
+	if string(fake.objects["cards/"+image.ObjectKey]) != "jpeg bytes" {
+		t.Fatalf("bucket has %v", fake.objects)
+	}
+
+	loaded, err := service.GetBusinessCard(ctx, businessCard.ID)
+	if err != nil || string(loaded.Images[0].Data) != "jpeg bytes" {
+		t.Fatalf("GetBusinessCard() images = %+v, %v", loaded.Images, err)
+	}
+
+	// A corrupted object fails the checksum check
+	fake.objects["cards/"+image.ObjectKey] = []byte("tampered")
+	if _, err := service.GetBusinessCard(ctx, businessCard.ID); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("GetBusinessCard() error = %v, want a checksum mismatch", err)
+	}
+}
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"business-card-reader/internal/models"
+)
+
+// Supported spreadsheet export formats
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatXLSX = "xlsx"
+)
+
+// Column presets matching the import formats of common tools
+const (
+	ExportPresetDefault        = "default"
+	ExportPresetSalesforce     = "salesforce"
+	ExportPresetHubSpot        = "hubspot"
+	ExportPresetGoogleContacts = "google"
+)
+
+// ErrInvalidExport is returned for unknown formats, presets or column fields
+var ErrInvalidExport = errors.New("invalid export")
+
+// ExportOptions selects the file format and the columns of a spreadsheet export. Columns
+// overrides the preset; each entry is a field path, optionally followed by ":" and the header.
+type ExportOptions struct {
+	Format  string
+	Preset  string
+	Columns []string
+}
+
+// exportColumn maps a business card field, or a constant value, to a spreadsheet column
+type exportColumn struct {
+	Header string
+	Field  string
+	Value  string
+}
+
+// exportFields resolves every exportable field path to its value, exportFieldOrder is the
+// column order of the default preset
+var exportFields, exportFieldOrder = buildExportFields()
+
+func buildExportFields() (map[string]func(businessCard *models.BusinessCard) string, []string) {
+	fields := map[string]func(businessCard *models.BusinessCard) string{
+		"id":                  func(c *models.BusinessCard) string { return c.ID },
+		"status":              func(c *models.BusinessCard) string { return c.Status },
+		"created_at":          func(c *models.BusinessCard) string { return formatExportTime(c.CreatedAt) },
+		"processed_at":        func(c *models.BusinessCard) string { return formatExportTime(c.ProcessedAt) },
+		"extraction_provider": func(c *models.BusinessCard) string { return c.ExtractionProvider },
+	}
+	order := []string{"id", "status", "created_at", "processed_at", "extraction_provider"}
+
+	for _, field := range editableFields {
+		value := field.value
+		fields[field.path] = func(c *models.BusinessCard) string { return *value(c) }
+		order = append(order, field.path)
+	}
+
+	for _, field := range phoneFields {
+		path := field.path
+		fields[path+"_e164"] = func(c *models.BusinessCard) string { return phoneNumberE164(c, path) }
+		order = append(order, path+"_e164")
+	}
+
+	return fields, order
+}
+
+func formatExportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+var exportPresets = map[string][]exportColumn{
+	ExportPresetSalesforce: {
+		{Header: "First Name", Field: "personal_data.first_name"},
+		{Header: "Last Name", Field: "personal_data.last_name"},
+		{Header: "Title", Field: "personal_data.job_title"},
+		{Header: "Company", Field: "company_data.name"},
+		{Header: "Industry", Field: "company_data.industry"},
+		{Header: "Email", Field: "personal_data.email"},
+		{Header: "Phone", Field: "personal_data.phone"},
+		{Header: "Mobile", Field: "personal_data.mobile"},
+		{Header: "Website", Field: "company_data.website"},
+		{Header: "Street", Field: "company_data.address.street"},
+		{Header: "City", Field: "company_data.address.city"},
+		{Header: "State/Province", Field: "company_data.address.state"},
+		{Header: "Zip/Postal Code", Field: "company_data.address.postal_code"},
+		{Header: "Country", Field: "company_data.address.country"},
+		{Header: "Lead Source", Value: "Business Card"},
+	},
+	ExportPresetHubSpot: {
+		{Header: "First Name", Field: "personal_data.first_name"},
+		{Header: "Last Name", Field: "personal_data.last_name"},
+		{Header: "Email", Field: "personal_data.email"},
+		{Header: "Phone Number", Field: "personal_data.phone"},
+		{Header: "Mobile Phone Number", Field: "personal_data.mobile"},
+		{Header: "Job Title", Field: "personal_data.job_title"},
+		{Header: "Company Name", Field: "company_data.name"},
+		{Header: "Industry", Field: "company_data.industry"},
+		{Header: "Website URL", Field: "company_data.website"},
+		{Header: "Street Address", Field: "company_data.address.street"},
+		{Header: "City", Field: "company_data.address.city"},
+		{Header: "State/Region", Field: "company_data.address.state"},
+		{Header: "Postal Code", Field: "company_data.address.postal_code"},
+		{Header: "Country/Region", Field: "company_data.address.country"},
+	},
+	ExportPresetGoogleContacts: {
+		{Header: "Name", Field: "personal_data.full_name"},
+		{Header: "Given Name", Field: "personal_data.first_name"},
+		{Header: "Family Name", Field: "personal_data.last_name"},
+		{Header: "Organization 1 - Name", Field: "company_data.name"},
+		{Header: "Organization 1 - Title", Field: "personal_data.job_title"},
+		{Header: "Organization 1 - Department", Field: "personal_data.department"},
+		{Header: "E-mail 1 - Type", Value: "Work"},
+		{Header: "E-mail 1 - Value", Field: "personal_data.email"},
+		{Header: "Phone 1 - Type", Value: "Work"},
+		{Header: "Phone 1 - Value", Field: "personal_data.phone"},
+		{Header: "Phone 2 - Type", Value: "Mobile"},
+		{Header: "Phone 2 - Value", Field: "personal_data.mobile"},
+		{Header: "Address 1 - Type", Value: "Work"},
+		{Header: "Address 1 - Street", Field: "company_data.address.street"},
+		{Header: "Address 1 - City", Field: "company_data.address.city"},
+		{Header: "Address 1 - Region", Field: "company_data.address.state"},
+		{Header: "Address 1 - Postal Code", Field: "company_data.address.postal_code"},
+		{Header: "Address 1 - Country", Field: "company_data.address.country"},
+		{Header: "Website 1 - Type", Value: "Work"},
+		{Header: "Website 1 - Value", Field: "company_data.website"},
+	},
+}
+
+// normalizeExportOptions defaults to CSV with every field
+func normalizeExportOptions(options ExportOptions) (ExportOptions, error) {
+	options.Format = strings.ToLower(strings.TrimSpace(options.Format))
+	if options.Format == "" {
+		options.Format = ExportFormatCSV
+	}
+	if options.Format != ExportFormatCSV && options.Format != ExportFormatXLSX {
+		return options, fmt.Errorf("%w: format must be csv or xlsx", ErrInvalidExport)
+	}
+
+	options.Preset = strings.ToLower(strings.TrimSpace(options.Preset))
+	if options.Preset == "" {
+		options.Preset = ExportPresetDefault
+	}
+
+	return options, nil
+}
+
+// resolveExportColumns returns the custom columns when given, otherwise the preset's
+func resolveExportColumns(options ExportOptions) ([]exportColumn, error) {
+	if len(options.Columns) > 0 {
+		columns := make([]exportColumn, 0, len(options.Columns))
+		for _, column := range options.Columns {
+			field, header, _ := strings.Cut(column, ":")
+			field = strings.TrimSpace(field)
+			if _, ok := exportFields[field]; !ok {
+				return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidExport, field)
+			}
+			header = strings.TrimSpace(header)
+			if header == "" {
+				header = field
+			}
+			columns = append(columns, exportColumn{Header: header, Field: field})
+		}
+		return columns, nil
+	}
+
+	if options.Preset == ExportPresetDefault {
+		columns := make([]exportColumn, len(exportFieldOrder))
+		for i, field := range exportFieldOrder {
+			columns[i] = exportColumn{Header: field, Field: field}
+		}
+		return columns, nil
+	}
+
+	columns, ok := exportPresets[options.Preset]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown preset %q", ErrInvalidExport, options.Preset)
+	}
+	return columns, nil
+}
+
+// exportRow renders a business card as the values of the given columns
+func exportRow(businessCard *models.BusinessCard, columns []exportColumn) []string {
+	row := make([]string, len(columns))
+	for i, column := range columns {
+		if column.Field == "" {
+			row[i] = column.Value
+			continue
+		}
+		row[i] = exportFields[column.Field](businessCard)
+	}
+	return row
+}
+
+// rowWriter is a streaming spreadsheet writer
+type rowWriter interface {
+	WriteRow(values []string) error
+	Close() error
+}
+
+func newRowWriter(w io.Writer, format string) rowWriter {
+	if format == ExportFormatXLSX {
+		return newXLSXWriter(w, "Business Cards")
+	}
+	return &csvRowWriter{writer: csv.NewWriter(w)}
+}
+
+type csvRowWriter struct {
+	writer *csv.Writer
+}
+
+func (c *csvRowWriter) WriteRow(values []string) error {
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		escaped[i] = escapeCSVFormula(value)
+	}
+	return c.writer.Write(escaped)
+}
+
+func (c *csvRowWriter) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// escapeCSVFormula keeps spreadsheet applications from evaluating extracted text as a formula.
+// Phone numbers legitimately start with "+" and are left alone.
+func escapeCSVFormula(value string) string {
+	if value == "" {
+		return value
+	}
+
+	switch value[0] {
+	case '=', '@', '\t', '\r':
+		return "'" + value
+	case '+', '-':
+		if strings.Trim(value, "+-0123456789 ()./") != "" {
+			return "'" + value
+		}
+	}
+	return value
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"business-card-reader/internal/models"
+)
+
+func TestEscapeCSVFormula(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"Acme Corp", "Acme Corp"},
+		{"", ""},
+		{"=HYPERLINK(\"http://evil\")", "'=HYPERLINK(\"http://evil\")"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\tcmd", "'\tcmd"},
+		{"+49 (30) 123-4567", "+49 (30) 123-4567"},
+		{"-42", "-42"},
+		{"+cmd|' /C calc'!A0", "'+cmd|' /C calc'!A0"},
+		{"-2+3+cmd", "'-2+3+cmd"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := escapeCSVFormula(tt.value); got != tt.want {
+				t.Errorf("escapeCSVFormula(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXLSXColumnName(t *testing.T) {
+	for index, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"} {
+		if got := xlsxColumnName(index); got != want {
+			t.Errorf("xlsxColumnName(%d) = %q, want %q", index, got, want)
+		}
+	}
+}
+
+func TestResolveExportColumns(t *testing.T) {
+	tests := []struct {
+		name        string
+		options     ExportOptions
+		wantHeaders []string
+		wantErr     bool
+	}{
+		{
+			name:        "custom columns",
+			options:     ExportOptions{Columns: []string{"personal_data.email: Work Email", "company_data.name"}},
+			wantHeaders: []string{"Work Email", "company_data.name"},
+		},
+		{
+			name:        "custom columns override the preset",
+			options:     ExportOptions{Preset: ExportPresetHubSpot, Columns: []string{"id"}},
+			wantHeaders: []string{"id"},
+		},
+		{
+			name:        "preset",
+			options:     ExportOptions{Preset: "HubSpot"},
+			wantHeaders: []string{"First Name", "Last Name", "Email"},
+		},
+		{
+			name:        "default preset",
+			options:     ExportOptions{},
+			wantHeaders: []string{"id", "status", "created_at"},
+		},
+		{name: "unknown field", options: ExportOptions{Columns: []string{"personal_data.password"}}, wantErr: true},
+		{name: "unknown preset", options: ExportOptions{Preset: "pipedrive"}, wantErr: true},
+		{name: "unknown format", options: ExportOptions{Format: "ods"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options, err := normalizeExportOptions(tt.options)
+			var columns []exportColumn
+			if err == nil {
+				columns, err = resolveExportColumns(options)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidExport) {
+					t.Errorf("got %v, want ErrInvalidExport", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var headers []string
+			for _, column := range columns {
+				headers = append(headers, column.Header)
+			}
+			if len(headers) < len(tt.wantHeaders) || !slices.Equal(headers[:len(tt.wantHeaders)], tt.wantHeaders) {
+				t.Errorf("headers = %v, want them to start with %v", headers, tt.wantHeaders)
+			}
+		})
+	}
+}
+
+// newExportService stores two completed cards, the second with a value a spreadsheet would
+// evaluate as a formula
+func newExportService(t *testing.T) *BusinessCardService {
+	t.Helper()
+	repository := NewMemoryRepository()
+	created := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	for i, name := range []string{"Acme Corp", "=Globex & Co"} {
+		businessCard := models.BusinessCard{ID: name, Status: models.StatusCompleted, CreatedAt: created.Add(time.Duration(i) * time.Minute)}
+		businessCard.PersonalData.FirstName = "Jane"
+		businessCard.PersonalData.LastName = "Doe"
+		businessCard.PersonalData.Phone = "+49 30 1234567"
+		businessCard.CompanyData.Name = name
+		if err := repository.SaveBusinessCard(context.Background(), &businessCard); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return NewBusinessCardService(repository, &stubExtractor{})
+}
+
+func TestExportBusinessCardsCSV(t *testing.T) {
+	service := newExportService(t)
+
+	var buffer bytes.Buffer
+	count, err := service.ExportBusinessCards(context.Background(), &buffer, models.BusinessCardQuery{SortOrder: models.SortOrderAsc}, ExportOptions{Preset: ExportPresetSalesforce})
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&buffer).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 || len(records) != 3 {
+		t.Fatalf("exported %d cards in %d records", count, len(records))
+	}
+
+	header := records[0]
+	want := map[string][]string{
+		"Company":     {"Acme Corp", "'=Globex & Co"},
+		"Phone":       {"+49 30 1234567", "+49 30 1234567"},
+		"Lead Source": {"Business Card", "Business Card"},
+	}
+	for column, values := range want {
+		index := slices.Index(header, column)
+		if index < 0 {
+			t.Errorf("header %v lacks %q", header, column)
+			continue
+		}
+		for i, value := range values {
+			if got := records[i+1][index]; got != value {
+				t.Errorf("row %d %s = %q, want %q", i+1, column, got, value)
+			}
+		}
+	}
+
+	t.Run("no matches writes the header", func(t *testing.T) {
+		var buffer bytes.Buffer
+		count, err := service.ExportBusinessCards(context.Background(), &buffer, models.BusinessCardQuery{Status: models.StatusFailed}, ExportOptions{Columns: []string{"id", "company_data.name:Company"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 0 || buffer.String() != "id,Company\n" {
+			t.Errorf("exported %d cards: %q", count, buffer.String())
+		}
+	})
+}
+
+func TestExportBusinessCardsXLSX(t *testing.T) {
+	service := newExportService(t)
+
+	var buffer bytes.Buffer
+	if _, err := service.ExportBusinessCards(context.Background(), &buffer, models.BusinessCardQuery{SortOrder: models.SortOrderAsc}, ExportOptions{Format: ExportFormatXLSX, Columns: []string{"company_data.name:Company", "personal_data.phone"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatalf("export isn't a zip archive: %v", err)
+	}
+	parts := make(map[string]string)
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts[file.Name] = string(content)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+		content, ok := parts[name]
+		if !ok {
+			t.Errorf("workbook lacks %s", name)
+			continue
+		}
+		decoder := xml.NewDecoder(strings.NewReader(content))
+		for {
+			if _, err := decoder.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Errorf("%s isn't well-formed XML: %v", name, err)
+				break
+			}
+		}
+	}
+
+	// Cells hold the values as typed, a formula needs a <f> element to be evaluated
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{
+		`<c r="A1" t="inlineStr" s="1"><is><t xml:space="preserve">Company</t></is></c>`,
+		`<c r="A3" t="inlineStr"><is><t xml:space="preserve">=Globex &amp; Co</t></is></c>`,
+		`<c r="B2" t="inlineStr"><is><t xml:space="preserve">+49 30 1234567</t></is></c>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("worksheet lacks %s", want)
+		}
+	}
+}
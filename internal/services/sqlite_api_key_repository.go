@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"business-card-reader/internal/models"
+)
+
+func (s *SQLiteRepository) SaveAPIKey(ctx context.Context, apiKey *models.APIKey) error {
+	stored := *apiKey
+	stored.Key = ""
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return fmt.Errorf("failed to marshal API key: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO api_keys (id, created_at, data)
+		VALUES (?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			data = excluded.data`,
+		apiKey.ID,
+		sortableTime(apiKey.CreatedAt),
+		string(data),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save API key: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SQLiteRepository) GetAPIKey(ctx context.Context, id string) (*models.APIKey, error) {
+	var data string
+	err := s.db.QueryRowContext(ctx, `SELECT data FROM api_keys WHERE id = ?`, id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	var apiKey models.APIKey
+	if err := json.Unmarshal([]byte(data), &apiKey); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal API key: %w", err)
+	}
+
+	return &apiKey, nil
+}
+
+func (s *SQLiteRepository) GetAllAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT data FROM api_keys ORDER BY created_at`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query API keys: %w", err)
+	}
+	defer rows.Close()
+
+	var apiKeys []models.APIKey
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to read API key row: %w", err)
+		}
+
+		var apiKey models.APIKey
+		if err := json.Unmarshal([]byte(data), &apiKey); err != nil {
+			continue // Skip rows that can't be unmarshaled
+		}
+		apiKeys = append(apiKeys, apiKey)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate API keys: %w", err)
+	}
+
+	return apiKeys, nil
+}
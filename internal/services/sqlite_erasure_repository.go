@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"business-card-reader/internal/models"
+)
+
+func (s *SQLiteRepository) SaveErasureReceipt(ctx context.Context, receipt *models.ErasureReceipt) error {
+	stored := *receipt
+	stored.Email = ""
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return fmt.Errorf("failed to marshal erasure receipt: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO erasure_receipts (id, created_at, data)
+		VALUES (?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			data = excluded.data`,
+		receipt.ID,
+		sortableTime(receipt.RequestedAt),
+		string(data),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save erasure receipt: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SQLiteRepository) GetErasureReceipt(ctx context.Context, id string) (*models.ErasureReceipt, error) {
+	var data string
+	err := s.db.QueryRowContext(ctx, `SELECT data FROM erasure_receipts WHERE id = ?`, id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrErasureReceiptNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get erasure receipt: %w", err)
+	}
+
+	var receipt models.ErasureReceipt
+	if err := json.Unmarshal([]byte(data), &receipt); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal erasure receipt: %w", err)
+	}
+
+	return &receipt, nil
+}
+
+func (s *SQLiteRepository) GetAllErasureReceipts(ctx context.Context) ([]models.ErasureReceipt, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT data FROM erasure_receipts ORDER BY created_at`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query erasure receipts: %w", err)
+	}
+	defer rows.Close()
+
+	var receipts []models.ErasureReceipt
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to read erasure receipt row: %w", err)
+		}
+
+		var receipt models.ErasureReceipt
+		if err := json.Unmarshal([]byte(data), &receipt); err != nil {
+			continue // Skip rows that can't be unmarshaled
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate erasure receipts: %w", err)
+	}
+
+	return receipts, nil
+}
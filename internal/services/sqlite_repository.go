@@ -0,0 +1,406 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"business-card-reader/internal/logger"
+	"business-card-reader/internal/models"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// SQLiteRepository stores business cards in an embedded SQLite database file.
+// The full card is kept as a JSON document, with the columns needed for lookups
+// duplicated alongside it.
+type SQLiteRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteRepository(path string) (*SQLiteRepository, error) {
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?_busy_timeout=5000&_journal_mode=WAL", path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SQLite database: %w", err)
+	}
+
+	// SQLite allows a single writer, serialize access instead of failing with SQLITE_BUSY
+	db.SetMaxOpenConns(1)
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to SQLite database: %w", err)
+	}
+
+	logger.LogInfo("NewSQLiteRepository", "SQLite repository initialized", map[string]interface{}{
+		"path": path,
+	})
+
+	return &SQLiteRepository{db: db}, nil
+}
+
+func (s *SQLiteRepository) SaveBusinessCard(ctx context.Context, businessCard *models.BusinessCard) error {
+	data, err := json.Marshal(businessCard)
+	if err != nil {
+		return fmt.Errorf("failed to marshal business card: %w", err)
+	}
+
+	// IDs are unique across tenants, a card is never overwritten by another tenant's
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO business_cards (id, tenant_id, status, created_at, processed_at, company_name, email_key, data)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			status = excluded.status,
+			created_at = excluded.created_at,
+			processed_at = excluded.processed_at,
+			company_name = excluded.company_name,
+			email_key = excluded.email_key,
+			data = excluded.data
+		WHERE business_cards.tenant_id = excluded.tenant_id`,
+		businessCard.ID,
+		recordTenant(businessCard.TenantID),
+		businessCard.Status,
+		sortableTime(businessCard.CreatedAt),
+		sortableTime(businessCard.ProcessedAt),
+		normalizeCompanyName(businessCard.CompanyData.Name),
+		emailKey(businessCard.PersonalData.Email),
+		string(data),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save business card: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return fmt.Errorf("failed to save business card: ID %s is used by another tenant", businessCard.ID)
+	}
+
+	return nil
+}
+
+func (s *SQLiteRepository) SaveBusinessCardIf(ctx context.Context, businessCard *models.BusinessCard, condition BusinessCardCondition) error {
+	data, err := json.Marshal(businessCard)
+	if err != nil {
+		return fmt.Errorf("failed to marshal business card: %w", err)
+	}
+
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE business_cards SET
+			status = ?,
+			created_at = ?,
+			processed_at = ?,
+			company_name = ?,
+			email_key = ?,
+			data = ?
+		WHERE id = ? AND tenant_id = ? AND status = ?
+			AND (? = '' OR json_extract(data, '$.review.reviewer') = ?)`,
+		businessCard.Status,
+		sortableTime(businessCard.CreatedAt),
+		sortableTime(businessCard.ProcessedAt),
+		normalizeCompanyName(businessCard.CompanyData.Name),
+		emailKey(businessCard.PersonalData.Email),
+		string(data),
+		businessCard.ID,
+		recordTenant(businessCard.TenantID),
+		condition.Status,
+		condition.Reviewer,
+		condition.Reviewer,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save business card: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to save business card: %w", err)
+	}
+	if affected == 0 {
+		return ErrBusinessCardChanged
+	}
+
+	return nil
+}
+
+func (s *SQLiteRepository) GetBusinessCard(ctx context.Context, id string) (*models.BusinessCard, error) {
+	var data string
+	err := s.db.QueryRowContext(ctx, `SELECT data FROM business_cards WHERE id = ? AND tenant_id = ?`, id, TenantFromContext(ctx)).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrBusinessCardNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get business card: %w", err)
+	}
+
+	var businessCard models.BusinessCard
+	if err := json.Unmarshal([]byte(data), &businessCard); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal business card: %w", err)
+	}
+
+	return &businessCard, nil
+}
+
+func (s *SQLiteRepository) GetAllBusinessCards(ctx context.Context) ([]models.BusinessCard, error) {
+	statement, args := "SELECT data FROM business_cards", []interface{}{}
+	if tenantID, scoped := listingTenant(ctx); scoped {
+		statement, args = statement+" WHERE tenant_id = ?", append(args, tenantID)
+	}
+
+	rows, err := s.db.QueryContext(ctx, statement+" ORDER BY created_at", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query business cards: %w", err)
+	}
+
+	return scanBusinessCards(rows)
+}
+
+func (s *SQLiteRepository) GetBusinessCardsByStatus(ctx context.Context, status string) ([]models.BusinessCard, error) {
+	statement, args := "SELECT data FROM business_cards WHERE status = ?", []interface{}{status}
+	if tenantID, scoped := listingTenant(ctx); scoped {
+		statement, args = statement+" AND tenant_id = ?", append(args, tenantID)
+	}
+
+	rows, err := s.db.QueryContext(ctx, statement+" ORDER BY created_at", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query business cards by status: %w", err)
+	}
+
+	return scanBusinessCards(rows)
+}
+
+func (s *SQLiteRepository) DeleteBusinessCard(ctx context.Context, id string) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM business_cards WHERE id = ? AND tenant_id = ?`, id, TenantFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to delete business card: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return ErrBusinessCardNotFound
+	}
+
+	return nil
+}
+
+func (s *SQLiteRepository) ListBusinessCards(ctx context.Context, query models.BusinessCardQuery) (*models.BusinessCardPage, error) {
+	cursor, err := decodeCursor(query.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only whitelisted column names are interpolated into the statement
+	sortColumn := "created_at"
+	if query.SortBy == models.SortByProcessedAt {
+		sortColumn = "processed_at"
+	}
+	direction, comparison := "ASC", ">"
+	if query.SortOrder == models.SortOrderDesc {
+		direction, comparison = "DESC", "<"
+	}
+
+	// Pages are never listed across tenants
+	conditions := []string{"tenant_id = ?"}
+	args := []interface{}{TenantFromContext(ctx)}
+	if query.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, query.Status)
+	}
+	if query.CompanyName != "" {
+		conditions = append(conditions, "company_name = ?")
+		args = append(args, normalizeCompanyName(query.CompanyName))
+	}
+	if query.Email != "" {
+		conditions = append(conditions, "email_key = ?")
+		args = append(args, query.Email)
+	}
+	if query.CreatedAfter != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, sortableTime(*query.CreatedAfter))
+	}
+	if query.CreatedBefore != nil {
+		conditions = append(conditions, "created_at <= ?")
+		args = append(args, sortableTime(*query.CreatedBefore))
+	}
+	if cursor != nil {
+		conditions = append(conditions, fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))", sortColumn, comparison))
+		args = append(args, cursor.SortValue, cursor.SortValue, cursor.ID)
+	}
+
+	statement := "SELECT data FROM business_cards WHERE " + strings.Join(conditions, " AND ")
+	// Fetch one extra row to find out whether there is a next page
+	statement += fmt.Sprintf(" ORDER BY %[1]s %[2]s, id %[2]s LIMIT ?", sortColumn, direction)
+	args = append(args, query.Limit+1)
+
+	rows, err := s.db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query business cards: %w", err)
+	}
+
+	businessCards, err := scanBusinessCards(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.BusinessCardPage{BusinessCards: businessCards}
+	if len(businessCards) > query.Limit {
+		page.BusinessCards = businessCards[:query.Limit]
+		last := &page.BusinessCards[query.Limit-1]
+		page.NextCursor = encodeCursor(pageCursor{SortValue: businessCardSortValue(last, query.SortBy), ID: last.ID})
+	}
+
+	return page, nil
+}
+
+func (s *SQLiteRepository) CreateTableIfNotExists(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS business_cards (
+		id TEXT PRIMARY KEY,
+		tenant_id TEXT NOT NULL DEFAULT 'default',
+		status TEXT NOT NULL,
+		created_at TEXT NOT NULL,
+		processed_at TEXT NOT NULL DEFAULT '',
+		company_name TEXT NOT NULL DEFAULT '',
+		email_key TEXT NOT NULL DEFAULT '',
+		data TEXT NOT NULL
+	)`)
+	if err != nil {
+		return fmt.Errorf("failed to create table: %w", err)
+	}
+
+	if err := s.migrateColumns(ctx); err != nil {
+		return err
+	}
+
+	statements := []string{
+		// Indexes from before tenants existed, replaced by the tenant ones below
+		`DROP INDEX IF EXISTS idx_business_cards_status`,
+		`DROP INDEX IF EXISTS idx_business_cards_created_at`,
+		`DROP INDEX IF EXISTS idx_business_cards_processed_at`,
+		`DROP INDEX IF EXISTS idx_business_cards_company_name`,
+		`CREATE INDEX IF NOT EXISTS idx_business_cards_tenant_status ON business_cards (tenant_id, status, created_at)`,
+		`CREATE INDEX IF NOT EXISTS idx_business_cards_tenant_created_at ON business_cards (tenant_id, created_at, id)`,
+		`CREATE INDEX IF NOT EXISTS idx_business_cards_tenant_processed_at ON business_cards (tenant_id, processed_at, id)`,
+		`CREATE INDEX IF NOT EXISTS idx_business_cards_tenant_company_name ON business_cards (tenant_id, company_name, created_at)`,
+		`CREATE INDEX IF NOT EXISTS idx_business_cards_tenant_email_key ON business_cards (tenant_id, email_key, created_at)`,
+		`CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+			id TEXT PRIMARY KEY,
+			created_at TEXT NOT NULL,
+			data TEXT NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS webhook_deliveries (
+			id TEXT PRIMARY KEY,
+			subscription_id TEXT NOT NULL,
+			status TEXT NOT NULL,
+			next_attempt_at TEXT NOT NULL,
+			created_at TEXT NOT NULL,
+			data TEXT NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription ON webhook_deliveries (subscription_id, created_at)`,
+		`CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries (status, next_attempt_at)`,
+		`CREATE TABLE IF NOT EXISTS api_keys (
+			id TEXT PRIMARY KEY,
+			created_at TEXT NOT NULL,
+			data TEXT NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS erasure_receipts (
+			id TEXT PRIMARY KEY,
+			created_at TEXT NOT NULL,
+			data TEXT NOT NULL
+		)`,
+	}
+
+	for _, statement := range statements {
+		if _, err := s.db.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("failed to create table: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// migrateColumns adds the columns introduced after the first release to older databases and fills
+// them from the stored cards: processed_at and company_name for sorting and filtering listings,
+// email_key for duplicate search, and tenant_id, which puts existing cards in the default tenant
+func (s *SQLiteRepository) migrateColumns(ctx context.Context) error {
+	rows, err := s.db.QueryContext(ctx, `SELECT name FROM pragma_table_info('business_cards')`)
+	if err != nil {
+		return fmt.Errorf("failed to inspect business_cards table: %w", err)
+	}
+
+	columns := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to inspect business_cards table: %w", err)
+		}
+		columns[name] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to inspect business_cards table: %w", err)
+	}
+
+	if columns["processed_at"] && columns["company_name"] && columns["email_key"] && columns["tenant_id"] {
+		return nil
+	}
+
+	logger.LogInfo("SQLiteRepository", "Migrating business_cards table columns", map[string]interface{}{})
+
+	for _, column := range []struct{ name, defaultValue string }{
+		{name: "processed_at"},
+		{name: "company_name"},
+		{name: "email_key"},
+		{name: "tenant_id", defaultValue: DefaultTenant},
+	} {
+		if columns[column.name] {
+			continue
+		}
+		if _, err := s.db.ExecContext(ctx, fmt.Sprintf(`ALTER TABLE business_cards ADD COLUMN %s TEXT NOT NULL DEFAULT '%s'`, column.name, column.defaultValue)); err != nil {
+			return fmt.Errorf("failed to add %s column: %w", column.name, err)
+		}
+	}
+
+	businessCards, err := s.GetAllBusinessCards(WithAllTenants(ctx))
+	if err != nil {
+		return err
+	}
+
+	for i := range businessCards {
+		businessCards[i].TenantID = recordTenant(businessCards[i].TenantID)
+		if err := s.SaveBusinessCard(ctx, &businessCards[i]); err != nil {
+			return fmt.Errorf("failed to backfill business card %s: %w", businessCards[i].ID, err)
+		}
+	}
+
+	logger.LogInfo("SQLiteRepository", "Backfilled business_cards columns", map[string]interface{}{
+		"count": len(businessCards),
+	})
+
+	return nil
+}
+
+// Close releases the underlying database handle
+func (s *SQLiteRepository) Close() error {
+	return s.db.Close()
+}
+
+func scanBusinessCards(rows *sql.Rows) ([]models.BusinessCard, error) {
+	defer rows.Close()
+
+	var businessCards []models.BusinessCard
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to read business card row: %w", err)
+		}
+
+		var businessCard models.BusinessCard
+		if err := json.Unmarshal([]byte(data), &businessCard); err != nil {
+			continue // Skip rows that can't be unmarshaled
+		}
+		businessCards = append(businessCards, businessCard)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate business cards: %w", err)
+	}
+
+	return businessCards, nil
+}
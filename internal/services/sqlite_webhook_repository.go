@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"business-card-reader/internal/models"
+)
+
+func (s *SQLiteRepository) SaveWebhookSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	data, err := json.Marshal(subscription)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook subscription: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO webhook_subscriptions (id, created_at, data)
+		VALUES (?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			created_at = excluded.created_at,
+			data = excluded.data`,
+		subscription.ID,
+		sortableTime(subscription.CreatedAt),
+		string(data),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SQLiteRepository) GetWebhookSubscription(ctx context.Context, id string) (*models.WebhookSubscription, error) {
+	var data string
+	err := s.db.QueryRowContext(ctx, `SELECT data FROM webhook_subscriptions WHERE id = ?`, id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrWebhookSubscriptionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+
+	var subscription models.WebhookSubscription
+	if err := json.Unmarshal([]byte(data), &subscription); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal webhook subscription: %w", err)
+	}
+
+	return &subscription, nil
+}
+
+func (s *SQLiteRepository) GetAllWebhookSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT data FROM webhook_subscriptions ORDER BY created_at`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subscriptions []models.WebhookSubscription
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to read webhook subscription row: %w", err)
+		}
+
+		var subscription models.WebhookSubscription
+		if err := json.Unmarshal([]byte(data), &subscription); err != nil {
+			continue // Skip rows that can't be unmarshaled
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate webhook subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+func (s *SQLiteRepository) DeleteWebhookSubscription(ctx context.Context, id string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM webhook_subscriptions WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return ErrWebhookSubscriptionNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM webhook_deliveries WHERE subscription_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete webhook deliveries: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit webhook subscription deletion: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SQLiteRepository) SaveWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	data, err := json.Marshal(delivery)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook delivery: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO webhook_deliveries (id, subscription_id, status, next_attempt_at, created_at, data)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			status = excluded.status,
+			next_attempt_at = excluded.next_attempt_at,
+			data = excluded.data`,
+		delivery.ID,
+		delivery.SubscriptionID,
+		delivery.Status,
+		sortableTime(delivery.NextAttemptAt),
+		sortableTime(delivery.CreatedAt),
+		string(data),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SQLiteRepository) GetWebhookDeliveriesBySubscription(ctx context.Context, subscriptionID string) ([]models.WebhookDelivery, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT data FROM webhook_deliveries WHERE subscription_id = ? ORDER BY created_at`, subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook deliveries: %w", err)
+	}
+
+	return scanWebhookDeliveries(rows)
+}
+
+func (s *SQLiteRepository) GetDueWebhookDeliveries(ctx context.Context, now time.Time) ([]models.WebhookDelivery, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT data FROM webhook_deliveries
+		WHERE status = ? AND next_attempt_at <= ?
+		ORDER BY next_attempt_at`,
+		models.DeliveryStatusPending,
+		sortableTime(now),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query due webhook deliveries: %w", err)
+	}
+
+	return scanWebhookDeliveries(rows)
+}
+
+func scanWebhookDeliveries(rows *sql.Rows) ([]models.WebhookDelivery, error) {
+	defer rows.Close()
+
+	var deliveries []models.WebhookDelivery
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to read webhook delivery row: %w", err)
+		}
+
+		var delivery models.WebhookDelivery
+		if err := json.Unmarshal([]byte(data), &delivery); err != nil {
+			continue // Skip rows that can't be unmarshaled
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
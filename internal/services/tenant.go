@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// DefaultTenant owns the records of deployments without authentication, of API keys created
+// without a tenant and of records stored before tenants existed
+const DefaultTenant = "default"
+
+// tenantKeySeparator joins the tenant ID and the record ID in storage keys
+const tenantKeySeparator = "#"
+
+// ErrInvalidTenant is returned when a tenant ID fails validation
+var ErrInvalidTenant = errors.New("invalid tenant")
+
+// tenantIDPattern keeps tenant IDs usable as storage key prefixes
+var tenantIDPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,64}$`)
+
+type tenantContextKey struct{}
+
+type allTenantsContextKey struct{}
+
+// WithTenant scopes the business card, webhook and API key calls made with the returned context
+// to the tenant
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantID)
+}
+
+// TenantFromContext returns the tenant the context is scoped to, DefaultTenant when none was set
+func TenantFromContext(ctx context.Context) string {
+	if tenantID, ok := ctx.Value(tenantContextKey{}).(string); ok && tenantID != "" {
+		return tenantID
+	}
+	return DefaultTenant
+}
+
+// WithAllTenants lets background jobs such as the retry scheduler list business cards of every
+// tenant with GetAllBusinessCards and GetBusinessCardsByStatus. Lookups by ID still need the
+// card's tenant set with WithTenant.
+func WithAllTenants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allTenantsContextKey{}, true)
+}
+
+// listingTenant returns the tenant listing calls are restricted to, or false for a context
+// created by WithAllTenants
+func listingTenant(ctx context.Context) (string, bool) {
+	if all, _ := ctx.Value(allTenantsContextKey{}).(bool); all {
+		return "", false
+	}
+	return TenantFromContext(ctx), true
+}
+
+// ValidateTenantID checks that a tenant ID only uses letters, digits, dots, dashes and underscores
+func ValidateTenantID(tenantID string) error {
+	if !tenantIDPattern.MatchString(tenantID) {
+		return fmt.Errorf("%w: %q must be 1 to 64 letters, digits, dots, dashes or underscores", ErrInvalidTenant, tenantID)
+	}
+	return nil
+}
+
+// recordTenant returns the tenant of a stored record, which is DefaultTenant for records
+// stored before tenants existed
+func recordTenant(tenantID string) string {
+	if tenantID == "" {
+		return DefaultTenant
+	}
+	return tenantID
+}
+
+// tenantKey prefixes a record ID with its tenant
+func tenantKey(tenantID string, id string) string {
+	return tenantID + tenantKeySeparator + id
+}
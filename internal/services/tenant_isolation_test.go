@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"business-card-reader/internal/models"
+)
+
+// repositoryBackends creates an empty repository of every storage backend, DynamoDB running on
+// an in-memory client
+var repositoryBackends = map[string]func(t *testing.T) Repository{
+	"memory": func(t *testing.T) Repository {
+		return NewMemoryRepository()
+	},
+	"sqlite": func(t *testing.T) Repository {
+		repository, err := NewSQLiteRepository(filepath.Join(t.TempDir(), "cards.db"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { repository.Close() })
+		return repository
+	},
+	"dynamodb": func(t *testing.T) Repository {
+		repository, _ := newTestDynamoService(t)
+		return repository
+	},
+}
+
+func TestTenantIsolation(t *testing.T) {
+	acme := WithTenant(context.Background(), "acme")
+	globex := WithTenant(context.Background(), "globex")
+
+	for backend, newRepository := range repositoryBackends {
+		t.Run(backend, func(t *testing.T) {
+			repository := newRepository(t)
+			if err := repository.CreateTableIfNotExists(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			t.Run("business cards", func(t *testing.T) {
+				businessCard := models.BusinessCard{ID: "card-1", TenantID: "acme", Status: models.StatusCompleted, CreatedAt: time.Now()}
+				businessCard.PersonalData.FullName = "Jane Doe"
+				businessCard.PersonalData.Email = "jane@acme.com"
+				businessCard.CompanyData.Name = "Acme"
+				if err := repository.SaveBusinessCard(acme, &businessCard); err != nil {
+					t.Fatal(err)
+				}
+				service := NewBusinessCardService(repository, &stubExtractor{})
+
+				if _, err := repository.GetBusinessCard(globex, "card-1"); !errors.Is(err, ErrBusinessCardNotFound) {
+					t.Errorf("get from another tenant: got %v, want ErrBusinessCardNotFound", err)
+				}
+				if businessCards, err := repository.GetAllBusinessCards(globex); err != nil || len(businessCards) != 0 {
+					t.Errorf("another tenant sees %d business cards (%v)", len(businessCards), err)
+				}
+				if businessCards, err := repository.GetBusinessCardsByStatus(globex, models.StatusCompleted); err != nil || len(businessCards) != 0 {
+					t.Errorf("another tenant sees %d business cards by status (%v)", len(businessCards), err)
+				}
+
+				queries := map[string]models.BusinessCardQuery{
+					"all":        {},
+					"by status":  {Status: models.StatusCompleted},
+					"by company": {CompanyName: "Acme"},
+					"by email":   {Email: "jane@acme.com"},
+				}
+				for name, query := range queries {
+					page, err := service.ListBusinessCards(globex, query)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if len(page.BusinessCards) != 0 {
+						t.Errorf("another tenant lists %d business cards %s", len(page.BusinessCards), name)
+					}
+					if page, err := service.ListBusinessCards(acme, query); err != nil || len(page.BusinessCards) != 1 {
+						t.Errorf("owner can't list its business card %s (%v)", name, err)
+					}
+				}
+
+				fullName := "John Roe"
+				update := models.BusinessCardUpdateRequest{PersonalData: models.PersonalDataUpdate{FullName: &fullName}}
+				if _, err := service.UpdateBusinessCard(globex, "card-1", update, "intruder"); !errors.Is(err, ErrBusinessCardNotFound) {
+					t.Errorf("update from another tenant: got %v, want ErrBusinessCardNotFound", err)
+				}
+				if _, err := service.DeleteBusinessCard(globex, "card-1"); !errors.Is(err, ErrBusinessCardNotFound) {
+					t.Errorf("delete from another tenant: got %v, want ErrBusinessCardNotFound", err)
+				}
+				if err := repository.DeleteBusinessCard(globex, "card-1"); !errors.Is(err, ErrBusinessCardNotFound) {
+					t.Errorf("repository delete from another tenant: got %v, want ErrBusinessCardNotFound", err)
+				}
+
+				stored, err := repository.GetBusinessCard(acme, "card-1")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if stored.PersonalData.FullName != "Jane Doe" {
+					t.Errorf("another tenant changed the business card to %q", stored.PersonalData.FullName)
+				}
+			})
+
+			t.Run("webhook subscriptions", func(t *testing.T) {
+				webhookService := NewWebhookService(repository, webhookTestConfig())
+				subscription, err := webhookService.CreateSubscription(acme, models.WebhookSubscriptionRequest{
+					URL:    "https://hooks.example.com/cards",
+					Events: []string{models.WebhookEventCompleted},
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if _, err := webhookService.GetSubscription(globex, subscription.ID); !errors.Is(err, ErrWebhookSubscriptionNotFound) {
+					t.Errorf("get from another tenant: got %v, want ErrWebhookSubscriptionNotFound", err)
+				}
+				if subscriptions, err := webhookService.GetAllSubscriptions(globex); err != nil || len(subscriptions) != 0 {
+					t.Errorf("another tenant sees %d subscriptions (%v)", len(subscriptions), err)
+				}
+				if _, err := webhookService.GetDeliveries(globex, subscription.ID); !errors.Is(err, ErrWebhookSubscriptionNotFound) {
+					t.Errorf("deliveries from another tenant: got %v, want ErrWebhookSubscriptionNotFound", err)
+				}
+				if err := webhookService.DeleteSubscription(globex, subscription.ID); !errors.Is(err, ErrWebhookSubscriptionNotFound) {
+					t.Errorf("delete from another tenant: got %v, want ErrWebhookSubscriptionNotFound", err)
+				}
+
+				if _, err := webhookService.GetSubscription(acme, subscription.ID); err != nil {
+					t.Errorf("owner lost its subscription: %v", err)
+				}
+			})
+
+			t.Run("API keys", func(t *testing.T) {
+				apiKeyService := NewAPIKeyService(repository)
+				apiKey, err := apiKeyService.CreateAPIKey(acme, models.APIKeyRequest{Name: "ci", Scopes: []string{models.ScopeRead}}, "admin")
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if apiKeys, err := apiKeyService.GetAPIKeys(globex); err != nil || len(apiKeys) != 0 {
+					t.Errorf("another tenant sees %d API keys (%v)", len(apiKeys), err)
+				}
+				if _, err := apiKeyService.RevokeAPIKey(globex, apiKey.ID, "intruder"); !errors.Is(err, ErrAPIKeyNotFound) {
+					t.Errorf("revoke from another tenant: got %v, want ErrAPIKeyNotFound", err)
+				}
+
+				if _, err := apiKeyService.Authenticate(context.Background(), apiKey.Key); err != nil {
+					t.Errorf("key of the owner stopped working: %v", err)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"business-card-reader/internal/config"
+	"business-card-reader/internal/logger"
+	"business-card-reader/internal/models"
+)
+
+// ExtractorTesseract is the registry name of the offline OCR extraction provider
+const ExtractorTesseract = "tesseract"
+
+func init() {
+	RegisterExtractor(ExtractorTesseract, func(cfg *config.Config) (Extractor, error) {
+		return NewTesseractExtractor(cfg.OCR.TesseractPath, cfg.OCR.Language)
+	})
+}
+
+// TesseractExtractor runs the tesseract CLI locally and fills the structured fields with
+// rule-based parsing. It is less accurate than the vision models but has no external
+// dependency, which makes it a good fallback when those are unavailable.
+type TesseractExtractor struct {
+	binaryPath string
+	language   string
+}
+
+func NewTesseractExtractor(binaryPath string, language string) (*TesseractExtractor, error) {
+	resolvedPath, err := exec.LookPath(binaryPath)
+	if err != nil {
+		return nil, fmt.Errorf("tesseract binary not found: %w", err)
+	}
+
+	logger.LogInfo("NewTesseractExtractor", "Tesseract extractor initialized", map[string]interface{}{
+		"binary_path": resolvedPath,
+		"language":    language,
+	})
+
+	return &TesseractExtractor{
+		binaryPath: resolvedPath,
+		language:   language,
+	}, nil
+}
+
+func (t *TesseractExtractor) Name() string {
+	return ExtractorTesseract
+}
+
+func (t *TesseractExtractor) ExtractBusinessCardData(ctx context.Context, images []models.ImageData) (*models.BusinessCard, error) {
+	logger.LogInfo("ExtractBusinessCardData", "Starting OCR processing", map[string]interface{}{
+		"image_count": len(images),
+		"language":    t.language,
+	})
+
+	var texts []string
+	for i, img := range images {
+		text, err := t.recognize(ctx, img)
+		if err != nil {
+			logger.LogError("ExtractBusinessCardData", err, map[string]interface{}{
+				"step":        "ocr",
+				"image_index": i,
+				"filename":    img.FileName,
+			})
+			return nil, fmt.Errorf("failed to run OCR on image %d: %w", i, err)
+		}
+		texts = append(texts, text)
+	}
+
+	extractedText := strings.TrimSpace(strings.Join(texts, "\n\n"))
+	if extractedText == "" {
+		return nil, fmt.Errorf("no text recognized")
+	}
+
+	personalData, companyData := parseBusinessCardText(extractedText)
+
+	logger.LogInfo("ExtractBusinessCardData", "Business card data extracted with OCR", map[string]interface{}{
+		"text_length": len(extractedText),
+		"has_email":   personalData.Email != "",
+		"has_phone":   personalData.Phone != "",
+	})
+
+	return &models.BusinessCard{
+		PersonalData:  personalData,
+		CompanyData:   companyData,
+		ExtractedText: extractedText,
+		Images:        images,
+	}, nil
+}
+
+// recognize pipes the image through "tesseract stdin stdout" and returns the plain text
+func (t *TesseractExtractor) recognize(ctx context.Context, img models.ImageData) (string, error) {
+	args := []string{"stdin", "stdout"}
+	if t.language != "" {
+		args = append(args, "-l", t.language)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, t.binaryPath, args...)
+	cmd.Stdin = bytes.NewReader(img.Data)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
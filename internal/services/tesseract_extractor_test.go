@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"business-card-reader/internal/models"
+)
+
+func TestParseBusinessCardText(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		wantPersonal models.PersonalData
+		wantCompany  models.CompanyData
+	}{
+		{
+			name: "labelled phones and generic mailbox",
+			text: "Jane Doe\nSales Director\nTel: +49 30 1234567\nMobile: +49 171 7654321\nFax: +49 30 1234568\nJane.Doe@Acme.com\ninfo@acme.com\nwww.acme.com",
+			wantPersonal: models.PersonalData{
+				Email:  "jane.doe@acme.com",
+				Phone:  "+49 30 1234567",
+				Mobile: "+49 171 7654321",
+			},
+			wantCompany: models.CompanyData{Email: "info@acme.com", Website: "www.acme.com"},
+		},
+		{
+			name: "email domain isn't a website",
+			text: "john@globex.io",
+			wantPersonal: models.PersonalData{
+				Email: "john@globex.io",
+			},
+		},
+		{
+			name: "linkedin profile and company page",
+			text: "https://www.linkedin.com/in/jane-doe/\nlinkedin.com/company/acme",
+			wantPersonal: models.PersonalData{
+				LinkedIn: "linkedin.com/in/jane-doe",
+			},
+			wantCompany: func() models.CompanyData {
+				var company models.CompanyData
+				company.SocialMedia.LinkedIn = "linkedin.com/company/acme"
+				return company
+			}(),
+		},
+		{
+			name: "labelled linkedin handle",
+			text: "LinkedIn: @janedoe",
+			wantPersonal: models.PersonalData{
+				LinkedIn: "linkedin.com/in/janedoe",
+			},
+		},
+		{
+			name:        "postal code line",
+			text:        "Hauptstraße 5\n10115 Berlin",
+			wantCompany: models.CompanyData{Address: models.Address{PostalCode: "10115", Full: "10115 Berlin"}},
+		},
+		{
+			name:        "canadian postal code",
+			text:        "Toronto, ON M5V 2T6",
+			wantCompany: models.CompanyData{Address: models.Address{PostalCode: "M5V 2T6", Full: "Toronto, ON M5V 2T6"}},
+		},
+		{
+			name: "short digit runs aren't phones",
+			text: "Since 1998\nSuite 12-34",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			personal, company := parseBusinessCardText(tt.text)
+			if personal != tt.wantPersonal {
+				t.Errorf("personal data = %+v, want %+v", personal, tt.wantPersonal)
+			}
+			if company != tt.wantCompany {
+				t.Errorf("company data = %+v, want %+v", company, tt.wantCompany)
+			}
+		})
+	}
+}
+
+// fakeTesseract writes a shell script standing in for the tesseract binary
+func fakeTesseract(t *testing.T, script string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tesseract")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestTesseractExtractor(t *testing.T) {
+	images := []models.ImageData{{FileName: "front.png", ContentType: "image/png", Data: []byte("png bytes")}}
+
+	t.Run("parses the recognized text", func(t *testing.T) {
+		// The image arrives on stdin and the language as the last argument
+		binary := fakeTesseract(t, `cat >/dev/null; printf 'Jane Doe\njane@acme.com\nMobile: +49 171 7654321\nlang %s\n' "$4"`)
+		extractor, err := NewTesseractExtractor(binary, "deu")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		businessCard, err := extractor.ExtractBusinessCardData(context.Background(), images)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if businessCard.PersonalData.Email != "jane@acme.com" || businessCard.PersonalData.Mobile != "+49 171 7654321" {
+			t.Errorf("personal data = %+v", businessCard.PersonalData)
+		}
+		if !strings.Contains(businessCard.ExtractedText, "lang deu") {
+			t.Errorf("extracted text = %q, want the language passed on", businessCard.ExtractedText)
+		}
+	})
+
+	t.Run("reports tesseract errors", func(t *testing.T) {
+		binary := fakeTesseract(t, `echo "Error in pixReadStream" >&2; exit 1`)
+		extractor, err := NewTesseractExtractor(binary, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := extractor.ExtractBusinessCardData(context.Background(), images); err == nil || !strings.Contains(err.Error(), "pixReadStream") {
+			t.Errorf("got %v, want tesseract's message", err)
+		}
+	})
+
+	t.Run("refuses empty text", func(t *testing.T) {
+		binary := fakeTesseract(t, `cat >/dev/null; printf '  \n'`)
+		extractor, err := NewTesseractExtractor(binary, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := extractor.ExtractBusinessCardData(context.Background(), images); err == nil {
+			t.Error("got a business card without text")
+		}
+	})
+
+	t.Run("missing binary", func(t *testing.T) {
+		if _, err := NewTesseractExtractor(filepath.Join(t.TempDir(), "missing"), ""); err == nil {
+			t.Error("got an extractor without a binary")
+		}
+	})
+}
+
+func TestFallbackExtractorTakesOver(t *testing.T) {
+	binary := fakeTesseract(t, `cat >/dev/null; printf 'jane@acme.com\n'`)
+	fallback, err := NewTesseractExtractor(binary, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := NewBusinessCardService(NewMemoryRepository(), &stubExtractor{err: errors.New("quota exceeded")})
+	service.SetFallbackExtractor(fallback)
+
+	businessCard, err := service.ProcessBusinessCard(context.Background(), testUpload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if businessCard.Status == models.StatusFailed || businessCard.ExtractionProvider != ExtractorTesseract {
+		t.Errorf("status %s from provider %q, want the card extracted by tesseract", businessCard.Status, businessCard.ExtractionProvider)
+	}
+	if businessCard.PersonalData.Email != "jane@acme.com" {
+		t.Errorf("email = %q", businessCard.PersonalData.Email)
+	}
+
+	t.Run("both fail", func(t *testing.T) {
+		failing := fakeTesseract(t, `exit 1`)
+		fallback, err := NewTesseractExtractor(failing, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		service.SetFallbackExtractor(fallback)
+
+		businessCard, err := service.ProcessBusinessCard(context.Background(), testUpload)
+		if err == nil || businessCard == nil || businessCard.Status != models.StatusFailed {
+			t.Fatalf("got %+v (%v), want a FAILED card", businessCard, err)
+		}
+		if !strings.Contains(businessCard.Error, "quota exceeded") || !strings.Contains(businessCard.Error, "fallback tesseract also failed") {
+			t.Errorf("error = %q, want both failures", businessCard.Error)
+		}
+	})
+}
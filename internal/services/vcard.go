@@ -0,0 +1,316 @@
+package services
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"business-card-reader/internal/models"
+)
+
+// Supported vCard versions
+const (
+	VCardVersion30 = "3.0"
+	VCardVersion40 = "4.0"
+)
+
+// ErrUnsupportedVCardVersion is returned for vCard versions other than 3.0 and 4.0
+var ErrUnsupportedVCardVersion = errors.New("unsupported vCard version")
+
+// vCardLineLength is the maximum length of a content line in octets, excluding the line break
+const vCardLineLength = 75
+
+// VCardOptions controls how business cards are rendered as vCards
+type VCardOptions struct {
+	Version      string
+	IncludePhoto bool
+}
+
+// normalizeVCardOptions defaults to vCard 4.0 (RFC 6350)
+func normalizeVCardOptions(options VCardOptions) (VCardOptions, error) {
+	switch options.Version {
+	case "":
+		options.Version = VCardVersion40
+	case VCardVersion30, VCardVersion40:
+	default:
+		return options, fmt.Errorf("%w: %s", ErrUnsupportedVCardVersion, options.Version)
+	}
+	return options, nil
+}
+
+// vCardWriter writes folded, CRLF-terminated content lines
+type vCardWriter struct {
+	w       io.Writer
+	version string
+	err     error
+}
+
+// WriteVCard renders a business card as one vCard. The photo is the first stored image whose
+// bytes are loaded, when options.IncludePhoto is set.
+func WriteVCard(w io.Writer, businessCard *models.BusinessCard, options VCardOptions) error {
+	options, err := normalizeVCardOptions(options)
+	if err != nil {
+		return err
+	}
+
+	v := &vCardWriter{w: w, version: options.Version}
+	personal := businessCard.PersonalData
+	company := businessCard.CompanyData
+
+	v.line("BEGIN", nil, "VCARD")
+	v.line("VERSION", nil, options.Version)
+
+	// FN is the only property every vCard must have
+	v.line("FN", nil, escapeVCardText(vCardFormattedName(businessCard)))
+	if personal.FirstName != "" || personal.LastName != "" {
+		v.line("N", nil, vCardStructured(personal.LastName, personal.FirstName, "", "", ""))
+	} else if options.Version == VCardVersion30 {
+		// N is required in vCard 3.0
+		v.line("N", nil, vCardStructured(personal.FullName, "", "", "", ""))
+	}
+
+	if personal.Department != "" {
+		v.line("ORG", nil, vCardStructured(company.Name, personal.Department))
+	} else if company.Name != "" {
+		v.line("ORG", nil, escapeVCardText(company.Name))
+	}
+	if personal.JobTitle != "" {
+		v.line("TITLE", nil, escapeVCardText(personal.JobTitle))
+	}
+
+	if personal.Email != "" {
+		v.line("EMAIL", v.types("work"), escapeVCardText(personal.Email))
+	}
+	if company.Email != "" && !strings.EqualFold(company.Email, personal.Email) {
+		v.line("EMAIL", v.types("work"), escapeVCardText(company.Email))
+	}
+
+	if personal.Phone != "" {
+		v.telephone(personal.Phone, "work", "voice")
+	}
+	if personal.Mobile != "" {
+		v.telephone(personal.Mobile, "cell", "voice")
+	}
+	if company.Phone != "" && company.Phone != personal.Phone && company.Phone != personal.Mobile {
+		v.telephone(company.Phone, "work", "voice")
+	}
+
+	v.address(company.Address)
+
+	if personal.Website != "" {
+		v.line("URL", nil, escapeVCardText(personal.Website))
+	}
+	if company.Website != "" && company.Website != personal.Website {
+		v.line("URL", v.types("work"), escapeVCardText(company.Website))
+	}
+
+	// X-SOCIALPROFILE is what most address books understand for social networks
+	socialProfiles := []struct {
+		network string
+		value   string
+	}{
+		{"linkedin", personal.LinkedIn},
+		{"linkedin", company.SocialMedia.LinkedIn},
+		{"twitter", company.SocialMedia.Twitter},
+		{"facebook", company.SocialMedia.Facebook},
+		{"instagram", company.SocialMedia.Instagram},
+	}
+	written := make(map[string]bool)
+	for _, profile := range socialProfiles {
+		if profile.value == "" || written[profile.value] {
+			continue
+		}
+		written[profile.value] = true
+		v.line("X-SOCIALPROFILE", []string{"TYPE=" + profile.network}, escapeVCardText(profile.value))
+	}
+
+	if options.IncludePhoto {
+		v.photo(businessCard.Images)
+	}
+
+	if businessCard.ID != "" {
+		if options.Version == VCardVersion40 {
+			v.line("UID", nil, "urn:uuid:"+businessCard.ID)
+		} else {
+			v.line("UID", nil, businessCard.ID)
+		}
+	}
+	if !businessCard.ProcessedAt.IsZero() {
+		v.line("REV", nil, businessCard.ProcessedAt.UTC().Format("20060102T150405Z"))
+	}
+
+	v.line("END", nil, "VCARD")
+
+	return v.err
+}
+
+// vCardFormattedName picks the best available display name
+func vCardFormattedName(businessCard *models.BusinessCard) string {
+	personal := businessCard.PersonalData
+	switch {
+	case personal.FullName != "":
+		return personal.FullName
+	case personal.FirstName != "" || personal.LastName != "":
+		return strings.TrimSpace(personal.FirstName + " " + personal.LastName)
+	case businessCard.CompanyData.Name != "":
+		return businessCard.CompanyData.Name
+	case personal.Email != "":
+		return personal.Email
+	default:
+		return "Unknown"
+	}
+}
+
+// types renders a TYPE parameter: a quoted list in vCard 4.0, upper-case values in vCard 3.0
+func (v *vCardWriter) types(values ...string) []string {
+	if v.version == VCardVersion40 {
+		if len(values) == 1 {
+			return []string{"TYPE=" + values[0]}
+		}
+		return []string{`TYPE="` + strings.Join(values, ",") + `"`}
+	}
+	return []string{"TYPE=" + strings.ToUpper(strings.Join(values, ","))}
+}
+
+// telephone writes a TEL property. vCard 4.0 values are tel: URIs.
+func (v *vCardWriter) telephone(number string, types ...string) {
+	if v.version == VCardVersion40 {
+		uri := telephoneURI(number)
+		if uri == "" {
+			v.line("TEL", append(v.types(types...), "VALUE=text"), escapeVCardText(number))
+			return
+		}
+		v.line("TEL", append(v.types(types...), "VALUE=uri"), uri)
+		return
+	}
+	v.line("TEL", v.types(types...), escapeVCardText(number))
+}
+
+// telephoneURI converts a phone number to an RFC 3966 tel: URI, or returns "" when the number
+// has an extension or other text that doesn't fit one
+func telephoneURI(number string) string {
+	var digits strings.Builder
+	for i, r := range strings.TrimSpace(number) {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && i == 0:
+			digits.WriteRune(r)
+		case strings.ContainsRune(" -.()/", r):
+			// Visual separators
+		default:
+			return ""
+		}
+	}
+	if digits.Len() == 0 {
+		return ""
+	}
+	return "tel:" + digits.String()
+}
+
+func (v *vCardWriter) address(address models.Address) {
+	if address.Street == "" && address.City == "" && address.State == "" && address.PostalCode == "" && address.Country == "" {
+		if address.Full == "" {
+			return
+		}
+		// Only the unparsed address is known, keep it as the street
+		address.Street = address.Full
+	}
+
+	params := v.types("work")
+	if address.Full != "" && v.version == VCardVersion40 {
+		params = append(params, "LABEL="+quoteVCardParameter(address.Full))
+	}
+	v.line("ADR", params, vCardStructured("", "", address.Street, address.City, address.State, address.PostalCode, address.Country))
+
+	if address.Full != "" && v.version == VCardVersion30 {
+		v.line("LABEL", v.types("work"), escapeVCardText(address.Full))
+	}
+}
+
+func (v *vCardWriter) photo(images []models.ImageData) {
+	for _, image := range images {
+		if len(image.Data) == 0 {
+			continue
+		}
+
+		contentType := strings.ToLower(image.ContentType)
+		if contentType == "image/jpg" {
+			contentType = "image/jpeg"
+		}
+		encoded := base64.StdEncoding.EncodeToString(image.Data)
+
+		if v.version == VCardVersion40 {
+			v.line("PHOTO", nil, "data:"+contentType+";base64,"+encoded)
+		} else {
+			imageType := strings.ToUpper(strings.TrimPrefix(contentType, "image/"))
+			v.line("PHOTO", []string{"ENCODING=b", "TYPE=" + imageType}, encoded)
+		}
+		return
+	}
+}
+
+// line writes one content line, folded after 75 octets without splitting UTF-8 sequences
+func (v *vCardWriter) line(name string, params []string, value string) {
+	if v.err != nil {
+		return
+	}
+
+	content := name
+	for _, param := range params {
+		content += ";" + param
+	}
+	content += ":" + value
+
+	var folded strings.Builder
+	lineLength := 0
+	for _, r := range content {
+		size := utf8.RuneLen(r)
+		if lineLength+size > vCardLineLength {
+			// The continuation line starts with a space that counts towards its length
+			folded.WriteString("\r\n ")
+			lineLength = 1
+		}
+		folded.WriteRune(r)
+		lineLength += size
+	}
+	folded.WriteString("\r\n")
+
+	_, v.err = io.WriteString(v.w, folded.String())
+}
+
+// vCardStructured escapes and joins the components of a structured value such as N or ADR
+func vCardStructured(components ...string) string {
+	escaped := make([]string, len(components))
+	for i, component := range components {
+		escaped[i] = escapeVCardText(component)
+	}
+	return strings.Join(escaped, ";")
+}
+
+// escapeVCardText escapes a text value as described in RFC 6350 section 3.4
+func escapeVCardText(value string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		",", `\,`,
+		";", `\;`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	)
+	return replacer.Replace(value)
+}
+
+// quoteVCardParameter quotes a parameter value using the RFC 6868 caret encoding
+func quoteVCardParameter(value string) string {
+	replacer := strings.NewReplacer(
+		"^", "^^",
+		"\r\n", "^n",
+		"\n", "^n",
+		`"`, "^'",
+	)
+	return `"` + replacer.Replace(value) + `"`
+}
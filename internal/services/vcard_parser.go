@@ -0,0 +1,356 @@
+package services
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"mime/quotedprintable"
+	"slices"
+	"strings"
+
+	"business-card-reader/internal/models"
+)
+
+// vCardProperty is one unfolded content line. Parameter names are upper-cased, TYPE values
+// lower-cased, and the value is still escaped.
+type vCardProperty struct {
+	name   string
+	params map[string][]string
+	value  string
+}
+
+// parsedVCard is one BEGIN:VCARD ... END:VCARD block, or the reason it couldn't be read
+type parsedVCard struct {
+	properties []vCardProperty
+	err        error
+}
+
+// parseVCards reads every vCard of a file. vCard 2.1, 3.0 and 4.0 are accepted, including
+// quoted-printable values written by older phones.
+func parseVCards(r io.Reader) ([]parsedVCard, error) {
+	lines, err := unfoldVCardLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var cards []parsedVCard
+	var current *parsedVCard
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		property, err := parseVCardLine(line)
+		switch {
+		case err != nil:
+			if current != nil && current.err == nil {
+				current.err = err
+			}
+		case property.name == "BEGIN" && strings.EqualFold(property.value, "VCARD"):
+			if current != nil {
+				current.err = fmt.Errorf("missing END:VCARD")
+				cards = append(cards, *current)
+			}
+			current = &parsedVCard{}
+		case property.name == "END" && strings.EqualFold(property.value, "VCARD"):
+			if current != nil {
+				cards = append(cards, *current)
+				current = nil
+			}
+		case current != nil:
+			current.properties = append(current.properties, property)
+		}
+	}
+
+	if current != nil {
+		current.err = fmt.Errorf("missing END:VCARD")
+		cards = append(cards, *current)
+	}
+
+	return cards, nil
+}
+
+// unfoldVCardLines joins folded lines, which continue with a space or tab, and quoted-printable
+// lines ending in a soft line break
+func unfoldVCardLines(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if len(lines) == 0 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+
+		last := len(lines) - 1
+		switch {
+		case last >= 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")):
+			lines[last] += line[1:]
+		case last >= 0 && strings.HasSuffix(lines[last], "=") && isQuotedPrintableLine(lines[last]):
+			lines[last] = strings.TrimSuffix(lines[last], "=") + line
+		default:
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read vCard file: %w", err)
+	}
+
+	return lines, nil
+}
+
+func isQuotedPrintableLine(line string) bool {
+	header, _, _ := strings.Cut(line, ":")
+	return strings.Contains(strings.ToUpper(header), "QUOTED-PRINTABLE")
+}
+
+// parseVCardLine splits a content line into its name, parameters and value
+func parseVCardLine(line string) (vCardProperty, error) {
+	separator := -1
+	quoted := false
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		} else if r == ':' && !quoted {
+			separator = i
+			break
+		}
+	}
+	if separator < 0 {
+		return vCardProperty{}, fmt.Errorf("malformed line %q", truncateText(line, 40))
+	}
+
+	parts := splitOutsideQuotes(line[:separator], ';')
+	name := strings.ToUpper(parts[0])
+	// Drop the group prefix, as in item1.EMAIL
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+
+	property := vCardProperty{
+		name:   name,
+		params: make(map[string][]string),
+		value:  line[separator+1:],
+	}
+	for _, param := range parts[1:] {
+		key, value, found := strings.Cut(param, "=")
+		if !found {
+			// vCard 2.1 writes types without a name, as in TEL;CELL
+			key, value = "TYPE", param
+		}
+		key = strings.ToUpper(key)
+		for _, v := range splitOutsideQuotes(value, ',') {
+			v = strings.Trim(v, `"`)
+			if key == "TYPE" {
+				// vCard 4.0 quotes type lists, as in TYPE="work,voice"
+				property.params[key] = append(property.params[key], strings.Split(strings.ToLower(v), ",")...)
+				continue
+			}
+			property.params[key] = append(property.params[key], v)
+		}
+	}
+
+	// vCard 2.1 shorthand for ENCODING=QUOTED-PRINTABLE
+	if slices.Contains(property.params["TYPE"], "quoted-printable") {
+		property.params["ENCODING"] = append(property.params["ENCODING"], "QUOTED-PRINTABLE")
+	}
+	if slices.ContainsFunc(property.params["ENCODING"], func(encoding string) bool {
+		return strings.EqualFold(encoding, "QUOTED-PRINTABLE")
+	}) {
+		decoded, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(property.value)))
+		if err != nil {
+			return vCardProperty{}, fmt.Errorf("invalid quoted-printable value in %s", name)
+		}
+		property.value = string(decoded)
+	}
+
+	return property, nil
+}
+
+func (p vCardProperty) hasType(types ...string) bool {
+	for _, t := range types {
+		if slices.Contains(p.params["TYPE"], t) {
+			return true
+		}
+	}
+	return false
+}
+
+// text returns the unescaped value
+func (p vCardProperty) text() string {
+	return unescapeVCardText(p.value)
+}
+
+// components returns the unescaped components of a structured value such as N or ADR
+func (p vCardProperty) components(count int) []string {
+	var components []string
+	var current strings.Builder
+	escaped := false
+	for _, r := range p.value {
+		switch {
+		case escaped:
+			current.WriteRune('\\')
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ';':
+			components = append(components, unescapeVCardText(current.String()))
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	components = append(components, unescapeVCardText(current.String()))
+
+	for len(components) < count {
+		components = append(components, "")
+	}
+	return components
+}
+
+func unescapeVCardText(value string) string {
+	var unescaped strings.Builder
+	escaped := false
+	for _, r := range value {
+		switch {
+		case escaped:
+			if r == 'n' || r == 'N' {
+				unescaped.WriteRune('\n')
+			} else {
+				unescaped.WriteRune(r)
+			}
+			escaped = false
+		case r == '\\':
+			escaped = true
+		default:
+			unescaped.WriteRune(r)
+		}
+	}
+	return unescaped.String()
+}
+
+func splitOutsideQuotes(value string, separator rune) []string {
+	var parts []string
+	var current strings.Builder
+	quoted := false
+	for _, r := range value {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			current.WriteRune(r)
+		case r == separator && !quoted:
+			parts = append(parts, current.String())
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	return append(parts, current.String())
+}
+
+// businessCardFromVCard maps the properties of a vCard onto business card data. It is the
+// counterpart of WriteVCard.
+func businessCardFromVCard(properties []vCardProperty) *models.BusinessCard {
+	businessCard := &models.BusinessCard{}
+	personal := &businessCard.PersonalData
+	company := &businessCard.CompanyData
+
+	addressRead, workAddressRead := false, false
+
+	setIfEmpty := func(target *string, value string) bool {
+		if *target != "" || value == "" {
+			return false
+		}
+		*target = value
+		return true
+	}
+
+	for _, property := range properties {
+		switch property.name {
+		case "FN":
+			setIfEmpty(&personal.FullName, property.text())
+		case "N":
+			components := property.components(2)
+			setIfEmpty(&personal.LastName, components[0])
+			setIfEmpty(&personal.FirstName, components[1])
+		case "ORG":
+			components := property.components(2)
+			setIfEmpty(&company.Name, components[0])
+			setIfEmpty(&personal.Department, components[1])
+		case "TITLE":
+			setIfEmpty(&personal.JobTitle, property.text())
+		case "EMAIL":
+			email := strings.TrimPrefix(property.text(), "mailto:")
+			if !setIfEmpty(&personal.Email, email) {
+				setIfEmpty(&company.Email, email)
+			}
+		case "TEL":
+			number := strings.TrimPrefix(property.text(), "tel:")
+			if property.hasType("cell", "mobile") && setIfEmpty(&personal.Mobile, number) {
+				continue
+			}
+			if !setIfEmpty(&personal.Phone, number) {
+				setIfEmpty(&company.Phone, number)
+			}
+		case "ADR":
+			// Prefer the work address, as written by WriteVCard
+			if workAddressRead || (addressRead && !property.hasType("work")) {
+				continue
+			}
+			addressRead, workAddressRead = true, property.hasType("work")
+
+			components := property.components(7)
+			street := components[2]
+			if components[1] != "" {
+				street = strings.TrimSpace(street + " " + components[1])
+			}
+			company.Address.Street = street
+			company.Address.City = components[3]
+			company.Address.State = components[4]
+			company.Address.PostalCode = components[5]
+			company.Address.Country = components[6]
+			company.Address.Full = ""
+			if labels := property.params["LABEL"]; len(labels) > 0 {
+				company.Address.Full = strings.NewReplacer("^n", "\n", "^'", `"`, "^^", "^").Replace(labels[0])
+			}
+		case "LABEL":
+			setIfEmpty(&company.Address.Full, property.text())
+		case "URL":
+			website := property.text()
+			if property.hasType("work") || !setIfEmpty(&personal.Website, website) {
+				setIfEmpty(&company.Website, website)
+			}
+		case "X-SOCIALPROFILE":
+			profile := property.text()
+			switch {
+			case property.hasType("linkedin"):
+				if !setIfEmpty(&personal.LinkedIn, profile) {
+					setIfEmpty(&company.SocialMedia.LinkedIn, profile)
+				}
+			case property.hasType("twitter", "x"):
+				setIfEmpty(&company.SocialMedia.Twitter, profile)
+			case property.hasType("facebook"):
+				setIfEmpty(&company.SocialMedia.Facebook, profile)
+			case property.hasType("instagram"):
+				setIfEmpty(&company.SocialMedia.Instagram, profile)
+			}
+		}
+	}
+
+	// WriteVCard puts the full name into N when it has no name parts
+	if personal.FirstName == "" && personal.LastName == personal.FullName {
+		personal.LastName = ""
+	}
+
+	return businessCard
+}
+
+// truncateText shortens a value for error messages
+func truncateText(value string, length int) string {
+	if len(value) <= length {
+		return value
+	}
+	return value[:length] + "..."
+}
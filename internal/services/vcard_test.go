@@ -0,0 +1,250 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"business-card-reader/internal/models"
+)
+
+func TestEscapeVCardText(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"plain text", "plain text"},
+		{"Smith, Jones", `Smith\, Jones`},
+		{"Sales; Marketing", `Sales\; Marketing`},
+		{`C:\Cards`, `C:\\Cards`},
+		{"first\nsecond", `first\nsecond`},
+		{"first\r\nsecond\rthird", `first\nsecond\nthird`},
+		// The backslash is escaped first, so escapes aren't escaped twice
+		{`a\,b`, `a\\\,b`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := escapeVCardText(tt.value); got != tt.want {
+				t.Errorf("escapeVCardText(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteVCardEscapesValues(t *testing.T) {
+	businessCard := &models.BusinessCard{}
+	businessCard.PersonalData.FirstName = "Anna"
+	businessCard.PersonalData.LastName = "Smith; Jones"
+	businessCard.PersonalData.JobTitle = "Head of Sales, EMEA\nand APAC"
+	businessCard.PersonalData.Department = `R\D`
+	businessCard.CompanyData.Name = "Smith, Jones; Partners"
+
+	var buffer bytes.Buffer
+	if err := WriteVCard(&buffer, businessCard, VCardOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"N:Smith\\; Jones;Anna;;;\r\n",
+		"ORG:Smith\\, Jones\\; Partners;R\\\\D\r\n",
+		"TITLE:Head of Sales\\, EMEA\\nand APAC\r\n",
+	} {
+		if !strings.Contains(buffer.String(), want) {
+			t.Errorf("vCard lacks %q:\n%s", want, buffer.String())
+		}
+	}
+}
+
+func TestWriteVCardFoldsLongLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		jobTitle string
+	}{
+		{"ascii", strings.Repeat("Senior Vice President ", 10)},
+		{"multi-byte", strings.Repeat("Geschäftsführerin für Übersee, 営業部長 ", 6)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			businessCard := &models.BusinessCard{}
+			businessCard.PersonalData.FullName = "Anna Smith"
+			businessCard.PersonalData.JobTitle = tt.jobTitle
+
+			var buffer bytes.Buffer
+			if err := WriteVCard(&buffer, businessCard, VCardOptions{}); err != nil {
+				t.Fatal(err)
+			}
+
+			output := buffer.String()
+			if !strings.HasSuffix(output, "\r\n") {
+				t.Error("vCard doesn't end with CRLF")
+			}
+			lines := strings.Split(strings.TrimSuffix(output, "\r\n"), "\r\n")
+			folded := 0
+			for _, line := range lines {
+				if len(line) > vCardLineLength {
+					t.Errorf("line of %d octets: %q", len(line), line)
+				}
+				if !utf8.ValidString(line) {
+					t.Errorf("line splits a UTF-8 sequence: %q", line)
+				}
+				if strings.HasPrefix(line, " ") {
+					folded++
+				}
+			}
+			if folded == 0 {
+				t.Error("long TITLE wasn't folded")
+			}
+
+			unfolded := strings.ReplaceAll(output, "\r\n ", "")
+			if want := "TITLE:" + escapeVCardText(tt.jobTitle) + "\r\n"; !strings.Contains(unfolded, want) {
+				t.Errorf("unfolded vCard lacks %q", want)
+			}
+		})
+	}
+}
+
+func TestWriteVCardVersions(t *testing.T) {
+	businessCard := &models.BusinessCard{ID: "card-1", ProcessedAt: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)}
+	businessCard.PersonalData.FullName = "Anna Smith"
+	businessCard.PersonalData.Phone = "+49 (30) 123-4567"
+	businessCard.PersonalData.Mobile = "+49 171 7654321 ext. 9"
+	businessCard.CompanyData.Address.City = "Berlin"
+	businessCard.CompanyData.Address.Full = "Hauptstraße 5, 10115 Berlin"
+
+	tests := []struct {
+		version string
+		want    []string
+	}{
+		{
+			version: VCardVersion40,
+			want: []string{
+				"VERSION:4.0\r\n",
+				"TEL;TYPE=\"work,voice\";VALUE=uri:tel:+49301234567\r\n",
+				"TEL;TYPE=\"cell,voice\";VALUE=text:+49 171 7654321 ext. 9\r\n",
+				"ADR;TYPE=work;LABEL=\"Hauptstraße 5, 10115 Berlin\":;;;Berlin;;;\r\n",
+				"UID:urn:uuid:card-1\r\n",
+				"REV:20240115T100000Z\r\n",
+			},
+		},
+		{
+			version: VCardVersion30,
+			want: []string{
+				"VERSION:3.0\r\n",
+				"N:Anna Smith;;;;\r\n",
+				"TEL;TYPE=WORK,VOICE:+49 (30) 123-4567\r\n",
+				"ADR;TYPE=WORK:;;;Berlin;;;\r\n",
+				"LABEL;TYPE=WORK:Hauptstraße 5\\, 10115 Berlin\r\n",
+				"UID:card-1\r\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			var buffer bytes.Buffer
+			if err := WriteVCard(&buffer, businessCard, VCardOptions{Version: tt.version}); err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(buffer.String(), want) {
+					t.Errorf("vCard lacks %q:\n%s", want, buffer.String())
+				}
+			}
+		})
+	}
+
+	t.Run("unsupported version", func(t *testing.T) {
+		if err := WriteVCard(&bytes.Buffer{}, businessCard, VCardOptions{Version: "2.1"}); !errors.Is(err, ErrUnsupportedVCardVersion) {
+			t.Errorf("got %v, want ErrUnsupportedVCardVersion", err)
+		}
+	})
+}
+
+func TestExportVCardLoadsPhoto(t *testing.T) {
+	ctx := context.Background()
+	imageStore := newFakeImageStore()
+	if err := imageStore.PutImage(ctx, "images/front.jpg", "image/jpeg", []byte("jpeg bytes")); err != nil {
+		t.Fatal(err)
+	}
+	repository := NewMemoryRepository()
+	businessCard := models.BusinessCard{
+		ID:        "card-1",
+		Status:    models.StatusCompleted,
+		CreatedAt: time.Now(),
+		Images:    []models.ImageData{{FileName: "front.jpg", ContentType: "image/jpg", ObjectKey: "images/front.jpg"}},
+	}
+	businessCard.PersonalData.FullName = "Anna Smith"
+	if err := repository.SaveBusinessCard(ctx, &businessCard); err != nil {
+		t.Fatal(err)
+	}
+	service := NewBusinessCardService(repository, &stubExtractor{})
+	service.SetImageStore(imageStore)
+
+	tests := []struct {
+		options VCardOptions
+		want    string
+	}{
+		{VCardOptions{IncludePhoto: true}, "PHOTO:data:image/jpeg;base64," + base64.StdEncoding.EncodeToString([]byte("jpeg bytes"))},
+		{VCardOptions{Version: VCardVersion30, IncludePhoto: true}, "PHOTO;ENCODING=b;TYPE=JPEG:" + base64.StdEncoding.EncodeToString([]byte("jpeg bytes"))},
+		{VCardOptions{}, ""},
+	}
+
+	for _, tt := range tests {
+		vCard, err := service.ExportVCard(ctx, "card-1", tt.options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tt.want == "" {
+			if strings.Contains(string(vCard), "PHOTO") {
+				t.Errorf("%+v: vCard has a photo:\n%s", tt.options, vCard)
+			}
+			continue
+		}
+		if !strings.Contains(string(vCard), tt.want+"\r\n") {
+			t.Errorf("%+v: vCard lacks %q:\n%s", tt.options, tt.want, vCard)
+		}
+	}
+
+	if _, err := service.ExportVCard(ctx, "missing", VCardOptions{}); !errors.Is(err, ErrBusinessCardNotFound) {
+		t.Errorf("got %v, want ErrBusinessCardNotFound", err)
+	}
+}
+
+func TestExportVCardsStreams(t *testing.T) {
+	ctx := context.Background()
+	repository := NewMemoryRepository()
+	for i, name := range []string{"Anna Smith", "Ben Jones", "Cleo Park"} {
+		businessCard := models.BusinessCard{ID: name, Status: models.StatusCompleted, CreatedAt: time.Now().Add(time.Duration(i) * time.Minute)}
+		businessCard.PersonalData.FullName = name
+		if err := repository.SaveBusinessCard(ctx, &businessCard); err != nil {
+			t.Fatal(err)
+		}
+	}
+	service := NewBusinessCardService(repository, &stubExtractor{})
+
+	var buffer bytes.Buffer
+	count, err := service.ExportVCards(ctx, &buffer, models.BusinessCardQuery{Status: models.StatusCompleted, SortOrder: models.SortOrderAsc}, VCardOptions{Version: VCardVersion30})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 3 || strings.Count(buffer.String(), "BEGIN:VCARD\r\n") != 3 {
+		t.Errorf("exported %d cards:\n%s", count, buffer.String())
+	}
+	if first, last := strings.Index(buffer.String(), "FN:Anna Smith"), strings.Index(buffer.String(), "FN:Cleo Park"); first < 0 || last < first {
+		t.Error("vCards aren't in listing order")
+	}
+
+	t.Run("invalid version writes nothing", func(t *testing.T) {
+		var buffer bytes.Buffer
+		if _, err := service.ExportVCards(ctx, &buffer, models.BusinessCardQuery{}, VCardOptions{Version: "2.1"}); err == nil || buffer.Len() != 0 {
+			t.Errorf("got %v with %d bytes written", err, buffer.Len())
+		}
+	})
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"syscall"
+)
+
+// errWebhookAddressNotAllowed is returned when a webhook receiver resolves to an internal address
+var errWebhookAddressNotAllowed = errors.New("webhook receiver address is not allowed")
+
+// internalNetworks are the internal ranges netip.Addr has no predicate for: "this network" and
+// carrier-grade NAT
+var internalNetworks = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+// webhookAddressPolicy keeps webhook deliveries out of the deployment's own network. Receivers
+// must resolve to public addresses unless their host name or network is allowlisted, as for a CRM
+// running next to the service.
+type webhookAddressPolicy struct {
+	allowedHosts    []string
+	allowedNetworks []netip.Prefix
+	lookup          func(ctx context.Context, host string) ([]netip.Addr, error)
+}
+
+// newWebhookAddressPolicy reads allowlist entries, each a host name, an IP address or a CIDR range
+func newWebhookAddressPolicy(allowed []string) *webhookAddressPolicy {
+	policy := &webhookAddressPolicy{
+		lookup: func(ctx context.Context, host string) ([]netip.Addr, error) {
+			return net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+		},
+	}
+	for _, entry := range allowed {
+		if network, err := netip.ParsePrefix(entry); err == nil {
+			policy.allowedNetworks = append(policy.allowedNetworks, network.Masked())
+		} else if addr, err := netip.ParseAddr(entry); err == nil {
+			policy.allowedNetworks = append(policy.allowedNetworks, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+		} else {
+			policy.allowedHosts = append(policy.allowedHosts, strings.ToLower(entry))
+		}
+	}
+	return policy
+}
+
+// internalAddress reports whether the address is loopback, link-local, private, unspecified or
+// multicast, which covers cloud metadata endpoints such as 169.254.169.254
+func internalAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if addr.IsLoopback() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() || addr.IsPrivate() || addr.IsUnspecified() {
+		return true
+	}
+	for _, network := range internalNetworks {
+		if network.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *webhookAddressPolicy) allowsHost(host string) bool {
+	return slices.Contains(p.allowedHosts, strings.ToLower(host))
+}
+
+func (p *webhookAddressPolicy) allowsAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !internalAddress(addr) {
+		return true
+	}
+	for _, network := range p.allowedNetworks {
+		if network.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkURL resolves the receiver's host at registration and rejects it when any of its addresses
+// is internal
+func (p *webhookAddressPolicy) checkURL(ctx context.Context, receiver *url.URL) error {
+	host := receiver.Hostname()
+	if p.allowsHost(host) {
+		return nil
+	}
+
+	var addrs []netip.Addr
+	if addr, err := netip.ParseAddr(host); err == nil {
+		addrs = []netip.Addr{addr}
+	} else {
+		resolved, err := p.lookup(ctx, host)
+		if err != nil || len(resolved) == 0 {
+			return fmt.Errorf("%w: host %s can't be resolved", ErrInvalidWebhookSubscription, host)
+		}
+		addrs = resolved
+	}
+
+	for _, addr := range addrs {
+		if !p.allowsAddress(addr) {
+			return fmt.Errorf("%w: url resolves to the internal address %s, allow it with WEBHOOK_ALLOWED_HOSTS", ErrInvalidWebhookSubscription, addr.Unmap())
+		}
+	}
+	return nil
+}
+
+// dialContext connects to webhook receivers through the dialer and refuses internal addresses.
+// The check runs on the address actually dialed, so a host that resolves to an internal address
+// after its registration is refused as well.
+func (p *webhookAddressPolicy) dialContext(dialer *net.Dialer) func(ctx context.Context, network string, address string) (net.Conn, error) {
+	return func(ctx context.Context, network string, address string) (net.Conn, error) {
+		if host, _, err := net.SplitHostPort(address); err == nil && p.allowsHost(host) {
+			return dialer.DialContext(ctx, network, address)
+		}
+
+		guarded := *dialer
+		guarded.Control = func(network string, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			addr, err := netip.ParseAddr(host)
+			if err != nil {
+				return err
+			}
+			if !p.allowsAddress(addr) {
+				return fmt.Errorf("%w: %s", errWebhookAddressNotAllowed, addr.Unmap())
+			}
+			return nil
+		}
+		return guarded.DialContext(ctx, network, address)
+	}
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"business-card-reader/internal/config"
+	"business-card-reader/internal/models"
+)
+
+// webhookTestConfig allowlists the receiver host of the tests, which the sandbox can't resolve
+func webhookTestConfig() *config.Config {
+	cfg := &config.Config{}
+	cfg.Webhooks.AllowedHosts = []string{"hooks.example.com"}
+	return cfg
+}
+
+// fakeResolver answers lookups from a fixed table
+func fakeResolver(hosts map[string][]string) func(ctx context.Context, host string) ([]netip.Addr, error) {
+	return func(ctx context.Context, host string) ([]netip.Addr, error) {
+		var addrs []netip.Addr
+		for _, addr := range hosts[host] {
+			addrs = append(addrs, netip.MustParseAddr(addr))
+		}
+		if len(addrs) == 0 {
+			return nil, errors.New("no such host")
+		}
+		return addrs, nil
+	}
+}
+
+func TestWebhookURLValidation(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Webhooks.AllowedHosts = []string{"crm.corp.local", "10.20.0.0/16", "192.168.1.10"}
+	webhookService := NewWebhookService(NewMemoryRepository(), cfg)
+	webhookService.addressPolicy.lookup = fakeResolver(map[string][]string{
+		"crm.example.com":    {"93.184.216.34"},
+		"metadata.example":   {"169.254.169.254"},
+		"rebind.example.com": {"93.184.216.34", "10.0.0.5"},
+		"crm.corp.local":     {"10.0.0.8"},
+		"localhost":          {"127.0.0.1", "::1"},
+		"crm.internal":       {"10.20.1.1"},
+	})
+
+	tests := []struct {
+		url     string
+		allowed bool
+	}{
+		{"https://crm.example.com/hooks", true},
+		{"http://93.184.216.34:8080/hooks", true},
+		{"https://[2606:4700::1111]/hooks", true},
+		{"ftp://crm.example.com/hooks", false},
+		{"/hooks", false},
+		{"https://unknown.example.com/hooks", false},
+		{"http://127.0.0.1/hooks", false},
+		{"http://localhost:8080/hooks", false},
+		{"http://[::1]/hooks", false},
+		{"http://[::ffff:127.0.0.1]/hooks", false},
+		{"http://0.0.0.0/hooks", false},
+		{"http://10.1.2.3/hooks", false},
+		{"http://172.16.0.1/hooks", false},
+		{"http://192.168.0.1/hooks", false},
+		{"http://100.64.0.1/hooks", false},
+		{"http://169.254.169.254/latest/meta-data", false},
+		{"http://[fe80::1]/hooks", false},
+		{"http://[fd00::1]/hooks", false},
+		{"https://metadata.example/hooks", false},
+		{"https://rebind.example.com/hooks", false},
+		{"https://CRM.corp.local/hooks", true},
+		{"https://crm.internal/hooks", true},
+		{"http://192.168.1.10/hooks", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			_, err := webhookService.CreateSubscription(context.Background(), models.WebhookSubscriptionRequest{
+				URL:    tt.url,
+				Events: []string{models.WebhookEventCompleted},
+			})
+			if tt.allowed && err != nil {
+				t.Errorf("got %v, want the subscription", err)
+			}
+			if !tt.allowed && !errors.Is(err, ErrInvalidWebhookSubscription) {
+				t.Errorf("got %v, want ErrInvalidWebhookSubscription", err)
+			}
+		})
+	}
+}
+
+func TestWebhookDeliveryRefusesInternalAddress(t *testing.T) {
+	var received atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+	}))
+	defer receiver.Close()
+
+	tests := []struct {
+		name         string
+		allowedHosts []string
+		delivered    bool
+	}{
+		{"internal receiver", nil, false},
+		{"allowlisted address", []string{"127.0.0.1"}, true},
+		{"allowlisted network", []string{"127.0.0.0/8"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received.Store(0)
+			cfg := &config.Config{}
+			cfg.Webhooks.AllowedHosts = tt.allowedHosts
+			cfg.Webhooks.MaxAttempts = 1
+			repository := NewMemoryRepository()
+			webhookService := NewWebhookService(repository, cfg)
+
+			// Stored directly, as for a host that resolved to a public address at registration
+			subscription := &models.WebhookSubscription{ID: "hook", URL: receiver.URL, Active: true, CreatedAt: time.Now()}
+			if err := repository.SaveWebhookSubscription(context.Background(), subscription); err != nil {
+				t.Fatal(err)
+			}
+			delivery := &models.WebhookDelivery{ID: "delivery", SubscriptionID: "hook", Status: models.DeliveryStatusPending, Payload: []byte(`{}`), CreatedAt: time.Now()}
+			webhookService.attemptDelivery(context.Background(), subscription, delivery)
+
+			if delivered := delivery.Status == models.DeliveryStatusDelivered; delivered != tt.delivered {
+				t.Errorf("delivery status = %s (%s), want delivered %v", delivery.Status, delivery.LastError, tt.delivered)
+			}
+			if !tt.delivered && (received.Load() != 0 || !strings.Contains(delivery.LastError, errWebhookAddressNotAllowed.Error())) {
+				t.Errorf("receiver got %d requests, last error %q", received.Load(), delivery.LastError)
+			}
+		})
+	}
+}
@@ -0,0 +1,547 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"business-card-reader/internal/config"
+	"business-card-reader/internal/logger"
+	"business-card-reader/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Headers sent with every webhook delivery
+const (
+	WebhookHeaderEvent     = "X-Webhook-Event"
+	WebhookHeaderDelivery  = "X-Webhook-Delivery"
+	WebhookHeaderTimestamp = "X-Webhook-Timestamp"
+	WebhookHeaderSignature = "X-Webhook-Signature"
+)
+
+// ErrInvalidWebhookSubscription is returned when a subscription request fails validation
+var ErrInvalidWebhookSubscription = errors.New("invalid webhook subscription")
+
+// webhookEventsByStatus maps business card statuses to the webhook event they trigger
+var webhookEventsByStatus = map[string]string{
+	models.StatusCompleted:   models.WebhookEventCompleted,
+	models.StatusFailed:      models.WebhookEventFailed,
+	models.StatusRetrying:    models.WebhookEventRetried,
+	models.StatusDeadLetter:  models.WebhookEventDeadLettered,
+	models.StatusNeedsReview: models.WebhookEventNeedsReview,
+}
+
+// WebhookService manages webhook subscriptions and delivers business card events to them.
+// Subscriptions belong to a tenant and only receive the events of its business cards.
+// Deliveries are persisted before being sent, so pending ones survive a restart.
+type WebhookService struct {
+	repository     WebhookRepository
+	client         *http.Client
+	addressPolicy  *webhookAddressPolicy
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	pollInterval   time.Duration
+
+	wake     chan struct{}
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func NewWebhookService(repository WebhookRepository, cfg *config.Config) *WebhookService {
+	maxAttempts := cfg.Webhooks.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	addressPolicy := newWebhookAddressPolicy(cfg.Webhooks.AllowedHosts)
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// Deliveries connect to the receiver directly, so that the dialer checks the receiver's address
+	transport.Proxy = nil
+	transport.DialContext = addressPolicy.dialContext(&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second})
+
+	return &WebhookService{
+		repository:     repository,
+		client:         &http.Client{Timeout: cfg.Webhooks.Timeout, Transport: transport},
+		addressPolicy:  addressPolicy,
+		maxAttempts:    maxAttempts,
+		initialBackoff: cfg.Webhooks.InitialBackoff,
+		maxBackoff:     cfg.Webhooks.MaxBackoff,
+		pollInterval:   cfg.Webhooks.PollInterval,
+		wake:           make(chan struct{}, 1),
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+}
+
+// CreateSubscription validates and stores a new subscription for the context's tenant. A
+// signing secret is generated when the request doesn't provide one.
+func (w *WebhookService) CreateSubscription(ctx context.Context, request models.WebhookSubscriptionRequest) (*models.WebhookSubscription, error) {
+	if err := w.validateWebhookURL(ctx, request.URL); err != nil {
+		return nil, err
+	}
+
+	if len(request.Events) == 0 {
+		return nil, fmt.Errorf("%w: at least one event is required", ErrInvalidWebhookSubscription)
+	}
+	for _, event := range request.Events {
+		if !isWebhookEvent(event) {
+			return nil, fmt.Errorf("%w: unsupported event %q", ErrInvalidWebhookSubscription, event)
+		}
+	}
+
+	secret := request.Secret
+	if secret == "" {
+		generated, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	subscription := &models.WebhookSubscription{
+		ID:          uuid.New().String(),
+		TenantID:    TenantFromContext(ctx),
+		URL:         request.URL,
+		Secret:      secret,
+		Events:      request.Events,
+		Description: request.Description,
+		Active:      true,
+		CreatedAt:   time.Now(),
+	}
+
+	if err := w.repository.SaveWebhookSubscription(ctx, subscription); err != nil {
+		logger.LogError("CreateSubscription", err, map[string]interface{}{
+			"url": request.URL,
+		})
+		return nil, fmt.Errorf("failed to save webhook subscription: %w", err)
+	}
+
+	logger.LogInfo("CreateSubscription", "Webhook subscription created", map[string]interface{}{
+		"subscription_id": subscription.ID,
+		"tenant_id":       subscription.TenantID,
+		"url":             subscription.URL,
+		"events":          subscription.Events,
+	})
+
+	return subscription, nil
+}
+
+// GetSubscription returns a subscription of the context's tenant. Other tenants' subscriptions
+// are reported as not found.
+func (w *WebhookService) GetSubscription(ctx context.Context, id string) (*models.WebhookSubscription, error) {
+	subscription, err := w.repository.GetWebhookSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if recordTenant(subscription.TenantID) != TenantFromContext(ctx) {
+		return nil, ErrWebhookSubscriptionNotFound
+	}
+	return subscription, nil
+}
+
+func (w *WebhookService) GetAllSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	subscriptions, err := w.repository.GetAllWebhookSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return subscriptionsOfTenant(subscriptions, TenantFromContext(ctx)), nil
+}
+
+// DeleteSubscription removes a subscription together with its delivery log
+func (w *WebhookService) DeleteSubscription(ctx context.Context, id string) error {
+	if _, err := w.GetSubscription(ctx, id); err != nil {
+		return err
+	}
+
+	if err := w.repository.DeleteWebhookSubscription(ctx, id); err != nil {
+		return err
+	}
+
+	logger.LogInfo("DeleteSubscription", "Webhook subscription deleted", map[string]interface{}{
+		"subscription_id": id,
+	})
+
+	return nil
+}
+
+// GetDeliveries returns the delivery log of a subscription
+func (w *WebhookService) GetDeliveries(ctx context.Context, subscriptionID string) ([]models.WebhookDelivery, error) {
+	if _, err := w.GetSubscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	return w.repository.GetWebhookDeliveriesBySubscription(ctx, subscriptionID)
+}
+
+// OnBusinessCardEvent queues a delivery for every active subscription of the business card's
+// tenant interested in the event
+func (w *WebhookService) OnBusinessCardEvent(ctx context.Context, event models.BusinessCardEvent) {
+	eventType, ok := webhookEventsByStatus[event.Status]
+	if !ok {
+		return
+	}
+
+	tenantID := recordTenant(event.BusinessCard.TenantID)
+	subscriptions, err := w.repository.GetAllWebhookSubscriptions(WithTenant(ctx, tenantID))
+	if err != nil {
+		logger.LogError("OnBusinessCardEvent", err, map[string]interface{}{
+			"step":             "load_subscriptions",
+			"business_card_id": event.BusinessCardID,
+		})
+		return
+	}
+
+	queued := 0
+	for _, subscription := range subscriptionsOfTenant(subscriptions, tenantID) {
+		if !subscription.Active || !slices.Contains(subscription.Events, eventType) {
+			continue
+		}
+
+		deliveryID := uuid.New().String()
+		payload, err := json.Marshal(models.WebhookEvent{
+			ID:           deliveryID,
+			Event:        eventType,
+			CreatedAt:    event.Timestamp,
+			BusinessCard: event.BusinessCard,
+		})
+		if err != nil {
+			logger.LogError("OnBusinessCardEvent", err, map[string]interface{}{
+				"step":             "marshal_payload",
+				"business_card_id": event.BusinessCardID,
+			})
+			return
+		}
+
+		delivery := &models.WebhookDelivery{
+			ID:             deliveryID,
+			SubscriptionID: subscription.ID,
+			Event:          eventType,
+			BusinessCardID: event.BusinessCardID,
+			Payload:        payload,
+			Status:         models.DeliveryStatusPending,
+			NextAttemptAt:  event.Timestamp,
+			CreatedAt:      event.Timestamp,
+		}
+
+		if err := w.repository.SaveWebhookDelivery(ctx, delivery); err != nil {
+			logger.LogError("OnBusinessCardEvent", err, map[string]interface{}{
+				"step":            "save_delivery",
+				"subscription_id": subscription.ID,
+			})
+			continue
+		}
+		queued++
+	}
+
+	if queued > 0 {
+		logger.LogDebug("OnBusinessCardEvent", "Webhook deliveries queued", map[string]interface{}{
+			"business_card_id": event.BusinessCardID,
+			"event":            eventType,
+			"deliveries":       queued,
+		})
+		w.signal()
+	}
+}
+
+// Start launches the background dispatcher that sends due deliveries
+func (w *WebhookService) Start() {
+	go w.run()
+
+	logger.LogInfo("WebhookService", "Webhook dispatcher started", map[string]interface{}{
+		"max_attempts":  w.maxAttempts,
+		"poll_interval": w.pollInterval.String(),
+	})
+}
+
+// Stop ends the dispatcher after the current batch. Undelivered events stay pending
+// in the repository and are picked up again on the next start.
+func (w *WebhookService) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stop)
+		<-w.done
+	})
+}
+
+func (w *WebhookService) signal() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (w *WebhookService) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		w.dispatchDueDeliveries()
+
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+		case <-w.wake:
+		}
+	}
+}
+
+func (w *WebhookService) dispatchDueDeliveries() {
+	ctx := context.Background()
+
+	deliveries, err := w.repository.GetDueWebhookDeliveries(ctx, time.Now())
+	if err != nil {
+		logger.LogError("WebhookDispatcher", err, map[string]interface{}{
+			"step": "load_due_deliveries",
+		})
+		return
+	}
+
+	subscriptions := make(map[string]*models.WebhookSubscription)
+	for i := range deliveries {
+		select {
+		case <-w.stop:
+			return
+		default:
+		}
+
+		delivery := &deliveries[i]
+
+		subscription, ok := subscriptions[delivery.SubscriptionID]
+		if !ok {
+			subscription, err = w.repository.GetWebhookSubscription(ctx, delivery.SubscriptionID)
+			if err != nil && !errors.Is(err, ErrWebhookSubscriptionNotFound) {
+				logger.LogError("WebhookDispatcher", err, map[string]interface{}{
+					"step":            "load_subscription",
+					"subscription_id": delivery.SubscriptionID,
+				})
+				continue
+			}
+			subscriptions[delivery.SubscriptionID] = subscription
+		}
+
+		w.attemptDelivery(ctx, subscription, delivery)
+	}
+}
+
+// attemptDelivery sends a delivery once and records the outcome, scheduling the next
+// attempt with exponential backoff on failure
+func (w *WebhookService) attemptDelivery(ctx context.Context, subscription *models.WebhookSubscription, delivery *models.WebhookDelivery) {
+	now := time.Now()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+
+	var statusCode int
+	var err error
+	if subscription == nil || !subscription.Active {
+		err = errors.New("webhook subscription is no longer active")
+		delivery.Attempts = w.maxAttempts
+	} else {
+		statusCode, err = w.send(ctx, subscription, delivery)
+	}
+	delivery.LastStatusCode = statusCode
+
+	switch {
+	case err == nil:
+		delivery.Status = models.DeliveryStatusDelivered
+		delivery.DeliveredAt = &now
+		delivery.LastError = ""
+	case delivery.Attempts >= w.maxAttempts:
+		delivery.Status = models.DeliveryStatusFailed
+		delivery.LastError = err.Error()
+	default:
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = now.Add(exponentialBackoff(w.initialBackoff, w.maxBackoff, delivery.Attempts))
+	}
+
+	logger.LogInfo("WebhookDispatcher", "Webhook delivery attempted", map[string]interface{}{
+		"delivery_id":     delivery.ID,
+		"subscription_id": delivery.SubscriptionID,
+		"event":           delivery.Event,
+		"attempt":         delivery.Attempts,
+		"status":          delivery.Status,
+		"status_code":     statusCode,
+		"error":           delivery.LastError,
+	})
+
+	if err := w.repository.SaveWebhookDelivery(ctx, delivery); err != nil {
+		logger.LogError("WebhookDispatcher", err, map[string]interface{}{
+			"step":        "save_delivery",
+			"delivery_id": delivery.ID,
+		})
+	}
+}
+
+func (w *WebhookService) send(ctx context.Context, subscription *models.WebhookSubscription, delivery *models.WebhookDelivery) (int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "business-card-reader-webhooks")
+	req.Header.Set(WebhookHeaderEvent, delivery.Event)
+	req.Header.Set(WebhookHeaderDelivery, delivery.ID)
+	req.Header.Set(WebhookHeaderTimestamp, timestamp)
+	req.Header.Set(WebhookHeaderSignature, SignWebhookPayload(subscription.Secret, timestamp, delivery.Payload))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook endpoint returned status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// SignWebhookPayload computes the X-Webhook-Signature header value. Receivers verify a
+// delivery by computing the HMAC-SHA256 of "<timestamp>.<body>" with the subscription secret.
+func SignWebhookPayload(secret string, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func subscriptionsOfTenant(subscriptions []models.WebhookSubscription, tenantID string) []models.WebhookSubscription {
+	var scoped []models.WebhookSubscription
+	for _, subscription := range subscriptions {
+		if recordTenant(subscription.TenantID) == tenantID {
+			scoped = append(scoped, subscription)
+		}
+	}
+	return scoped
+}
+
+// validateWebhookURL accepts absolute http(s) URLs whose host resolves to public addresses only,
+// unless the host or its network is allowlisted
+func (w *WebhookService) validateWebhookURL(ctx context.Context, rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http(s) URL", ErrInvalidWebhookSubscription)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf("%w: url must be an absolute http(s) URL", ErrInvalidWebhookSubscription)
+	}
+	return w.addressPolicy.checkURL(ctx, parsed)
+}
+
+func isWebhookEvent(event string) bool {
+	for _, supported := range webhookEventsByStatus {
+		if event == supported {
+			return true
+		}
+	}
+	return false
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// RedactDeliveries replaces the payloads of the context's tenant's deliveries that carry one of
+// the business cards, or a card with the email, by a RedactedWebhookEvent. Pending deliveries are
+// cancelled, since their receiver would only get the redacted payload.
+func (w *WebhookService) RedactDeliveries(ctx context.Context, businessCardIDs []string, email string) (int, error) {
+	subscriptions, err := w.GetAllSubscriptions(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load webhook subscriptions: %w", err)
+	}
+
+	redacted := 0
+	for _, subscription := range subscriptions {
+		deliveries, err := w.repository.GetWebhookDeliveriesBySubscription(ctx, subscription.ID)
+		if err != nil {
+			return redacted, fmt.Errorf("failed to load webhook deliveries: %w", err)
+		}
+
+		for i := range deliveries {
+			delivery := &deliveries[i]
+			if !deliveryCarries(delivery, businessCardIDs, email) {
+				continue
+			}
+
+			payload, err := json.Marshal(models.RedactedWebhookEvent{
+				ID:             delivery.ID,
+				Event:          delivery.Event,
+				CreatedAt:      delivery.CreatedAt,
+				BusinessCardID: delivery.BusinessCardID,
+				Redacted:       true,
+			})
+			if err != nil {
+				return redacted, fmt.Errorf("failed to marshal redacted payload: %w", err)
+			}
+			delivery.Payload = payload
+			if delivery.Status == models.DeliveryStatusPending {
+				delivery.Status = models.DeliveryStatusFailed
+				delivery.LastError = "business card erased before delivery"
+			}
+
+			if err := w.repository.SaveWebhookDelivery(ctx, delivery); err != nil {
+				return redacted, fmt.Errorf("failed to save redacted delivery: %w", err)
+			}
+			redacted++
+		}
+	}
+
+	logger.LogInfo("RedactDeliveries", "Webhook deliveries redacted", map[string]interface{}{
+		"business_card_ids": businessCardIDs,
+		"deliveries":        redacted,
+	})
+
+	return redacted, nil
+}
+
+// deliveryCarries reports whether a delivery's payload still holds one of the business cards or a
+// card with the email. Payloads that were redacted already don't.
+func deliveryCarries(delivery *models.WebhookDelivery, businessCardIDs []string, email string) bool {
+	var event struct {
+		models.WebhookEvent
+		Redacted bool `json:"redacted"`
+	}
+	matchesID := slices.Contains(businessCardIDs, delivery.BusinessCardID)
+	if err := json.Unmarshal(delivery.Payload, &event); err != nil {
+		return matchesID
+	}
+	if event.Redacted {
+		return false
+	}
+	if matchesID {
+		return true
+	}
+
+	businessCard := event.BusinessCard
+	return email != "" &&
+		(strings.EqualFold(strings.TrimSpace(businessCard.PersonalData.Email), email) ||
+			strings.EqualFold(strings.TrimSpace(businessCard.CompanyData.Email), email))
+}
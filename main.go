@@ -1,14 +1,23 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	"business-card-reader/docs"
 	"business-card-reader/internal/config"
 	"business-card-reader/internal/handlers"
 	"business-card-reader/internal/logger"
+	"business-card-reader/internal/middleware"
+	"business-card-reader/internal/models"
 	"business-card-reader/internal/services"
 
 	"github.com/gin-gonic/gin"
@@ -22,7 +31,17 @@ import (
 // @description API for processing business cards using Gemini AI
 // @host localhost:8080
 // @BasePath /api/v1
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT from the identity provider as "Bearer <token>" when AUTH_MODE is jwt
 func main() {
+	migrate := flag.Bool("migrate", false, "Upgrade the storage backend (e.g. create DynamoDB secondary indexes and backfill existing items), then exit")
+	flag.Parse()
+
 	// Initialize logger first
 	logger.Init()
 
@@ -32,7 +51,17 @@ func main() {
 	// Log environment variables for debugging (mask sensitive values)
 	log.Println("Loaded environment variables:")
 	for _, key := range []string{
-		"GEMINI_API_KEY", "GEMINI_MODEL_NAME", "AWS_REGION", "AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "DYNAMODB_TABLE_NAME", "PORT", "GIN_MODE", "AWS_ENDPOINT_URL"} {
+		"GEMINI_API_KEY", "GEMINI_MODEL_NAME", "AWS_REGION", "AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "DYNAMODB_TABLE_NAME", "PORT", "GIN_MODE", "AWS_ENDPOINT_URL",
+		"STORAGE_BACKEND", "SQLITE_PATH", "EXTRACTION_PROVIDER",
+		"IMAGE_STORE", "IMAGE_STORE_PATH", "S3_BUCKET", "S3_REGION", "S3_ENDPOINT", "S3_USE_PATH_STYLE",
+		"OPENAI_BASE_URL", "OPENAI_API_KEY", "OPENAI_MODEL_NAME", "OLLAMA_BASE_URL", "OLLAMA_MODEL_NAME",
+		"OCR_FALLBACK_ENABLED", "TESSERACT_PATH", "TESSERACT_LANGUAGE",
+		"PROCESSING_MODE", "PROCESSING_WORKERS", "PROCESSING_QUEUE_SIZE",
+		"RETRY_SCHEDULER_ENABLED", "RETRY_MAX_ATTEMPTS", "RETRY_INITIAL_BACKOFF", "RETRY_MAX_BACKOFF", "RETRY_SCHEDULER_INTERVAL",
+		"WEBHOOK_MAX_ATTEMPTS", "WEBHOOK_INITIAL_BACKOFF", "WEBHOOK_MAX_BACKOFF", "WEBHOOK_POLL_INTERVAL", "WEBHOOK_TIMEOUT", "WEBHOOK_DELIVERY_RETENTION", "WEBHOOK_ALLOWED_HOSTS",
+		"PHONE_DEFAULT_REGION", "POST_PROCESSORS", "CONFIDENCE_THRESHOLD", "AUTH_MODE", "API_ADMIN_KEY",
+		"JWT_JWKS_URL", "JWT_KEY_FILE", "JWT_ISSUER", "JWT_AUDIENCE", "JWT_ROLES_CLAIM", "JWT_TENANT_CLAIM", "JWT_ROLE_MAPPING",
+		"RATE_LIMIT_KEY_PER_MINUTE", "RATE_LIMIT_KEY_BURST", "RATE_LIMIT_IP_PER_MINUTE", "RATE_LIMIT_IP_BURST", "EXTRACTION_MAX_CONCURRENCY", "EXTRACTION_SLOT_WAIT", "TRUSTED_PROXIES"} {
 		val := os.Getenv(key)
 		if val == "" {
 			log.Printf("  %s=NOT SET", key)
@@ -55,29 +84,123 @@ func main() {
 	}
 
 	// Initialize services
-	dynamoService, err := services.NewDynamoService(cfg.AWS.Region)
+	repository, err := services.NewRepository(cfg)
 	if err != nil {
 		logger.LogError("main", err, map[string]interface{}{
-			"step": "initialize_dynamo_service",
+			"step":    "initialize_repository",
+			"backend": cfg.Storage.Backend,
 		})
-		log.Fatal("Failed to initialize DynamoDB service:", err)
+		log.Fatal("Failed to initialize repository:", err)
 	}
 
-	geminiService, err := services.NewGeminiService(cfg.Gemini.APIKey, cfg.Gemini.ModelName)
+	if *migrate {
+		migrator, ok := repository.(services.Migrator)
+		if !ok {
+			log.Printf("Storage backend %s has nothing to migrate", cfg.Storage.Backend)
+			return
+		}
+		if err := migrator.Migrate(context.Background()); err != nil {
+			log.Fatal("Failed to migrate storage:", err)
+		}
+		log.Println("Storage migration completed")
+		return
+	}
+
+	extractor, err := services.NewExtractor(cfg)
 	if err != nil {
 		logger.LogError("main", err, map[string]interface{}{
-			"step": "initialize_gemini_service",
+			"step":     "initialize_extractor",
+			"provider": cfg.Extraction.Provider,
 		})
-		log.Fatal("Failed to initialize Gemini service:", err)
+		log.Fatal("Failed to initialize extraction provider:", err)
+	}
+
+	businessCardService := services.NewBusinessCardService(repository, extractor)
+
+	if cfg.OCR.FallbackEnabled && extractor.Name() != services.ExtractorTesseract {
+		fallbackExtractor, err := services.NewTesseractExtractor(cfg.OCR.TesseractPath, cfg.OCR.Language)
+		if err != nil {
+			logger.LogError("main", err, map[string]interface{}{
+				"step": "initialize_fallback_extractor",
+			})
+			log.Fatal("Failed to initialize OCR fallback extractor:", err)
+		}
+		businessCardService.SetFallbackExtractor(fallbackExtractor)
+	}
+
+	postProcessors, err := services.NewPostProcessors(cfg)
+	if err != nil {
+		logger.LogError("main", err, map[string]interface{}{
+			"step": "initialize_post_processors",
+		})
+		log.Fatal("Failed to initialize post-processors:", err)
+	}
+	businessCardService.SetPostProcessors(postProcessors)
+	businessCardService.SetConfidenceThreshold(cfg.Extraction.ConfidenceThreshold)
+	if cfg.RateLimit.MaxConcurrentExtractions > 0 {
+		businessCardService.SetExtractionConcurrency(cfg.RateLimit.MaxConcurrentExtractions, cfg.RateLimit.ExtractionWait)
+	}
+
+	imageStore, err := services.NewImageStore(context.Background(), cfg)
+	if err != nil {
+		logger.LogError("main", err, map[string]interface{}{
+			"step":        "initialize_image_store",
+			"image_store": cfg.ImageStore.Backend,
+		})
+		log.Fatal("Failed to initialize image store:", err)
+	}
+	if imageStore != nil {
+		businessCardService.SetImageStore(imageStore)
+	}
+
+	apiKeyService := services.NewAPIKeyService(repository)
+	authenticator, err := middleware.NewAuthenticator(cfg, apiKeyService)
+	if err != nil {
+		log.Fatal("Failed to initialize authentication:", err)
 	}
+	rateLimiter := middleware.NewRateLimiter(cfg)
 
-	businessCardService := services.NewBusinessCardService(dynamoService, geminiService)
+	webhookService := services.NewWebhookService(repository, cfg)
+	businessCardService.AddListener(webhookService)
+	businessCardService.SetDeliveryRedactor(webhookService)
+
+	eventBroker := services.NewEventBroker()
+	businessCardService.AddListener(eventBroker)
+
+	if err := businessCardService.InitializeDatabase(context.Background()); err != nil {
+		log.Fatal("Failed to initialize database:", err)
+	}
+
+	webhookService.Start()
+
+	if cfg.Processing.Mode == "async" {
+		if err := businessCardService.EnableAsyncProcessing(context.Background(), cfg.Processing.Workers, cfg.Processing.QueueSize); err != nil {
+			logger.LogError("main", err, map[string]interface{}{
+				"step": "enable_async_processing",
+			})
+			log.Fatal("Failed to enable async processing:", err)
+		}
+	}
+
+	var retryScheduler *services.RetryScheduler
+	if cfg.Retry.SchedulerEnabled {
+		retryScheduler = services.NewRetryScheduler(businessCardService, cfg)
+		retryScheduler.Start()
+	}
 
 	// Initialize handlers
 	handler := handlers.NewBusinessCardHandler(businessCardService)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	eventHandler := handlers.NewEventHandler(businessCardService, eventBroker)
+	reviewHandler := handlers.NewReviewHandler(businessCardService)
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyService)
 
 	// Setup router
 	router := gin.Default()
+	// Only trusted proxies can set the client IP, which the rate limits are keyed by
+	if err := router.SetTrustedProxies(cfg.RateLimit.TrustedProxies); err != nil {
+		log.Fatal("Invalid TRUSTED_PROXIES:", err)
+	}
 
 	// Add request logging middleware
 	router.Use(func(c *gin.Context) {
@@ -105,8 +228,8 @@ func main() {
 	// Add CORS middleware
 	router.Use(func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, access_token")
+		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key, X-User")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -127,14 +250,49 @@ func main() {
 	// Add Swagger endpoint
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	// Routes
-	api := router.Group("/api/v1")
+	// Routes. Every route requires a caller with the given role unless AUTH_MODE is none.
+	viewer := authenticator.RequireRole(models.RoleViewer)
+	scanner := authenticator.RequireRole(models.RoleScanner)
+	reviewer := authenticator.RequireRole(models.RoleReviewer)
+	admin := authenticator.RequireRole(models.RoleAdmin)
+	// Routes that run an extraction are rate limited per API key and per client IP
+	rateLimited := rateLimiter.Limit()
+
+	api := router.Group("/api/v1", authenticator.Authenticate())
 	{
-		api.POST("/business-cards", handler.ProcessBusinessCard)
-		api.GET("/business-cards", handler.GetBusinessCards)
-		api.GET("/business-cards/:id", handler.GetBusinessCardByID)
-		api.POST("/business-cards/:id/retry", handler.RetryFailedBusinessCard)
-		api.GET("/business-cards/failed", handler.GetFailedBusinessCards)
+		api.POST("/business-cards", scanner, rateLimited, handler.ProcessBusinessCard)
+		api.GET("/business-cards", viewer, handler.GetBusinessCards)
+		api.GET("/business-cards/:id", viewer, handler.GetBusinessCardByID)
+		api.PATCH("/business-cards/:id", reviewer, handler.UpdateBusinessCard)
+		api.DELETE("/business-cards/:id", scanner, handler.DeleteBusinessCard)
+		api.POST("/business-cards/erasure", admin, handler.EraseBusinessCards)
+		api.GET("/business-cards/erasure", admin, handler.GetErasureReceipts)
+		api.GET("/business-cards/erasure/:id", admin, handler.GetErasureReceipt)
+		api.POST("/business-cards/import", scanner, handler.ImportBusinessCards)
+		api.GET("/business-cards/vcard", viewer, handler.ExportBusinessCardsVCard)
+		api.GET("/business-cards/export", viewer, handler.ExportBusinessCards)
+		api.GET("/business-cards/:id/vcard", viewer, handler.ExportBusinessCardVCard)
+		api.GET("/business-cards/:id/duplicates", viewer, handler.GetBusinessCardDuplicates)
+		api.POST("/business-cards/:id/merge", reviewer, handler.MergeBusinessCards)
+		api.POST("/business-cards/:id/retry", scanner, rateLimited, handler.RetryFailedBusinessCard)
+		api.GET("/business-cards/failed", viewer, handler.GetFailedBusinessCards)
+		api.GET("/business-cards/dead-letter", viewer, handler.GetDeadLetterBusinessCards)
+		api.GET("/business-cards/events", viewer, eventHandler.StreamAllEvents)
+		api.GET("/business-cards/:id/events", viewer, eventHandler.StreamBusinessCardEvents)
+
+		api.GET("/review-queue", reviewer, reviewHandler.GetReviewQueue)
+		api.POST("/review-queue/:id/claim", reviewer, reviewHandler.ClaimReview)
+		api.POST("/review-queue/:id/approve", reviewer, reviewHandler.ApproveReview)
+
+		api.POST("/api-keys", admin, apiKeyHandler.CreateAPIKey)
+		api.GET("/api-keys", admin, apiKeyHandler.GetAPIKeys)
+		api.DELETE("/api-keys/:id", admin, apiKeyHandler.RevokeAPIKey)
+
+		api.POST("/webhooks", admin, webhookHandler.CreateWebhook)
+		api.GET("/webhooks", admin, webhookHandler.GetWebhooks)
+		api.GET("/webhooks/:id", admin, webhookHandler.GetWebhookByID)
+		api.DELETE("/webhooks/:id", admin, webhookHandler.DeleteWebhook)
+		api.GET("/webhooks/:id/deliveries", admin, webhookHandler.GetWebhookDeliveries)
 	}
 
 	// Health check
@@ -152,6 +310,38 @@ func main() {
 		"gin_mode": os.Getenv("GIN_MODE"),
 	})
 
-	log.Printf("Server starting on port %s", port)
-	log.Fatal(router.Run(":" + port))
+	server := &http.Server{
+		Addr:    ":" + port,
+		Handler: router,
+	}
+	// Event streams never finish on their own, end them so Shutdown doesn't wait for its timeout
+	server.RegisterOnShutdown(eventBroker.Close)
+
+	go func() {
+		log.Printf("Server starting on port %s", port)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal(err)
+		}
+	}()
+
+	// Wait for a termination signal, then let in-flight requests and queued cards finish
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	logger.LogInfo("main", "Shutting down server", map[string]interface{}{})
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.LogError("main", err, map[string]interface{}{
+			"step": "shutdown_server",
+		})
+	}
+	if retryScheduler != nil {
+		retryScheduler.Stop()
+	}
+	businessCardService.Shutdown()
+	webhookService.Stop()
 }